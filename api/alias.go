@@ -0,0 +1,270 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/qiwitech/qdp/proto/apipb"
+	"github.com/qiwitech/qdp/proto/gatepb"
+	"github.com/qiwitech/qdp/proto/metadbpb"
+	"github.com/qiwitech/qdp/pt"
+)
+
+// MaxAliasLen is a maximum alias length in bytes
+const MaxAliasLen = 128
+
+var aliasAccountField = []byte("account")
+
+// RegisterAlias binds alias to account.
+// Alias is case insensitive, it's owned by the first account registered it.
+// Ownership is proven by the account signing key, so account must have public key set.
+// Registration is not atomic across many plutoapi instances sharing the same metadb.
+func (s *Service) RegisterAlias(ctx context.Context, req *apipb.RegisterAliasRequest) (*apipb.RegisterAliasResponse, error) {
+	if s.metadb == nil {
+		return nil, ErrMetaIsNotAvailable
+	}
+
+	res := &apipb.RegisterAliasResponse{Status: &apipb.Status{}}
+
+	alias := normalizeAlias(req.Alias)
+	if msg := checkNewAlias(alias); msg != "" {
+		res.Status.Code = apipb.TransferCode_BAD_REQUEST
+		res.Status.Message = msg
+		return res, nil
+	}
+	if req.Account == 0 {
+		res.Status.Code = apipb.TransferCode_BAD_REQUEST
+		res.Status.Message = "account must be specified"
+		return res, nil
+	}
+
+	sett, err := s.gate.GetLastSettings(ctx, &gatepb.GetLastSettingsRequest{Account: req.Account})
+	if err != nil {
+		return nil, errors.Wrap(err, "api")
+	}
+	if sett.Status != nil && sett.Status.Code != 0 {
+		res.Status.Code = apipb.TransferCode(sett.Status.Code)
+		res.Status.Message = sett.Status.Message
+		return res, nil
+	}
+	if sett.Closed {
+		res.Status.Code = apipb.TransferCode_ACCOUNT_CLOSED
+		res.Status.Message = "account is closed"
+		return res, nil
+	}
+
+	if err := verifyAliasSign(alias, req, sett.PublicKey); err != nil {
+		res.Status.Code = apipb.TransferCode_INVALID_SIGN
+		res.Status.Message = err.Error()
+		return res, nil
+	}
+
+	s.aliasMu.Lock()
+	defer s.aliasMu.Unlock()
+
+	accs, err := s.resolveAliases(ctx, []string{alias})
+	if err != nil {
+		res.Status.Code = apipb.TransferCode_METADATA_ERROR
+		res.Status.Message = err.Error()
+		return res, nil
+	}
+	switch accs[0] {
+	case req.Account:
+		return res, nil
+	case 0:
+	default:
+		res.Status.Code = apipb.TransferCode_ALIAS_TAKEN
+		res.Status.Message = fmt.Sprintf("alias %q is taken", alias)
+		return res, nil
+	}
+
+	acc := tobytes(req.Account)
+	d := &metadbpb.Data{
+		Key:    []byte(alias),
+		Fields: []*metadbpb.Pair{{Key: aliasAccountField, Value: acc}},
+		Index:  []*metadbpb.Pair{{Key: aliasAccountField, Value: acc}},
+	}
+	resp, err := s.metadb.Put(ctx, &metadbpb.PutRequest{Prefix: AliasMetaPrefix, Data: d})
+	if err != nil {
+		return nil, errors.Wrap(err, "meta")
+	}
+	if resp.Status.Code != metadbpb.DBStatusCode_OK {
+		res.Status.Code = apipb.TransferCode_METADATA_ERROR
+		res.Status.Message = resp.Status.Message
+		return res, nil
+	}
+
+	return res, nil
+}
+
+// ResolveAlias returns account alias is bound to
+func (s *Service) ResolveAlias(ctx context.Context, req *apipb.ResolveAliasRequest) (*apipb.ResolveAliasResponse, error) {
+	if s.metadb == nil {
+		return nil, ErrMetaIsNotAvailable
+	}
+
+	res := &apipb.ResolveAliasResponse{Status: &apipb.Status{}}
+
+	alias := normalizeAlias(req.Alias)
+	if msg := checkAlias(alias); msg != "" {
+		res.Status.Code = apipb.TransferCode_BAD_REQUEST
+		res.Status.Message = msg
+		return res, nil
+	}
+
+	accs, err := s.resolveAliases(ctx, []string{alias})
+	if err != nil {
+		res.Status.Code = apipb.TransferCode_METADATA_ERROR
+		res.Status.Message = err.Error()
+		return res, nil
+	}
+	if accs[0] == 0 {
+		res.Status.Code = apipb.TransferCode_ALIAS_NOT_FOUND
+		res.Status.Message = fmt.Sprintf("alias %q not found", alias)
+		return res, nil
+	}
+
+	res.Account = accs[0]
+
+	return res, nil
+}
+
+// resolveReceivers returns transfer items with aliases replaced by accounts.
+// Signed transfers can't use aliases since sign covers receiver accounts, not aliases.
+// Nil status is returned on success.
+func (s *Service) resolveReceivers(ctx context.Context, batch []*apipb.TransferItem, signed bool) ([]*gatepb.TransferItem, *apipb.Status, error) {
+	res := make([]*gatepb.TransferItem, len(batch))
+
+	var aliases []string
+	var idx []int
+	for i, r := range batch {
+		res[i] = &gatepb.TransferItem{Receiver: r.Receiver, Amount: r.Amount}
+		if r.Alias == "" {
+			continue
+		}
+		if r.Receiver != 0 {
+			return nil, &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "both receiver and alias specified"}, nil
+		}
+		if signed {
+			return nil, &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "signed transfer must specify receiver accounts, resolve aliases first"}, nil
+		}
+		aliases = append(aliases, normalizeAlias(r.Alias))
+		idx = append(idx, i)
+	}
+
+	if len(aliases) == 0 {
+		return res, nil, nil
+	}
+	if s.metadb == nil {
+		return nil, nil, ErrMetaIsNotAvailable
+	}
+
+	accs, err := s.resolveAliases(ctx, aliases)
+	if err != nil {
+		return nil, &apipb.Status{Code: apipb.TransferCode_METADATA_ERROR, Message: err.Error()}, nil
+	}
+
+	for j, acc := range accs {
+		if acc == 0 {
+			return nil, &apipb.Status{Code: apipb.TransferCode_ALIAS_NOT_FOUND, Message: fmt.Sprintf("alias %q not found", aliases[j])}, nil
+		}
+		res[idx[j]].Receiver = acc
+	}
+
+	return res, nil, nil
+}
+
+// resolveAliases returns accounts for normalized aliases. Zero account is returned for unknown alias
+func (s *Service) resolveAliases(ctx context.Context, aliases []string) ([]uint64, error) {
+	keys := make([][]byte, len(aliases))
+	for i, a := range aliases {
+		keys[i] = []byte(a)
+	}
+
+	resp, err := s.metadb.GetMulti(ctx, &metadbpb.GetMultiRequest{Prefix: AliasMetaPrefix, Keys: keys})
+	if err != nil {
+		return nil, errors.Wrap(err, "metadb")
+	}
+	if resp.Status == nil {
+		return nil, errors.New("metadb: no status received")
+	}
+	if resp.Status.Code != metadbpb.DBStatusCode_OK {
+		return nil, errors.New("metadb: " + resp.Status.Message)
+	}
+
+	accs := make([]uint64, len(aliases))
+	for _, d := range resp.Results {
+		if d == nil {
+			continue
+		}
+		for _, p := range d.Fields {
+			if !bytes.Equal(p.Key, aliasAccountField) || len(p.Value) != 8 {
+				continue
+			}
+			for i, k := range keys {
+				if bytes.Equal(k, d.Key) {
+					unbytes(p.Value, &accs[i])
+				}
+			}
+		}
+	}
+
+	return accs, nil
+}
+
+// verifyAliasSign checks sign made over normalized alias
+func verifyAliasSign(alias string, req *apipb.RegisterAliasRequest, pubkey string) error {
+	if pubkey == "" {
+		return errors.New("account has no public key")
+	}
+	pk, err := pt.ParsePubKey(pubkey)
+	if err != nil {
+		return errors.Wrap(err, "public key")
+	}
+	key, err := pk.BTCECKey()
+	if err != nil {
+		return errors.Wrap(err, "public key")
+	}
+
+	sign, err := pt.GetSignFromString(req.Sign)
+	if err != nil {
+		return errors.Wrap(err, "sign")
+	}
+
+	hash := pt.GetAliasHashDefault(alias, pt.AccID(req.Account))
+
+	return pt.VerifyTransferHash(sign, hash, key)
+}
+
+func normalizeAlias(a string) string {
+	return pt.NormalizeAlias(a)
+}
+
+func checkAlias(a string) string {
+	switch {
+	case a == "":
+		return "alias must be specified"
+	case len(a) > MaxAliasLen:
+		return fmt.Sprintf("alias is too long: %d > %d", len(a), MaxAliasLen)
+	}
+	if _, err := strconv.ParseUint(a, 10, 64); err == nil {
+		return "alias must not be a number"
+	}
+	return ""
+}
+
+// checkNewAlias also rejects hex numbers, clients with hex account numbers would take them for accounts.
+// Aliases registered before are still resolved.
+func checkNewAlias(a string) string {
+	if msg := checkAlias(a); msg != "" {
+		return msg
+	}
+	if _, err := strconv.ParseUint(a, 16, 64); err == nil {
+		return "alias must not be a hex number"
+	}
+	return ""
+}
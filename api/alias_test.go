@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/mocks"
+	"github.com/qiwitech/qdp/proto/apipb"
+	"github.com/qiwitech/qdp/proto/gatepb"
+	"github.com/qiwitech/qdp/proto/metadbpb"
+	"github.com/qiwitech/qdp/pt"
+)
+
+func aliasData(alias string, acc uint64) *metadbpb.Data {
+	return &metadbpb.Data{
+		Key:    []byte(alias),
+		Fields: []*metadbpb.Pair{{Key: []byte("account"), Value: tobytes(acc)}},
+		Index:  []*metadbpb.Pair{{Key: []byte("account"), Value: tobytes(acc)}},
+	}
+}
+
+func TestRegisterAlias(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+	meta := mocks.NewMockMetaDBServiceInterface(mock)
+
+	g := NewService(proc)
+	g.SetMetaDBClient(meta)
+
+	priv, err := btcec.NewPrivateKey(pt.SigningCurve)
+	assert.NoError(t, err)
+	pub := pt.PublicKey(priv.PubKey().SerializeCompressed()).String()
+
+	sign, err := pt.SignTransfer(pt.GetAliasHashDefault("alice", 10), priv)
+	assert.NoError(t, err)
+
+	proc.EXPECT().GetLastSettings(gomock.Any(), &gatepb.GetLastSettingsRequest{Account: 10}).Return(
+		&gatepb.GetLastSettingsResponse{Status: &gatepb.Status{}, Account: 10, PublicKey: pub}, nil).Times(3)
+
+	// invalid sign
+	res, err := g.RegisterAlias(context.TODO(), &apipb.RegisterAliasRequest{Alias: "Alice", Account: 10, Sign: pt.Sign{}.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, apipb.TransferCode_INVALID_SIGN, res.Status.Code)
+
+	// ok
+	meta.EXPECT().GetMulti(gomock.Any(), &metadbpb.GetMultiRequest{Prefix: AliasMetaPrefix, Keys: [][]byte{[]byte("alice")}}).Return(
+		&metadbpb.GetMultiResponse{Status: &metadbpb.Status{}, Results: []*metadbpb.Data{{Key: []byte("alice")}}}, nil)
+	meta.EXPECT().Put(gomock.Any(), &metadbpb.PutRequest{Prefix: AliasMetaPrefix, Data: aliasData("alice", 10)}).Return(
+		&metadbpb.PutResponse{Status: &metadbpb.Status{}}, nil)
+
+	res, err = g.RegisterAlias(context.TODO(), &apipb.RegisterAliasRequest{Alias: "Alice", Account: 10, Sign: sign.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.RegisterAliasResponse{Status: &apipb.Status{}}, res)
+
+	// taken by another account
+	meta.EXPECT().GetMulti(gomock.Any(), gomock.Any()).Return(
+		&metadbpb.GetMultiResponse{Status: &metadbpb.Status{}, Results: []*metadbpb.Data{aliasData("alice", 20)}}, nil)
+
+	res, err = g.RegisterAlias(context.TODO(), &apipb.RegisterAliasRequest{Alias: "Alice", Account: 10, Sign: sign.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, apipb.TransferCode_ALIAS_TAKEN, res.Status.Code)
+}
+
+func TestRegisterAliasNormalized(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+	meta := mocks.NewMockMetaDBServiceInterface(mock)
+
+	g := NewService(proc)
+	g.SetMetaDBClient(meta)
+
+	priv, err := btcec.NewPrivateKey(pt.SigningCurve)
+	assert.NoError(t, err)
+	pub := pt.PublicKey(priv.PubKey().SerializeCompressed()).String()
+
+	proc.EXPECT().GetLastSettings(gomock.Any(), &gatepb.GetLastSettingsRequest{Account: 10}).Return(
+		&gatepb.GetLastSettingsResponse{Status: &gatepb.Status{}, Account: 10, PublicKey: pub}, nil).AnyTimes()
+
+	for _, alias := range []string{"Bob@Example.com", " bob@example.com\t", "  BOB@EXAMPLE.COM "} {
+		// sign of raw alias is not accepted
+		raw, err := pt.SignTransfer(pt.GetAliasHashDefault(alias, 10), priv)
+		assert.NoError(t, err)
+
+		res, err := g.RegisterAlias(context.TODO(), &apipb.RegisterAliasRequest{Alias: alias, Account: 10, Sign: raw.String()})
+		assert.NoError(t, err)
+		assert.Equal(t, apipb.TransferCode_INVALID_SIGN, res.Status.Code, "alias %q", alias)
+
+		sign, err := pt.SignTransfer(pt.GetAliasHashDefault(pt.NormalizeAlias(alias), 10), priv)
+		assert.NoError(t, err)
+
+		meta.EXPECT().GetMulti(gomock.Any(), &metadbpb.GetMultiRequest{Prefix: AliasMetaPrefix, Keys: [][]byte{[]byte("bob@example.com")}}).Return(
+			&metadbpb.GetMultiResponse{Status: &metadbpb.Status{}, Results: []*metadbpb.Data{aliasData("bob@example.com", 10)}}, nil)
+
+		res, err = g.RegisterAlias(context.TODO(), &apipb.RegisterAliasRequest{Alias: alias, Account: 10, Sign: sign.String()})
+		assert.NoError(t, err)
+		assert.Equal(t, &apipb.RegisterAliasResponse{Status: &apipb.Status{}}, res, "alias %q", alias)
+	}
+}
+
+func TestRegisterAliasBadRequest(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+	meta := mocks.NewMockMetaDBServiceInterface(mock)
+
+	g := NewService(proc)
+
+	_, err := g.RegisterAlias(context.TODO(), &apipb.RegisterAliasRequest{Alias: "alice", Account: 10})
+	assert.Equal(t, ErrMetaIsNotAvailable, err)
+
+	g.SetMetaDBClient(meta)
+
+	for _, alias := range []string{"", " ", "12345", "face", "DeadBeef"} {
+		res, err := g.RegisterAlias(context.TODO(), &apipb.RegisterAliasRequest{Alias: alias, Account: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, apipb.TransferCode_BAD_REQUEST, res.Status.Code, "alias %q", alias)
+	}
+
+	// no public key
+	proc.EXPECT().GetLastSettings(gomock.Any(), gomock.Any()).Return(&gatepb.GetLastSettingsResponse{Status: &gatepb.Status{}, Account: 10}, nil)
+
+	res, err := g.RegisterAlias(context.TODO(), &apipb.RegisterAliasRequest{Alias: "alice", Account: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.RegisterAliasResponse{Status: &apipb.Status{Code: apipb.TransferCode_INVALID_SIGN, Message: "account has no public key"}}, res)
+}
+
+func TestResolveAlias(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+	meta := mocks.NewMockMetaDBServiceInterface(mock)
+
+	g := NewService(proc)
+	g.SetMetaDBClient(meta)
+
+	meta.EXPECT().GetMulti(gomock.Any(), &metadbpb.GetMultiRequest{Prefix: AliasMetaPrefix, Keys: [][]byte{[]byte("bob@example.com")}}).Return(
+		&metadbpb.GetMultiResponse{Status: &metadbpb.Status{}, Results: []*metadbpb.Data{aliasData("bob@example.com", 20)}}, nil)
+
+	res, err := g.ResolveAlias(context.TODO(), &apipb.ResolveAliasRequest{Alias: " Bob@Example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.ResolveAliasResponse{Status: &apipb.Status{}, Account: 20}, res)
+
+	meta.EXPECT().GetMulti(gomock.Any(), gomock.Any()).Return(&metadbpb.GetMultiResponse{Status: &metadbpb.Status{}}, nil)
+
+	res, err = g.ResolveAlias(context.TODO(), &apipb.ResolveAliasRequest{Alias: "carol"})
+	assert.NoError(t, err)
+	assert.Equal(t, apipb.TransferCode_ALIAS_NOT_FOUND, res.Status.Code)
+}
+
+func TestProcessTransferAlias(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+	meta := mocks.NewMockMetaDBServiceInterface(mock)
+
+	g := NewService(proc)
+	g.SetMetaDBClient(meta)
+
+	meta.EXPECT().GetMulti(gomock.Any(), &metadbpb.GetMultiRequest{Prefix: AliasMetaPrefix, Keys: [][]byte{[]byte("carol"), []byte("bob")}}).Return(
+		&metadbpb.GetMultiResponse{Status: &metadbpb.Status{}, Results: []*metadbpb.Data{aliasData("bob", 20), aliasData("carol", 30)}}, nil)
+	proc.EXPECT().ProcessTransfer(gomock.Any(), &gatepb.TransferRequest{
+		Sender: 1,
+		Batch: []*gatepb.TransferItem{
+			{Receiver: 30, Amount: 1},
+			{Receiver: 40, Amount: 2},
+			{Receiver: 20, Amount: 3},
+		},
+	}).Return(&gatepb.TransferResponse{Status: &gatepb.Status{}, TxnId: "txn_id"}, nil)
+
+	res, err := g.ProcessTransfer(context.TODO(), &apipb.TransferRequest{
+		Sender: 1,
+		Batch: []*apipb.TransferItem{
+			{Alias: "Carol", Amount: 1},
+			{Receiver: 40, Amount: 2},
+			{Alias: "bob", Amount: 3},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.TransferResponse{Status: &apipb.Status{}, TxnId: "txn_id"}, res)
+
+	// not found
+	meta.EXPECT().GetMulti(gomock.Any(), gomock.Any()).Return(&metadbpb.GetMultiResponse{Status: &metadbpb.Status{}}, nil)
+
+	res, err = g.ProcessTransfer(context.TODO(), &apipb.TransferRequest{Sender: 1, Batch: []*apipb.TransferItem{{Alias: "dave", Amount: 1}}})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.TransferResponse{Status: &apipb.Status{Code: apipb.TransferCode_ALIAS_NOT_FOUND, Message: `alias "dave" not found`}}, res)
+
+	// ambiguous
+	res, err = g.ProcessTransfer(context.TODO(), &apipb.TransferRequest{Sender: 1, Batch: []*apipb.TransferItem{{Receiver: 2, Alias: "dave", Amount: 1}}})
+	assert.NoError(t, err)
+	assert.Equal(t, apipb.TransferCode_BAD_REQUEST, res.Status.Code)
+
+	// sign doesn't cover alias
+	res, err = g.ProcessTransfer(context.TODO(), &apipb.TransferRequest{Sender: 1, Batch: []*apipb.TransferItem{{Alias: "bob", Amount: 1}}, Sign: pt.Sign{}.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.TransferResponse{Status: &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "signed transfer must specify receiver accounts, resolve aliases first"}}, res)
+}
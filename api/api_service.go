@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -13,10 +16,12 @@ import (
 	"github.com/qiwitech/qdp/proto/gatepb"
 	"github.com/qiwitech/qdp/proto/metadbpb"
 	"github.com/qiwitech/qdp/proto/plutodbpb"
+	"github.com/qiwitech/qdp/pt"
 )
 
 var (
-	TxnsMetaPrefix = []byte("m")
+	TxnsMetaPrefix  = []byte("m")
+	AliasMetaPrefix = []byte("a")
 )
 
 var ErrMetaIsNotAvailable = errors.New("metadb is not available")
@@ -25,11 +30,32 @@ type Service struct {
 	gate    gatepb.ProcessorServiceInterface
 	plutodb plutodbpb.PlutoDBServiceInterface
 	metadb  metadbpb.MetaDBServiceInterface
+
+	// replicas is set if plutodb reads are routed to replicas
+	replicas *Replicas
+
+	aliasMu sync.Mutex
+
+	// BulkParallelism is a number of transfers BulkProcessTransfer processes concurrently at each node
+	BulkParallelism int
+	// MaxBulkSize is a maximum number of transfers in BulkProcessTransfer request
+	MaxBulkSize int
+	// MaxSettingsDataSize is a maximum size of settings user data
+	MaxSettingsDataSize int
+	// MaxChangesWait limits time StreamChanges waits for new changes
+	MaxChangesWait time.Duration
+	// ChangesPollInterval is how often StreamChanges checks the database while waiting
+	ChangesPollInterval time.Duration
 }
 
 func NewService(gate gatepb.ProcessorServiceInterface) *Service {
 	return &Service{
-		gate: gate,
+		gate:                gate,
+		BulkParallelism:     16,
+		MaxBulkSize:         10000,
+		MaxSettingsDataSize: 64 << 10,
+		MaxChangesWait:      30 * time.Second,
+		ChangesPollInterval: 200 * time.Millisecond,
 	}
 }
 
@@ -41,6 +67,19 @@ func (s *Service) SetPlutoDBClient(c plutodbpb.PlutoDBServiceInterface) {
 	s.plutodb = c
 }
 
+// SetPlutoDBReplicas sets plutodb client routing reads to replicas, it's notified about accounts changed
+func (s *Service) SetPlutoDBReplicas(r *Replicas) {
+	s.plutodb = r
+	s.replicas = r
+}
+
+// wrote notifies replicas router about changed accounts
+func (s *Service) wrote(accs ...uint64) {
+	if s.replicas != nil {
+		s.replicas.Wrote(accs...)
+	}
+}
+
 func (s *Service) ProcessTransfer(ctx context.Context, req *apipb.TransferRequest) (*apipb.TransferResponse, error) {
 	//ctx, cancel := context.WithTimeout(ctx, time.Second)
 	//defer cancel()
@@ -58,18 +97,23 @@ func (s *Service) ProcessTransfer(ctx context.Context, req *apipb.TransferReques
 		}
 	}
 
+	batch, st, err := s.resolveReceivers(ctx, req.Batch, req.Sign != "")
+	if err != nil {
+		return nil, err
+	}
+	if st != nil {
+		res.Status = st
+		return res, nil
+	}
+
 	t := &gatepb.TransferRequest{
 		Sender:     req.Sender,
-		Batch:      make([]*gatepb.TransferItem, len(req.Batch)),
+		Batch:      batch,
 		SettingsId: req.SettingsId,
 		PrevHash:   req.PrevHash,
 		Sign:       req.Sign,
 	}
 
-	for i, r := range req.Batch {
-		t.Batch[i] = &gatepb.TransferItem{Receiver: r.Receiver, Amount: r.Amount}
-	}
-
 	gateres, err := s.gate.ProcessTransfer(ctx, t)
 	if err != nil {
 		return nil, errors.Wrap(err, "api")
@@ -86,6 +130,12 @@ func (s *Service) ProcessTransfer(ctx context.Context, req *apipb.TransferReques
 		return res, nil
 	}
 
+	accs := []uint64{req.Sender}
+	for _, it := range batch {
+		accs = append(accs, it.Receiver)
+	}
+	s.wrote(accs...)
+
 	if m := req.Metadata; m != nil {
 		d := &metadbpb.Data{
 			Key: m.Key,
@@ -148,7 +198,7 @@ func (s *Service) GetBalance(ctx context.Context, req *apipb.GetBalanceRequest)
 	//ctx, cancel := context.WithTimeout(ctx, time.Second)
 	//defer cancel()
 
-	gatereq := gatepb.GetBalanceRequest(*req)
+	gatereq := gatepb.GetBalanceRequest{Account: req.Account}
 	gateres, err := s.gate.GetBalance(ctx, &gatereq)
 	if err != nil {
 		return nil, errors.Wrap(err, "api")
@@ -166,7 +216,26 @@ func (s *Service) GetBalance(ctx context.Context, req *apipb.GetBalanceRequest)
 }
 
 func (s *Service) UpdateSettings(ctx context.Context, req *apipb.SettingsRequest) (*apipb.SettingsResponse, error) {
-	gatereq := gatepb.SettingsRequest(*req)
+	if len(req.Data) != 0 {
+		st, err := s.checkSettingsData(req.Data, req.DataHash)
+		if err != nil {
+			return nil, err
+		}
+		if st != nil {
+			return &apipb.SettingsResponse{Status: st}, nil
+		}
+	}
+
+	gatereq := gatepb.SettingsRequest{
+		Account:            req.Account,
+		PublicKey:          req.PublicKey,
+		PrevHash:           req.PrevHash,
+		DataHash:           req.DataHash,
+		Sign:               req.Sign,
+		VerifyTransferSign: req.VerifyTransferSign,
+		ServerSequencing:   req.ServerSequencing,
+		Registered:         req.Registered,
+	}
 	gateres, err := s.gate.UpdateSettings(ctx, &gatereq)
 	if err != nil {
 		return nil, errors.Wrap(err, "api")
@@ -180,6 +249,19 @@ func (s *Service) UpdateSettings(ctx context.Context, req *apipb.SettingsRequest
 		Hash:       gateres.Hash,
 		SettingsId: gateres.SettingsId,
 	}
+	if res.Status.Code == apipb.TransferCode_INVALID_PREV_HASH && len(req.Data) != 0 {
+		return s.retrySettingsData(ctx, req, res)
+	}
+	if res.Status.Code != 0 {
+		return res, nil
+	}
+
+	s.wrote(req.Account)
+
+	// data is stored only for accepted settings, so nobody could fill the database without a valid sign
+	if len(req.Data) != 0 {
+		res.Status = s.putSettingsData(ctx, req.Data)
+	}
 
 	return res, nil
 }
@@ -188,7 +270,7 @@ func (s *Service) GetLastSettings(ctx context.Context, req *apipb.GetLastSetting
 	//ctx, cancel := context.WithTimeout(ctx, time.Second)
 	//defer cancel()
 
-	gatereq := gatepb.GetLastSettingsRequest(*req)
+	gatereq := gatepb.GetLastSettingsRequest{Account: req.Account}
 	gateres, err := s.gate.GetLastSettings(ctx, &gatereq)
 	if err != nil {
 		return nil, errors.Wrap(err, "api")
@@ -207,6 +289,139 @@ func (s *Service) GetLastSettings(ctx context.Context, req *apipb.GetLastSetting
 		DataHash:           gateres.DataHash,
 		Sign:               gateres.Sign,
 		VerifyTransferSign: gateres.VerifyTransferSign,
+		ServerSequencing:   gateres.ServerSequencing,
+		Registered:         gateres.Registered,
+		Closed:             gateres.Closed,
+		SweepTo:            gateres.SweepTo,
+	}
+
+	if req.WithData && res.Status.Code == apipb.TransferCode_OK {
+		if err = s.getSettingsData(ctx, res); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// checkSettingsData checks that user data matches settings data hash and could be stored
+func (s *Service) checkSettingsData(data []byte, dataHash string) (*apipb.Status, error) {
+	if len(data) > s.MaxSettingsDataSize {
+		return &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: fmt.Sprintf("data is too big: %d > %d bytes", len(data), s.MaxSettingsDataSize)}, nil
+	}
+
+	h, err := pt.GetHashFromString(dataHash)
+	if err != nil || h != pt.GetDataHash(data) {
+		return &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "data doesn't match data_hash"}, nil
+	}
+
+	if s.plutodb == nil {
+		return nil, errors.New("plutodb is not available")
+	}
+
+	return nil, nil
+}
+
+// putSettingsData stores user data of already accepted settings.
+// Settings are not rolled back on failure, they are just left without data.
+func (s *Service) putSettingsData(ctx context.Context, data []byte) *apipb.Status {
+	pdbresp, err := s.plutodb.PutData(ctx, &plutodbpb.PutDataRequest{Data: data})
+	if err != nil {
+		return &apipb.Status{Code: apipb.TransferCode_INTERNAL_ERROR, Message: errors.Wrap(err, "settings are updated, data is not stored").Error()}
+	}
+	if pdbresp.Status.Code != plutodbpb.DBStatusCode_OK {
+		return &apipb.Status{Code: dbStatusCode(pdbresp.Status.Code), Message: "settings are updated, data is not stored: " + pdbresp.Status.Message}
+	}
+
+	return &apipb.Status{}
+}
+
+// retrySettingsData stores data of settings committed by the same request before, so retry after failed data store succeeds.
+// Data hash is committed by the chain, so storing data for it is idempotent.
+// Otherwise the original response is returned.
+func (s *Service) retrySettingsData(ctx context.Context, req *apipb.SettingsRequest, res *apipb.SettingsResponse) (*apipb.SettingsResponse, error) {
+	last, err := s.gate.GetLastSettings(ctx, &gatepb.GetLastSettingsRequest{Account: req.Account})
+	if err != nil {
+		return nil, errors.Wrap(err, "api")
+	}
+	if last.Status.Code != gatepb.TransferCode_OK || !sameHash(last.PrevHash, req.PrevHash) || !sameHash(last.DataHash, req.DataHash) || !strings.EqualFold(last.Sign, req.Sign) {
+		return res, nil
+	}
+
+	res = &apipb.SettingsResponse{
+		Status:     s.putSettingsData(ctx, req.Data),
+		Hash:       last.Hash,
+		SettingsId: pt.NewSettingsID(pt.AccID(last.Account), pt.ID(last.Id)).String(),
+	}
+	if res.Status.Code == apipb.TransferCode_OK {
+		res.Status.Message = "settings are already updated, data is stored"
+	}
+
+	return res, nil
+}
+
+func sameHash(a, b string) bool {
+	ha, err := pt.GetHashFromString(a)
+	if err != nil {
+		return false
+	}
+	hb, err := pt.GetHashFromString(b)
+	return err == nil && ha == hb
+}
+
+// getSettingsData loads user data of the settings if it was stored
+func (s *Service) getSettingsData(ctx context.Context, res *apipb.GetLastSettingsResponse) error {
+	h, err := pt.GetHashFromString(res.DataHash)
+	if err != nil || h == pt.ZeroHash {
+		return nil
+	}
+
+	if s.plutodb == nil {
+		return errors.New("plutodb is not available")
+	}
+
+	pdbresp, err := s.plutodb.GetData(ctx, &plutodbpb.GetDataRequest{Hash: h[:]})
+	if err != nil {
+		return errors.Wrap(err, "api")
+	}
+
+	switch pdbresp.Status.Code {
+	case plutodbpb.DBStatusCode_OK:
+		res.Data = pdbresp.Data
+	case plutodbpb.DBStatusCode_NOT_FOUND:
+		// data hash was set without data
+	default:
+		res.Status.Code = dbStatusCode(pdbresp.Status.Code)
+		res.Status.Message = pdbresp.Status.Message
+	}
+
+	return nil
+}
+
+func (s *Service) CloseAccount(ctx context.Context, req *apipb.CloseAccountRequest) (*apipb.CloseAccountResponse, error) {
+	gatereq := gatepb.CloseAccountRequest{
+		Account:  req.Account,
+		SweepTo:  req.SweepTo,
+		PrevHash: req.PrevHash,
+		Sign:     req.Sign,
+	}
+	gateres, err := s.gate.CloseAccount(ctx, &gatereq)
+	if err != nil {
+		return nil, errors.Wrap(err, "api")
+	}
+
+	res := &apipb.CloseAccountResponse{
+		Status: &apipb.Status{
+			Code:    apipb.TransferCode(gateres.Status.Code),
+			Message: gateres.Status.Message,
+		},
+		SettingsId: gateres.SettingsId,
+		Hash:       gateres.Hash,
+		TxnId:      gateres.TxnId,
+		TxnHash:    gateres.TxnHash,
+	}
+	if res.Status.Code == 0 {
+		s.wrote(req.Account, req.SweepTo)
 	}
 
 	return res, nil
@@ -219,15 +434,19 @@ func (s *Service) GetHistory(ctx context.Context, req *apipb.GetHistoryRequest)
 		return nil, errors.New("plutodb is not available")
 	}
 
-	pdbreq := plutodbpb.GetHistoryRequest(*req)
-	pdbresp, err := s.plutodb.GetHistory(ctx, &pdbreq)
+	pdbreq, err := historyRequest(req)
+	if err != nil {
+		return &apipb.GetHistoryResponse{Status: &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: err.Error()}}, nil
+	}
+
+	pdbresp, err := s.plutodb.GetHistory(ctx, pdbreq)
 	if err != nil {
 		return nil, errors.Wrap(err, "api")
 	}
 
 	res := &apipb.GetHistoryResponse{
 		Status: &apipb.Status{
-			Code:    apipb.TransferCode(pdbresp.Status.Code),
+			Code:    dbStatusCode(pdbresp.Status.Code),
 			Message: pdbresp.Status.Message,
 		},
 		Token: pdbresp.Token,
@@ -237,6 +456,199 @@ func (s *Service) GetHistory(ctx context.Context, req *apipb.GetHistoryRequest)
 	return res, nil
 }
 
+func (s *Service) GetStatement(ctx context.Context, req *apipb.GetStatementRequest) (*apipb.GetStatementResponse, error) {
+	if s.plutodb == nil {
+		return nil, errors.New("plutodb is not available")
+	}
+
+	from, err := parseTime(req.FromTime)
+	if err != nil {
+		return &apipb.GetStatementResponse{Status: &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "from_time: " + err.Error()}}, nil
+	}
+	to, err := parseTime(req.ToTime)
+	if err != nil {
+		return &apipb.GetStatementResponse{Status: &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "to_time: " + err.Error()}}, nil
+	}
+
+	pdbresp, err := s.plutodb.GetStatement(ctx, &plutodbpb.GetStatementRequest{
+		Account:    req.Account,
+		FromTime:   from,
+		ToTime:     to,
+		TotalsOnly: req.TotalsOnly,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "api")
+	}
+
+	res := &apipb.GetStatementResponse{
+		Status: &apipb.Status{
+			Code:    dbStatusCode(pdbresp.Status.Code),
+			Message: pdbresp.Status.Message,
+		},
+		OpeningBalance: pdbresp.OpeningBalance,
+		ClosingBalance: pdbresp.ClosingBalance,
+		Debits:         pdbresp.Debits,
+		Credits:        pdbresp.Credits,
+		TxnCount:       pdbresp.TxnCount,
+		Txns:           txnsToApi(pdbresp.Txns),
+	}
+
+	return res, nil
+}
+
+func (s *Service) GetBalanceAt(ctx context.Context, req *apipb.GetBalanceAtRequest) (*apipb.GetBalanceAtResponse, error) {
+	if s.plutodb == nil {
+		return nil, errors.New("plutodb is not available")
+	}
+
+	t, err := parseTime(req.Time)
+	if err != nil {
+		return &apipb.GetBalanceAtResponse{Status: &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "time: " + err.Error()}}, nil
+	}
+
+	pdbresp, err := s.plutodb.GetBalanceAt(ctx, &plutodbpb.GetBalanceAtRequest{
+		Account: req.Account,
+		TxnId:   req.TxnId,
+		Time:    t,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "api")
+	}
+
+	res := &apipb.GetBalanceAtResponse{
+		Status: &apipb.Status{
+			Code:    dbStatusCode(pdbresp.Status.Code),
+			Message: pdbresp.Status.Message,
+		},
+		Balance:         pdbresp.Balance,
+		PendingIncoming: pdbresp.PendingIncoming,
+		TxnId:           pdbresp.TxnId,
+	}
+
+	return res, nil
+}
+
+// GetPendingIncoming returns account incoming txns which are not spent by outgoing txn yet.
+// They are taken from the processing node or from the database if FromDb is set.
+func (s *Service) GetPendingIncoming(ctx context.Context, req *apipb.GetPendingIncomingRequest) (*apipb.GetPendingIncomingResponse, error) {
+	res := &apipb.GetPendingIncomingResponse{Status: &apipb.Status{}}
+
+	var txns []*chainpb.Txn
+	if req.FromDb {
+		if s.plutodb == nil {
+			return nil, errors.New("plutodb is not available")
+		}
+
+		pdbresp, err := s.plutodb.GetPendingIncoming(ctx, &plutodbpb.GetPendingIncomingRequest{Account: req.Account})
+		if err != nil {
+			return nil, errors.Wrap(err, "api")
+		}
+
+		res.Status.Code = dbStatusCode(pdbresp.Status.Code)
+		res.Status.Message = pdbresp.Status.Message
+		txns = pdbresp.Txns
+	} else {
+		gateres, err := s.gate.GetPendingIncoming(ctx, &gatepb.GetPendingIncomingRequest{Account: req.Account})
+		if err != nil {
+			return nil, errors.Wrap(err, "api")
+		}
+
+		res.Status.Code = apipb.TransferCode(gateres.Status.Code)
+		res.Status.Message = gateres.Status.Message
+		txns = gateres.Txns
+	}
+
+	res.Txns = txnsToApi(txns)
+	for _, t := range txns {
+		res.Total += t.Amount
+	}
+
+	return res, nil
+}
+
+// GetSettingsHistory returns account settings page from the database, latest first.
+func (s *Service) GetSettingsHistory(ctx context.Context, req *apipb.GetSettingsHistoryRequest) (*apipb.GetSettingsHistoryResponse, error) {
+	if s.plutodb == nil {
+		return nil, errors.New("plutodb is not available")
+	}
+
+	pdbresp, err := s.plutodb.GetSettingsHistory(ctx, &plutodbpb.GetSettingsHistoryRequest{
+		Account: req.Account,
+		Limit:   req.Limit,
+		Token:   req.Token,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "api")
+	}
+
+	res := &apipb.GetSettingsHistoryResponse{
+		Status: &apipb.Status{
+			Code:    dbStatusCode(pdbresp.Status.Code),
+			Message: pdbresp.Status.Message,
+		},
+		Token: pdbresp.Token,
+	}
+	for _, e := range pdbresp.Settings {
+		st := settingsToApi(e.Settings)
+		st.KeyChanged = e.KeyChanged
+		res.Settings = append(res.Settings, st)
+	}
+
+	return res, nil
+}
+
+// StreamChanges returns txns and settings stored after the cursor in commit order.
+// If there are no changes yet it polls the database for up to req.Wait seconds (long polling).
+func (s *Service) StreamChanges(ctx context.Context, req *apipb.StreamChangesRequest) (*apipb.StreamChangesResponse, error) {
+	if s.plutodb == nil {
+		return nil, errors.New("plutodb is not available")
+	}
+
+	wait := time.Duration(req.Wait) * time.Second
+	if wait > s.MaxChangesWait {
+		wait = s.MaxChangesWait
+	}
+	deadline := time.Now().Add(wait)
+
+	cursor := req.Cursor
+	for {
+		pdbresp, err := s.plutodb.StreamChanges(ctx, &plutodbpb.StreamChangesRequest{Cursor: cursor, Limit: req.Limit})
+		if err != nil {
+			return nil, errors.Wrap(err, "api")
+		}
+
+		if len(pdbresp.Changes) != 0 || pdbresp.Status.Code != plutodbpb.DBStatusCode_OK || !time.Now().Before(deadline) {
+			res := &apipb.StreamChangesResponse{
+				Status: &apipb.Status{
+					Code:    dbStatusCode(pdbresp.Status.Code),
+					Message: pdbresp.Status.Message,
+				},
+				Cursor: pdbresp.Cursor,
+			}
+			for _, c := range pdbresp.Changes {
+				ch := &apipb.Change{Seq: c.Seq}
+				if c.Txn != nil {
+					ch.Txn = txnsToApi([]*chainpb.Txn{c.Txn})[0]
+				}
+				if c.Settings != nil {
+					ch.Settings = settingsToApi(c.Settings)
+				}
+				res.Changes = append(res.Changes, ch)
+			}
+			return res, nil
+		}
+		cursor = pdbresp.Cursor
+
+		t := time.NewTimer(s.ChangesPollInterval)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return nil, errors.Wrap(ctx.Err(), "api")
+		}
+	}
+}
+
 func (s *Service) GetByMetaKey(ctx context.Context, req *apipb.GetByMetaKeyRequest) (*apipb.GetByMetaKeyResponse, error) {
 	if s.metadb == nil {
 		return nil, ErrMetaIsNotAvailable
@@ -286,23 +698,30 @@ func (s *Service) GetByMetaKey(ctx context.Context, req *apipb.GetByMetaKeyReque
 		}
 
 		if len(txnsres.Txns) != len(txnsreq.IDs) {
-			panic(fmt.Sprintf("got %d txns on %d request length", len(txnsres.Txns), len(txnsreq.IDs)))
+			return nil, errors.Errorf("plutodb: got %d txns on %d request length", len(txnsres.Txns), len(txnsreq.IDs))
+		}
+		if txnsres.Found != nil && len(txnsres.Found) != len(txnsreq.IDs) {
+			return nil, errors.Errorf("plutodb: got %d found marks on %d request length", len(txnsres.Found), len(txnsreq.IDs))
 		}
 
 		res.Txns = txnsToApi(txnsres.Txns)
-	} else {
-		res.Txns = make([]*apipb.Txn, len(metaresp.Results))
-		for i := range metaresp.Results {
-			res.Txns[i] = &apipb.Txn{}
+		for i, it := range metaresp.Results {
+			res.Txns[i].Meta = MetaFromData(it)
 		}
-	}
 
-	for i, it := range metaresp.Results {
-		res.Txns[i].Meta = MetaFromData(it)
+		if txnsres.Found != nil {
+			res.Txns = removeNotFound(res.Txns, txnsres.Found)
+		} else {
+			// older plutodb marks not found txns by zero ones only
+			res.Txns = removeZeros(res.Txns)
+		}
+
+		return res, nil
 	}
 
-	if s.plutodb != nil {
-		res.Txns = removeZeros(res.Txns)
+	res.Txns = make([]*apipb.Txn, len(metaresp.Results))
+	for i, it := range metaresp.Results {
+		res.Txns[i] = &apipb.Txn{Meta: MetaFromData(it)}
 	}
 
 	return res, nil
@@ -420,6 +839,20 @@ func MetaFromData(d *metadbpb.Data) *apipb.Meta {
 	return m
 }
 
+// removeNotFound drops txns not marked as found
+func removeNotFound(in []*apipb.Txn, found []bool) []*apipb.Txn {
+	d := 0
+	for i, t := range in {
+		if !found[i] {
+			continue
+		}
+		in[d] = t
+		d++
+	}
+
+	return in[:d]
+}
+
 func removeZeros(in []*apipb.Txn) []*apipb.Txn {
 	s, d := 0, 0
 	for s < len(in) {
@@ -444,20 +877,38 @@ func txnsToApi(in []*chainpb.Txn) []*apipb.Txn {
 	for i := range in {
 		t := in[i]
 		txns[i] = &apipb.Txn{
-			Id:       fmtID(t.ID),
-			Sender:   fmtAccID(t.Sender),
-			Receiver: fmtAccID(t.Receiver),
-			Amount:   fmtAmount(t.Amount),
-			Balance:  fmtAmount(t.Balance),
-			SpentBy:  fmtID(t.SpentBy),
-			PrevHash: fmtHash(t.PrevHash),
-			Hash:     fmtHash(t.Hash),
-			Sign:     fmtSign(t.Sign),
+			Id:          fmtID(t.ID),
+			Sender:      fmtAccID(t.Sender),
+			Receiver:    fmtAccID(t.Receiver),
+			Amount:      fmtAmount(t.Amount),
+			Balance:     fmtAmount(t.Balance),
+			SpentBy:     fmtID(t.SpentBy),
+			PrevHash:    fmtHash(t.PrevHash),
+			Hash:        fmtHash(t.Hash),
+			Sign:        fmtSign(t.Sign),
+			ProcessedAt: fmtTime(t.ProcessedAt),
 		}
 	}
 	return txns
 }
 
+func settingsToApi(st *chainpb.Settings) *apipb.SettingsHistoryEntry {
+	return &apipb.SettingsHistoryEntry{
+		Id:                 st.ID,
+		Hash:               fmtHash(st.Hash),
+		PrevHash:           fmtHash(st.PrevHash),
+		PublicKey:          pt.PublicKey(st.PublicKey).String(),
+		DataHash:           fmtHash(st.DataHash),
+		Sign:               fmtSign(st.Sign),
+		VerifyTransferSign: st.VerifyTransferSign,
+		ServerSequencing:   st.ServerSequencing,
+		Registered:         st.Registered,
+		Closed:             st.Closed,
+		SweepTo:            st.SweepTo,
+		ProcessedAt:        fmtTime(st.ProcessedAt),
+	}
+}
+
 func fmtID(v uint64) string {
 	return fmt.Sprintf("%d", v)
 }
@@ -470,6 +921,62 @@ func fmtAmount(v int64) string {
 	return fmt.Sprintf("%d", v)
 }
 
+func dbStatusCode(c plutodbpb.DBStatusCode) apipb.TransferCode {
+	switch c {
+	case plutodbpb.DBStatusCode_OK:
+		return apipb.TransferCode_OK
+	case plutodbpb.DBStatusCode_INVALID_TOKEN, plutodbpb.DBStatusCode_BAD_REQUEST:
+		return apipb.TransferCode_BAD_REQUEST
+	default:
+		return apipb.TransferCode_INTERNAL_ERROR
+	}
+}
+
+func historyRequest(req *apipb.GetHistoryRequest) (*plutodbpb.GetHistoryRequest, error) {
+	from, err := parseTime(req.FromTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "from_time")
+	}
+	to, err := parseTime(req.ToTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "to_time")
+	}
+	if req.MaxAmount != 0 && req.MinAmount > req.MaxAmount {
+		return nil, errors.New("min_amount is greater than max_amount")
+	}
+
+	return &plutodbpb.GetHistoryRequest{
+		Account:      req.Account,
+		Limit:        req.Limit,
+		Token:        req.Token,
+		FromTime:     from,
+		ToTime:       to,
+		Direction:    plutodbpb.HistoryDirection(req.Direction),
+		Counterparty: req.Counterparty,
+		MinAmount:    req.MinAmount,
+		MaxAmount:    req.MaxAmount,
+	}, nil
+}
+
+// parseTime parses RFC3339 time into unix nanoseconds. Empty string is zero time
+func parseTime(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixNano(), nil
+}
+
+func fmtTime(v int64) string {
+	if v == 0 {
+		return ""
+	}
+	return time.Unix(0, v).UTC().Format(time.RFC3339Nano)
+}
+
 func fmtHash(v []byte) string {
 	return fmt.Sprintf("%x", v)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -14,6 +15,7 @@ import (
 	"github.com/qiwitech/qdp/proto/gatepb"
 	"github.com/qiwitech/qdp/proto/metadbpb"
 	"github.com/qiwitech/qdp/proto/plutodbpb"
+	"github.com/qiwitech/qdp/pt"
 )
 
 func TestProcessTransferError(t *testing.T) {
@@ -168,6 +170,119 @@ func TestUpdateSettingsOk(t *testing.T) {
 	assert.Equal(t, &apipb.SettingsResponse{Status: &apipb.Status{Code: 5, Message: "message"}, SettingsId: "settings_id"}, res)
 }
 
+func TestUpdateSettingsData(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+	pdb := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	g := NewService(proc)
+	g.SetPlutoDBClient(pdb)
+	g.MaxSettingsDataSize = 10
+
+	data := []byte("profile")
+	h := pt.GetDataHash(data)
+
+	// mismatch
+	res, err := g.UpdateSettings(context.TODO(), &apipb.SettingsRequest{Account: 1, Data: data, DataHash: pt.GetDataHash([]byte("other")).String()})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.SettingsResponse{Status: &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "data doesn't match data_hash"}}, res)
+
+	// too big
+	res, err = g.UpdateSettings(context.TODO(), &apipb.SettingsRequest{Account: 1, Data: []byte("too big profile"), DataHash: h.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, apipb.TransferCode_BAD_REQUEST, res.Status.Code)
+
+	// invalid sign, data is not stored
+	proc.EXPECT().UpdateSettings(gomock.Any(), &gatepb.SettingsRequest{Account: 1, DataHash: h.String(), Sign: "bad"}).Return(
+		&gatepb.SettingsResponse{Status: &gatepb.Status{Code: gatepb.TransferCode_INVALID_SIGN, Message: "invalid sign"}}, nil)
+
+	res, err = g.UpdateSettings(context.TODO(), &apipb.SettingsRequest{Account: 1, Data: data, DataHash: h.String(), Sign: "bad"})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.SettingsResponse{Status: &apipb.Status{Code: apipb.TransferCode_INVALID_SIGN, Message: "invalid sign"}}, res)
+
+	// ok
+	gomock.InOrder(
+		proc.EXPECT().UpdateSettings(gomock.Any(), &gatepb.SettingsRequest{Account: 1, DataHash: h.String()}).Return(
+			&gatepb.SettingsResponse{Status: &gatepb.Status{}, SettingsId: "1_1", Hash: "hash"}, nil),
+		pdb.EXPECT().PutData(gomock.Any(), &plutodbpb.PutDataRequest{Data: data}).Return(&plutodbpb.PutDataResponse{Status: &plutodbpb.Status{}, Hash: h[:]}, nil),
+	)
+
+	res, err = g.UpdateSettings(context.TODO(), &apipb.SettingsRequest{Account: 1, Data: data, DataHash: h.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.SettingsResponse{Status: &apipb.Status{}, SettingsId: "1_1", Hash: "hash"}, res)
+
+	// data store failed after settings are accepted
+	gomock.InOrder(
+		proc.EXPECT().UpdateSettings(gomock.Any(), &gatepb.SettingsRequest{Account: 1, DataHash: h.String(), PrevHash: "hash"}).Return(
+			&gatepb.SettingsResponse{Status: &gatepb.Status{}, SettingsId: "1_2", Hash: "hash2"}, nil),
+		pdb.EXPECT().PutData(gomock.Any(), &plutodbpb.PutDataRequest{Data: data}).Return(nil, errors.New("connection refused")),
+	)
+
+	res, err = g.UpdateSettings(context.TODO(), &apipb.SettingsRequest{Account: 1, Data: data, DataHash: h.String(), PrevHash: "hash"})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.SettingsResponse{
+		Status:     &apipb.Status{Code: apipb.TransferCode_INTERNAL_ERROR, Message: "settings are updated, data is not stored: connection refused"},
+		SettingsId: "1_2",
+		Hash:       "hash2",
+	}, res)
+
+	// retry stores data of already committed settings
+	gomock.InOrder(
+		proc.EXPECT().UpdateSettings(gomock.Any(), &gatepb.SettingsRequest{Account: 1, DataHash: h.String(), PrevHash: "aa", Sign: "sign"}).Return(
+			&gatepb.SettingsResponse{Status: &gatepb.Status{Code: gatepb.TransferCode_INVALID_PREV_HASH, Message: "invalid prev hash"}}, nil),
+		proc.EXPECT().GetLastSettings(gomock.Any(), &gatepb.GetLastSettingsRequest{Account: 1}).Return(
+			&gatepb.GetLastSettingsResponse{Status: &gatepb.Status{}, Account: 1, Id: 2, Hash: "hash2", PrevHash: "AA", DataHash: h.String(), Sign: "sign"}, nil),
+		pdb.EXPECT().PutData(gomock.Any(), &plutodbpb.PutDataRequest{Data: data}).Return(&plutodbpb.PutDataResponse{Status: &plutodbpb.Status{}, Hash: h[:]}, nil),
+	)
+
+	res, err = g.UpdateSettings(context.TODO(), &apipb.SettingsRequest{Account: 1, Data: data, DataHash: h.String(), PrevHash: "aa", Sign: "sign"})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.SettingsResponse{
+		Status:     &apipb.Status{Message: "settings are already updated, data is stored"},
+		SettingsId: "1_2",
+		Hash:       "hash2",
+	}, res)
+
+	// another request with stale prev_hash is not
+	gomock.InOrder(
+		proc.EXPECT().UpdateSettings(gomock.Any(), &gatepb.SettingsRequest{Account: 1, DataHash: h.String(), PrevHash: "aa", Sign: "other"}).Return(
+			&gatepb.SettingsResponse{Status: &gatepb.Status{Code: gatepb.TransferCode_INVALID_PREV_HASH, Message: "invalid prev hash"}}, nil),
+		proc.EXPECT().GetLastSettings(gomock.Any(), &gatepb.GetLastSettingsRequest{Account: 1}).Return(
+			&gatepb.GetLastSettingsResponse{Status: &gatepb.Status{}, Account: 1, Id: 2, Hash: "hash2", PrevHash: "aa", DataHash: h.String(), Sign: "sign"}, nil),
+	)
+
+	res, err = g.UpdateSettings(context.TODO(), &apipb.SettingsRequest{Account: 1, Data: data, DataHash: h.String(), PrevHash: "aa", Sign: "other"})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.SettingsResponse{Status: &apipb.Status{Code: apipb.TransferCode_INVALID_PREV_HASH, Message: "invalid prev hash"}}, res)
+}
+
+func TestCloseAccount(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+
+	g := NewService(proc)
+
+	proc.EXPECT().CloseAccount(gomock.Any(), &gatepb.CloseAccountRequest{Account: 10, SweepTo: 20, PrevHash: "prev", Sign: "sign"}).Return(
+		&gatepb.CloseAccountResponse{Status: &gatepb.Status{}, SettingsId: "10_2", Hash: "hash", TxnId: "10_5", TxnHash: "txn_hash"}, nil)
+
+	res, err := g.CloseAccount(context.TODO(), &apipb.CloseAccountRequest{Account: 10, SweepTo: 20, PrevHash: "prev", Sign: "sign"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.CloseAccountResponse{Status: &apipb.Status{}, SettingsId: "10_2", Hash: "hash", TxnId: "10_5", TxnHash: "txn_hash"}, res)
+
+	respErr := errors.New("some test error")
+	proc.EXPECT().CloseAccount(gomock.Any(), gomock.Any()).Return(nil, respErr)
+
+	res, err = g.CloseAccount(context.TODO(), &apipb.CloseAccountRequest{})
+
+	assert.EqualError(t, err, "api: "+respErr.Error())
+	assert.Nil(t, res)
+}
+
 func TestGetLastSettingsError(t *testing.T) {
 	mock := gomock.NewController(t)
 	defer mock.Finish()
@@ -287,6 +402,66 @@ func TestGetByMetaKeyOk(t *testing.T) {
 	}, resp)
 }
 
+func TestGetByMetaKeyMissingTxns(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+	pdb := mocks.NewMockPlutoDBServiceInterface(mock)
+	meta := mocks.NewMockMetaDBServiceInterface(mock)
+
+	g := NewService(proc)
+
+	g.SetPlutoDBClient(pdb)
+	g.SetMetaDBClient(meta)
+
+	metaresp := &metadbpb.GetMultiResponse{
+		Status: &metadbpb.Status{},
+		Results: []*metadbpb.Data{
+			{Key: []byte("k1"), Index: []*metadbpb.Pair{{Key: []byte("sender"), Value: tobytes(uint64(4))}, {Key: []byte("id"), Value: tobytes(uint64(6))}}},
+			{Key: []byte("k2"), Index: []*metadbpb.Pair{{Key: []byte("sender"), Value: tobytes(uint64(4))}, {Key: []byte("id"), Value: tobytes(uint64(7))}}},
+		},
+	}
+	meta.EXPECT().GetMulti(gomock.Any(), gomock.Any()).Return(metaresp, nil).Times(3)
+
+	// not found txn is a zero one and it's skipped
+	pdb.EXPECT().GetTxnMulti(gomock.Any(), gomock.Any()).Return(&plutodbpb.GetTxnMultiResponse{
+		Status: &plutodbpb.Status{},
+		Txns:   []*chainpb.Txn{{}, {Sender: 4, ID: 7}},
+		Found:  []bool{false, true},
+	}, nil)
+
+	resp, err := g.GetByMetaKey(context.TODO(), &apipb.GetByMetaKeyRequest{Keys: [][]byte{[]byte("k1"), []byte("k2")}})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Txns, 1) {
+		assert.Equal(t, "7", resp.Txns[0].Id)
+		assert.Equal(t, []byte("k2"), resp.Txns[0].Meta.Key)
+	}
+
+	// count mismatch is an error, not a panic
+	pdb.EXPECT().GetTxnMulti(gomock.Any(), gomock.Any()).Return(&plutodbpb.GetTxnMultiResponse{
+		Status: &plutodbpb.Status{},
+		Txns:   []*chainpb.Txn{{Sender: 4, ID: 7}},
+	}, nil)
+
+	_, err = g.GetByMetaKey(context.TODO(), &apipb.GetByMetaKeyRequest{Keys: [][]byte{[]byte("k1"), []byte("k2")}})
+	assert.Error(t, err)
+
+	// found marks are trusted over txn contents
+	pdb.EXPECT().GetTxnMulti(gomock.Any(), gomock.Any()).Return(&plutodbpb.GetTxnMultiResponse{
+		Status: &plutodbpb.Status{},
+		Txns:   []*chainpb.Txn{{Sender: 4, ID: 6}, {Sender: 4, ID: 7}},
+		Found:  []bool{true, false},
+	}, nil)
+
+	resp, err = g.GetByMetaKey(context.TODO(), &apipb.GetByMetaKeyRequest{Keys: [][]byte{[]byte("k1"), []byte("k2")}})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Txns, 1) {
+		assert.Equal(t, "6", resp.Txns[0].Id)
+		assert.Equal(t, []byte("k1"), resp.Txns[0].Meta.Key)
+	}
+}
+
 func TestGetByMetaKeyOkWithoutPlutodb(t *testing.T) {
 	mock := gomock.NewController(t)
 	defer mock.Finish()
@@ -347,6 +522,158 @@ func TestGetByMetaKeyOkWithoutPlutodb(t *testing.T) {
 	}, resp)
 }
 
+func TestGetHistoryFilters(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	pdb := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	g := NewService(nil)
+	g.SetPlutoDBClient(pdb)
+
+	pdb.EXPECT().GetHistory(gomock.Any(), &plutodbpb.GetHistoryRequest{
+		Account:      1,
+		Limit:        10,
+		FromTime:     1500000000000000000,
+		ToTime:       1500604800000000000,
+		Direction:    plutodbpb.HistoryDirection_OUTGOING,
+		Counterparty: 2,
+		MinAmount:    100,
+	}).Return(&plutodbpb.GetHistoryResponse{
+		Status: &plutodbpb.Status{},
+		Txns:   []*chainpb.Txn{{Sender: 1, ID: 3, Receiver: 2, Amount: 150, ProcessedAt: 1500000001000000000}},
+	}, nil)
+
+	resp, err := g.GetHistory(context.TODO(), &apipb.GetHistoryRequest{
+		Account:      1,
+		Limit:        10,
+		FromTime:     "2017-07-14T02:40:00Z",
+		ToTime:       "2017-07-21T05:40:00+03:00",
+		Direction:    apipb.HistoryDirection_OUTGOING,
+		Counterparty: 2,
+		MinAmount:    100,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Txns, 1) {
+		assert.Equal(t, "2017-07-14T02:40:01Z", resp.Txns[0].ProcessedAt)
+	}
+
+	pdb.EXPECT().GetHistory(gomock.Any(), &plutodbpb.GetHistoryRequest{Account: 1, Token: "bad"}).Return(&plutodbpb.GetHistoryResponse{
+		Status: &plutodbpb.Status{Code: plutodbpb.DBStatusCode_INVALID_TOKEN, Message: "invalid token"},
+	}, nil)
+
+	resp, err = g.GetHistory(context.TODO(), &apipb.GetHistoryRequest{Account: 1, Token: "bad"})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "invalid token"}, resp.Status)
+
+	resp, err = g.GetHistory(context.TODO(), &apipb.GetHistoryRequest{Account: 1, FromTime: "last week"})
+	assert.NoError(t, err)
+	assert.Equal(t, apipb.TransferCode_BAD_REQUEST, resp.Status.Code)
+
+	resp, err = g.GetHistory(context.TODO(), &apipb.GetHistoryRequest{Account: 1, MinAmount: 10, MaxAmount: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, apipb.TransferCode_BAD_REQUEST, resp.Status.Code)
+}
+
+func TestGetStatement(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	pdb := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	g := NewService(nil)
+	g.SetPlutoDBClient(pdb)
+
+	pdb.EXPECT().GetStatement(gomock.Any(), &plutodbpb.GetStatementRequest{
+		Account:  1,
+		FromTime: 1498867200000000000,
+		ToTime:   1501545600000000000,
+	}).Return(&plutodbpb.GetStatementResponse{
+		Status:         &plutodbpb.Status{},
+		OpeningBalance: 100,
+		ClosingBalance: 70,
+		Debits:         30,
+		TxnCount:       1,
+		Txns:           []*chainpb.Txn{{Sender: 1, ID: 3, Receiver: 2, Amount: 30, Balance: 70}},
+	}, nil)
+
+	resp, err := g.GetStatement(context.TODO(), &apipb.GetStatementRequest{
+		Account:  1,
+		FromTime: "2017-07-01T00:00:00Z",
+		ToTime:   "2017-08-01T00:00:00Z",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.GetStatementResponse{
+		Status:         &apipb.Status{},
+		OpeningBalance: 100,
+		ClosingBalance: 70,
+		Debits:         30,
+		TxnCount:       1,
+		Txns:           []*apipb.Txn{{Id: "3", Sender: "1", Receiver: "2", Amount: "30", Balance: "70", SpentBy: "0"}},
+	}, resp)
+
+	resp, err = g.GetStatement(context.TODO(), &apipb.GetStatementRequest{Account: 1, ToTime: "yesterday"})
+	assert.NoError(t, err)
+	assert.Equal(t, apipb.TransferCode_BAD_REQUEST, resp.Status.Code)
+}
+
+func TestGetBalanceAt(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	pdb := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	g := NewService(nil)
+	g.SetPlutoDBClient(pdb)
+
+	pdb.EXPECT().GetBalanceAt(gomock.Any(), &plutodbpb.GetBalanceAtRequest{Account: 1, Time: 1498867200000000000}).Return(
+		&plutodbpb.GetBalanceAtResponse{Status: &plutodbpb.Status{}, Balance: 70, PendingIncoming: 20, TxnId: 3}, nil)
+
+	resp, err := g.GetBalanceAt(context.TODO(), &apipb.GetBalanceAtRequest{Account: 1, Time: "2017-07-01T00:00:00Z"})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.GetBalanceAtResponse{Status: &apipb.Status{}, Balance: 70, PendingIncoming: 20, TxnId: 3}, resp)
+
+	pdb.EXPECT().GetBalanceAt(gomock.Any(), &plutodbpb.GetBalanceAtRequest{Account: 1, TxnId: 5}).Return(
+		&plutodbpb.GetBalanceAtResponse{Status: &plutodbpb.Status{Code: plutodbpb.DBStatusCode_BAD_REQUEST, Message: "txn not found"}}, nil)
+
+	resp, err = g.GetBalanceAt(context.TODO(), &apipb.GetBalanceAtRequest{Account: 1, TxnId: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "txn not found"}, resp.Status)
+}
+
+func TestGetPendingIncoming(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+	pdb := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	g := NewService(proc)
+	g.SetPlutoDBClient(pdb)
+
+	proc.EXPECT().GetPendingIncoming(gomock.Any(), &gatepb.GetPendingIncomingRequest{Account: 5}).Return(&gatepb.GetPendingIncomingResponse{
+		Status: &gatepb.Status{},
+		Txns:   []*chainpb.Txn{{Sender: 1, ID: 3, Receiver: 5, Amount: 10}, {Sender: 2, ID: 4, Receiver: 5, Amount: 15}},
+	}, nil)
+
+	resp, err := g.GetPendingIncoming(context.TODO(), &apipb.GetPendingIncomingRequest{Account: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.Status{}, resp.Status)
+	assert.Len(t, resp.Txns, 2)
+	assert.Equal(t, int64(25), resp.Total)
+
+	pdb.EXPECT().GetPendingIncoming(gomock.Any(), &plutodbpb.GetPendingIncomingRequest{Account: 5}).Return(&plutodbpb.GetPendingIncomingResponse{
+		Status: &plutodbpb.Status{},
+		Txns:   []*chainpb.Txn{{Sender: 1, ID: 3, Receiver: 5, Amount: 10}},
+	}, nil)
+
+	resp, err = g.GetPendingIncoming(context.TODO(), &apipb.GetPendingIncomingRequest{Account: 5, FromDb: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.Status{}, resp.Status)
+	assert.Len(t, resp.Txns, 1)
+	assert.Equal(t, int64(10), resp.Total)
+}
+
 func TestRemoveZeros(t *testing.T) {
 	assert.Equal(t, []*apipb.Txn(nil), removeZeros(nil))
 	assert.Equal(t, []*apipb.Txn{}, removeZeros([]*apipb.Txn{}))
@@ -356,3 +683,116 @@ func TestRemoveZeros(t *testing.T) {
 	assert.Equal(t, []*apipb.Txn{{Id: "1"}, {Id: "3"}}, removeZeros([]*apipb.Txn{{Id: "1"}, {Id: "0"}, {Id: "3"}}))
 	assert.Equal(t, []*apipb.Txn{}, removeZeros([]*apipb.Txn{{Id: ""}, {Id: "0"}, {Id: "0"}}))
 }
+
+func TestGetSettingsHistory(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	pdb := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	g := NewService(nil)
+	g.SetPlutoDBClient(pdb)
+
+	key := pt.PublicKey{2, 1, 2, 3}
+
+	pdb.EXPECT().GetSettingsHistory(gomock.Any(), &plutodbpb.GetSettingsHistoryRequest{Account: 1, Limit: 2}).Return(&plutodbpb.GetSettingsHistoryResponse{
+		Status: &plutodbpb.Status{},
+		Settings: []*plutodbpb.SettingsHistoryEntry{
+			{Settings: &chainpb.Settings{ID: 3, Account: 1, PublicKey: key, Hash: []byte{0xab}, PrevHash: []byte{0xcd}, VerifyTransferSign: true, ProcessedAt: 1498867200000000000}, KeyChanged: true},
+			{Settings: &chainpb.Settings{ID: 2, Account: 1, Hash: []byte{0xcd}, Sign: []byte{0xef}}},
+		},
+		Token: "next",
+	}, nil)
+
+	resp, err := g.GetSettingsHistory(context.TODO(), &apipb.GetSettingsHistoryRequest{Account: 1, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.GetSettingsHistoryResponse{
+		Status: &apipb.Status{},
+		Settings: []*apipb.SettingsHistoryEntry{
+			{Id: 3, Hash: "ab", PrevHash: "cd", PublicKey: key.String(), KeyChanged: true, VerifyTransferSign: true, ProcessedAt: "2017-07-01T00:00:00Z"},
+			{Id: 2, Hash: "cd", Sign: "ef"},
+		},
+		Token: "next",
+	}, resp)
+}
+
+func TestGetLastSettingsData(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+	pdb := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	g := NewService(proc)
+	g.SetPlutoDBClient(pdb)
+
+	h := pt.GetDataHash([]byte("profile"))
+
+	proc.EXPECT().GetLastSettings(gomock.Any(), gomock.Any()).Return(&gatepb.GetLastSettingsResponse{Status: &gatepb.Status{}, Account: 1, DataHash: h.String()}, nil).Times(3)
+	pdb.EXPECT().GetData(gomock.Any(), &plutodbpb.GetDataRequest{Hash: h[:]}).Return(&plutodbpb.GetDataResponse{Status: &plutodbpb.Status{}, Data: []byte("profile")}, nil)
+
+	res, err := g.GetLastSettings(context.TODO(), &apipb.GetLastSettingsRequest{Account: 1, WithData: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.GetLastSettingsResponse{Status: &apipb.Status{}, Account: 1, DataHash: h.String(), Data: []byte("profile")}, res)
+
+	// not requested
+	res, err = g.GetLastSettings(context.TODO(), &apipb.GetLastSettingsRequest{Account: 1})
+	assert.NoError(t, err)
+	assert.Nil(t, res.Data)
+
+	// hash set without data
+	pdb.EXPECT().GetData(gomock.Any(), gomock.Any()).Return(&plutodbpb.GetDataResponse{Status: &plutodbpb.Status{Code: plutodbpb.DBStatusCode_NOT_FOUND}}, nil)
+
+	res, err = g.GetLastSettings(context.TODO(), &apipb.GetLastSettingsRequest{Account: 1, WithData: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.GetLastSettingsResponse{Status: &apipb.Status{}, Account: 1, DataHash: h.String()}, res)
+}
+
+func TestStreamChanges(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	pdb := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	g := NewService(nil)
+	g.SetPlutoDBClient(pdb)
+	g.ChangesPollInterval = time.Millisecond
+
+	// nothing new at first, cursor moves past the gap
+	gomock.InOrder(
+		pdb.EXPECT().StreamChanges(gomock.Any(), &plutodbpb.StreamChangesRequest{Cursor: 5, Limit: 10}).Return(&plutodbpb.StreamChangesResponse{Status: &plutodbpb.Status{}, Cursor: 6}, nil),
+		pdb.EXPECT().StreamChanges(gomock.Any(), &plutodbpb.StreamChangesRequest{Cursor: 6, Limit: 10}).Return(&plutodbpb.StreamChangesResponse{
+			Status: &plutodbpb.Status{},
+			Changes: []*plutodbpb.Change{
+				{Seq: 7, Txn: &chainpb.Txn{ID: 1, Sender: 2, Receiver: 3, Amount: 4, Balance: -4}},
+				{Seq: 8, Settings: &chainpb.Settings{ID: 1, Account: 3, Registered: true}},
+			},
+			Cursor: 8,
+		}, nil),
+	)
+
+	resp, err := g.StreamChanges(context.TODO(), &apipb.StreamChangesRequest{Cursor: 5, Limit: 10, Wait: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.StreamChangesResponse{
+		Status: &apipb.Status{},
+		Changes: []*apipb.Change{
+			{Seq: 7, Txn: &apipb.Txn{Id: "1", Sender: "2", Receiver: "3", Amount: "4", Balance: "-4", SpentBy: "0"}},
+			{Seq: 8, Settings: &apipb.SettingsHistoryEntry{Id: 1, Registered: true}},
+		},
+		Cursor: 8,
+	}, resp)
+
+	// no wait
+	pdb.EXPECT().StreamChanges(gomock.Any(), &plutodbpb.StreamChangesRequest{Cursor: 8}).Return(&plutodbpb.StreamChangesResponse{Status: &plutodbpb.Status{}, Cursor: 8}, nil)
+	resp, err = g.StreamChanges(context.TODO(), &apipb.StreamChangesRequest{Cursor: 8})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Changes)
+	assert.Equal(t, uint64(8), resp.Cursor)
+
+	// waiting is limited
+	g.MaxChangesWait = 20 * time.Millisecond
+	pdb.EXPECT().StreamChanges(gomock.Any(), &plutodbpb.StreamChangesRequest{Cursor: 8}).Return(&plutodbpb.StreamChangesResponse{Status: &plutodbpb.Status{}, Cursor: 8}, nil).MinTimes(2)
+	resp, err = g.StreamChanges(context.TODO(), &apipb.StreamChangesRequest{Cursor: 8, Wait: 60})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Changes)
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/qiwitech/qdp/proto/apipb"
+)
+
+// grouper is implemented by Router
+type grouper interface {
+	Group(accs []uint64) map[string][]int
+}
+
+// BulkProcessTransfer processes many independent transfers.
+// Transfers are grouped by owner node and processed concurrently (BulkParallelism per node),
+// transfers of the same sender are processed sequentially in the request order.
+// prev_hash could be omitted for all but the first transfer of a sender, it's taken from the previous result.
+// Each transfer result is reported separately, failed transfers don't affect others.
+func (s *Service) BulkProcessTransfer(ctx context.Context, req *apipb.BulkTransferRequest) (*apipb.BulkTransferResponse, error) {
+	res := &apipb.BulkTransferResponse{Status: &apipb.Status{}}
+
+	if s.MaxBulkSize != 0 && len(req.Transfers) > s.MaxBulkSize {
+		res.Status.Code = apipb.TransferCode_BAD_REQUEST
+		res.Status.Message = fmt.Sprintf("too many transfers: %d > %d", len(req.Transfers), s.MaxBulkSize)
+		return res, nil
+	}
+
+	res.Results = make([]*apipb.TransferResponse, len(req.Transfers))
+
+	senders := make([]uint64, len(req.Transfers))
+	for i, t := range req.Transfers {
+		if t == nil {
+			res.Results[i] = &apipb.TransferResponse{Status: &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "empty transfer"}}
+			continue
+		}
+		senders[i] = t.Sender
+	}
+
+	var groups map[string][]int
+	if g, ok := s.gate.(grouper); ok {
+		groups = g.Group(senders)
+	} else {
+		all := make([]int, len(senders))
+		for i := range all {
+			all[i] = i
+		}
+		groups = map[string][]int{"": all}
+	}
+
+	var wg sync.WaitGroup
+	for _, idx := range groups {
+		wg.Add(1)
+		go func(idx []int) {
+			defer wg.Done()
+			s.bulkNode(ctx, req.Transfers, res.Results, idx)
+		}(idx)
+	}
+	wg.Wait()
+
+	return res, nil
+}
+
+// bulkNode processes transfers of one node
+func (s *Service) bulkNode(ctx context.Context, reqs []*apipb.TransferRequest, res []*apipb.TransferResponse, idx []int) {
+	var chains [][]int
+	bySender := make(map[uint64]int)
+	for _, i := range idx {
+		if reqs[i] == nil {
+			continue
+		}
+		c, ok := bySender[reqs[i].Sender]
+		if !ok {
+			c = len(chains)
+			bySender[reqs[i].Sender] = c
+			chains = append(chains, nil)
+		}
+		chains[c] = append(chains[c], i)
+	}
+
+	workers := s.BulkParallelism
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(chains) {
+		workers = len(chains)
+	}
+
+	jobs := make(chan []int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for c := range jobs {
+				s.bulkChain(ctx, reqs, res, c)
+			}
+		}()
+	}
+
+	for _, c := range chains {
+		jobs <- c
+	}
+	close(jobs)
+
+	wg.Wait()
+}
+
+// bulkChain processes transfers of one sender sequentially.
+// Transfers without prev_hash (except the first one) are chained to the hash of the previous transfer
+func (s *Service) bulkChain(ctx context.Context, reqs []*apipb.TransferRequest, res []*apipb.TransferResponse, c []int) {
+	var prev *apipb.TransferResponse
+	for _, i := range c {
+		req := reqs[i]
+		if prev != nil && req.PrevHash == "" {
+			if prev.Status.GetCode() != apipb.TransferCode_OK {
+				res[i] = &apipb.TransferResponse{Status: &apipb.Status{
+					Code:    apipb.TransferCode_INVALID_PREV_HASH,
+					Message: "previous transfer of the sender failed",
+				}}
+				prev = res[i]
+				continue
+			}
+			cp := *req
+			cp.PrevHash = prev.Hash
+			req = &cp
+		}
+		res[i] = s.bulkItem(ctx, req)
+		prev = res[i]
+	}
+}
+
+func (s *Service) bulkItem(ctx context.Context, req *apipb.TransferRequest) *apipb.TransferResponse {
+	res, err := s.ProcessTransfer(ctx, req)
+	if err != nil {
+		return &apipb.TransferResponse{Status: &apipb.Status{
+			Code:    apipb.TransferCode_INTERNAL_ERROR,
+			Message: errors.Wrap(err, "bulk").Error(),
+		}}
+	}
+	return res
+}
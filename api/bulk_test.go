@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/mocks"
+	"github.com/qiwitech/qdp/proto/apipb"
+	"github.com/qiwitech/qdp/proto/gatepb"
+)
+
+func TestBulkProcessTransfer(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+
+	s := NewService(proc)
+
+	gomock.InOrder(
+		proc.EXPECT().ProcessTransfer(gomock.Any(), &gatepb.TransferRequest{Sender: 1, PrevHash: "a", Batch: []*gatepb.TransferItem{}}).
+			Return(&gatepb.TransferResponse{Status: &gatepb.Status{}, TxnId: "1_1", Hash: "b"}, nil),
+		proc.EXPECT().ProcessTransfer(gomock.Any(), &gatepb.TransferRequest{Sender: 1, PrevHash: "b", Batch: []*gatepb.TransferItem{}}).
+			Return(&gatepb.TransferResponse{Status: &gatepb.Status{}, TxnId: "1_2", Hash: "c"}, nil),
+	)
+	proc.EXPECT().ProcessTransfer(gomock.Any(), &gatepb.TransferRequest{Sender: 2, Batch: []*gatepb.TransferItem{}}).
+		Return(&gatepb.TransferResponse{Status: &gatepb.Status{Code: gatepb.TransferCode_NO_BALANCE, Message: "no balance"}}, nil)
+	proc.EXPECT().ProcessTransfer(gomock.Any(), &gatepb.TransferRequest{Sender: 3, Batch: []*gatepb.TransferItem{}}).
+		Return(nil, errors.New("connection refused"))
+
+	res, err := s.BulkProcessTransfer(context.TODO(), &apipb.BulkTransferRequest{Transfers: []*apipb.TransferRequest{
+		{Sender: 1, PrevHash: "a"},
+		{Sender: 2},
+		nil,
+		{Sender: 1, PrevHash: "b"},
+		{Sender: 3},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.BulkTransferResponse{
+		Status: &apipb.Status{},
+		Results: []*apipb.TransferResponse{
+			{Status: &apipb.Status{}, TxnId: "1_1", Hash: "b"},
+			{Status: &apipb.Status{Code: apipb.TransferCode_NO_BALANCE, Message: "no balance"}},
+			{Status: &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "empty transfer"}},
+			{Status: &apipb.Status{}, TxnId: "1_2", Hash: "c"},
+			{Status: &apipb.Status{Code: apipb.TransferCode_INTERNAL_ERROR, Message: "bulk: api: connection refused"}},
+		},
+	}, res)
+}
+
+func TestBulkProcessTransferTooMany(t *testing.T) {
+	s := NewService(nil)
+	s.MaxBulkSize = 1
+
+	res, err := s.BulkProcessTransfer(context.TODO(), &apipb.BulkTransferRequest{Transfers: []*apipb.TransferRequest{{}, {}}})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.BulkTransferResponse{
+		Status: &apipb.Status{Code: apipb.TransferCode_BAD_REQUEST, Message: "too many transfers: 2 > 1"},
+	}, res)
+}
+
+func TestBulkProcessTransferChainPrevHash(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+
+	s := NewService(proc)
+
+	gomock.InOrder(
+		proc.EXPECT().ProcessTransfer(gomock.Any(), &gatepb.TransferRequest{Sender: 1, Batch: []*gatepb.TransferItem{}}).
+			Return(&gatepb.TransferResponse{Status: &gatepb.Status{}, TxnId: "1_1", Hash: "b"}, nil),
+		proc.EXPECT().ProcessTransfer(gomock.Any(), &gatepb.TransferRequest{Sender: 1, PrevHash: "b", Batch: []*gatepb.TransferItem{}}).
+			Return(&gatepb.TransferResponse{Status: &gatepb.Status{}, TxnId: "1_2", Hash: "c"}, nil),
+	)
+	proc.EXPECT().ProcessTransfer(gomock.Any(), &gatepb.TransferRequest{Sender: 2, PrevHash: "x", Batch: []*gatepb.TransferItem{}}).
+		Return(&gatepb.TransferResponse{Status: &gatepb.Status{Code: gatepb.TransferCode_INVALID_PREV_HASH, Message: "invalid prev hash"}}, nil)
+
+	reqs := []*apipb.TransferRequest{
+		{Sender: 1},
+		{Sender: 2, PrevHash: "x"},
+		{Sender: 1},
+		{Sender: 2},
+	}
+
+	res, err := s.BulkProcessTransfer(context.TODO(), &apipb.BulkTransferRequest{Transfers: reqs})
+	assert.NoError(t, err)
+	assert.Equal(t, &apipb.BulkTransferResponse{
+		Status: &apipb.Status{},
+		Results: []*apipb.TransferResponse{
+			{Status: &apipb.Status{}, TxnId: "1_1", Hash: "b"},
+			{Status: &apipb.Status{Code: apipb.TransferCode_INVALID_PREV_HASH, Message: "invalid prev hash"}},
+			{Status: &apipb.Status{}, TxnId: "1_2", Hash: "c"},
+			{Status: &apipb.Status{Code: apipb.TransferCode_INVALID_PREV_HASH, Message: "previous transfer of the sender failed"}},
+		},
+	}, res)
+
+	// request is not modified
+	assert.Equal(t, "", reqs[2].PrevHash)
+}
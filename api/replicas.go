@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/qiwitech/qdp/proto/plutodbpb"
+)
+
+// Replicas routes plutodb reads to read replicas and everything else to primary.
+// Replica is used if its lag is below MaxLag and it has caught up with the latest writes
+// of requested accounts made through this Replicas, so history right after a transfer is read from primary.
+// Writes are tracked in process memory: read-your-writes holds only if the client reads from
+// the same plutoapi instance it wrote through. Writes made through other instances or directly to plutos
+// are seen at replica up to MaxLag later.
+// Reads failed at replica are retried at primary.
+type Replicas struct {
+	primary  plutodbpb.PlutoDBServiceInterface
+	replicas []*replica
+	next     uint32
+
+	mu     sync.Mutex
+	writes map[uint64]time.Time
+
+	// MaxLag is a maximum replica lag, more lagging replicas are not used
+	MaxLag time.Duration
+
+	now func() time.Time
+}
+
+type replica struct {
+	name string
+	c    plutodbpb.PlutoDBServiceInterface
+
+	mu      sync.Mutex
+	ok      bool
+	lag     time.Duration
+	checked time.Time
+}
+
+func NewReplicas(primary plutodbpb.PlutoDBServiceInterface) *Replicas {
+	return &Replicas{
+		primary: primary,
+		writes:  make(map[uint64]time.Time),
+		MaxLag:  5 * time.Second,
+		now:     time.Now,
+	}
+}
+
+// AddReplica adds read replica. It's not used until its lag is checked.
+func (r *Replicas) AddReplica(name string, c plutodbpb.PlutoDBServiceInterface) {
+	r.replicas = append(r.replicas, &replica{name: name, c: c})
+}
+
+// Run checks replicas lag every period until ctx is done
+func (r *Replicas) Run(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+
+	for {
+		r.Check(ctx)
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check updates replicas lag and forgets writes all the usable replicas have caught up with
+func (r *Replicas) Check(ctx context.Context) {
+	for _, rep := range r.replicas {
+		resp, err := rep.c.GetReplicaLag(ctx, &plutodbpb.GetReplicaLagRequest{})
+
+		rep.mu.Lock()
+		rep.ok = err == nil && resp.Status.Code == plutodbpb.DBStatusCode_OK
+		if rep.ok {
+			rep.lag = time.Duration(resp.Lag)
+			rep.checked = r.now()
+		}
+		rep.mu.Unlock()
+	}
+
+	now := r.now()
+	r.mu.Lock()
+	for acc, t := range r.writes {
+		if now.Sub(t) > r.MaxLag {
+			delete(r.writes, acc)
+		}
+	}
+	r.mu.Unlock()
+}
+
+// Wrote marks accounts as just changed
+func (r *Replicas) Wrote(accs ...uint64) {
+	if len(r.replicas) == 0 {
+		return
+	}
+
+	now := r.now()
+	r.mu.Lock()
+	for _, acc := range accs {
+		r.writes[acc] = now
+	}
+	r.mu.Unlock()
+}
+
+// pick returns replica to read accounts from in round robin order or nil if primary is to be used
+func (r *Replicas) pick(accs ...uint64) *replica {
+	if len(r.replicas) == 0 {
+		return nil
+	}
+
+	now := r.now()
+
+	// the latest write of accounts
+	var last time.Time
+	r.mu.Lock()
+	for _, acc := range accs {
+		if t, ok := r.writes[acc]; ok && t.After(last) {
+			last = t
+		}
+	}
+	r.mu.Unlock()
+
+	n := atomic.AddUint32(&r.next, 1)
+	for i := range r.replicas {
+		rep := r.replicas[(int(n)+i)%len(r.replicas)]
+
+		rep.mu.Lock()
+		ok := rep.ok
+		// replica could have lagged more since it's checked
+		stale := rep.lag + now.Sub(rep.checked)
+		rep.mu.Unlock()
+
+		if !ok || stale > r.MaxLag {
+			continue
+		}
+		if !last.IsZero() && stale >= now.Sub(last) {
+			continue
+		}
+
+		return rep
+	}
+
+	return nil
+}
+
+// failed excludes replica until the next successful check
+func (r *Replicas) failed(rep *replica) {
+	rep.mu.Lock()
+	rep.ok = false
+	rep.mu.Unlock()
+}
+
+func (r *Replicas) GetHistory(ctx context.Context, req *plutodbpb.GetHistoryRequest) (*plutodbpb.GetHistoryResponse, error) {
+	if rep := r.pick(req.Account); rep != nil {
+		resp, err := rep.c.GetHistory(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		r.failed(rep)
+	}
+	return r.primary.GetHistory(ctx, req)
+}
+
+// Fetch is always served by primary, preloading needs the latest account state
+func (r *Replicas) Fetch(ctx context.Context, req *plutodbpb.FetchRequest) (*plutodbpb.FetchResponse, error) {
+	return r.primary.Fetch(ctx, req)
+}
+
+func (r *Replicas) GetTxnMulti(ctx context.Context, req *plutodbpb.GetTxnMultiRequest) (*plutodbpb.GetTxnMultiResponse, error) {
+	accs := make([]uint64, len(req.IDs))
+	for i, id := range req.IDs {
+		accs[i] = id.Account
+	}
+
+	if rep := r.pick(accs...); rep != nil {
+		resp, err := rep.c.GetTxnMulti(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		r.failed(rep)
+	}
+	return r.primary.GetTxnMulti(ctx, req)
+}
+
+func (r *Replicas) GetStatement(ctx context.Context, req *plutodbpb.GetStatementRequest) (*plutodbpb.GetStatementResponse, error) {
+	if rep := r.pick(req.Account); rep != nil {
+		resp, err := rep.c.GetStatement(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		r.failed(rep)
+	}
+	return r.primary.GetStatement(ctx, req)
+}
+
+func (r *Replicas) GetBalanceAt(ctx context.Context, req *plutodbpb.GetBalanceAtRequest) (*plutodbpb.GetBalanceAtResponse, error) {
+	if rep := r.pick(req.Account); rep != nil {
+		resp, err := rep.c.GetBalanceAt(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		r.failed(rep)
+	}
+	return r.primary.GetBalanceAt(ctx, req)
+}
+
+func (r *Replicas) GetPendingIncoming(ctx context.Context, req *plutodbpb.GetPendingIncomingRequest) (*plutodbpb.GetPendingIncomingResponse, error) {
+	if rep := r.pick(req.Account); rep != nil {
+		resp, err := rep.c.GetPendingIncoming(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		r.failed(rep)
+	}
+	return r.primary.GetPendingIncoming(ctx, req)
+}
+
+func (r *Replicas) GetSettingsHistory(ctx context.Context, req *plutodbpb.GetSettingsHistoryRequest) (*plutodbpb.GetSettingsHistoryResponse, error) {
+	if rep := r.pick(req.Account); rep != nil {
+		resp, err := rep.c.GetSettingsHistory(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		r.failed(rep)
+	}
+	return r.primary.GetSettingsHistory(ctx, req)
+}
+
+func (r *Replicas) PutData(ctx context.Context, req *plutodbpb.PutDataRequest) (*plutodbpb.PutDataResponse, error) {
+	return r.primary.PutData(ctx, req)
+}
+
+// GetData falls back to primary also if data is not found, it could be just put there
+func (r *Replicas) GetData(ctx context.Context, req *plutodbpb.GetDataRequest) (*plutodbpb.GetDataResponse, error) {
+	if rep := r.pick(); rep != nil {
+		resp, err := rep.c.GetData(ctx, req)
+		if err == nil && resp.Status.Code != plutodbpb.DBStatusCode_NOT_FOUND {
+			return resp, nil
+		}
+		if err != nil {
+			r.failed(rep)
+		}
+	}
+	return r.primary.GetData(ctx, req)
+}
+
+func (r *Replicas) GetReplicaLag(ctx context.Context, req *plutodbpb.GetReplicaLagRequest) (*plutodbpb.GetReplicaLagResponse, error) {
+	return r.primary.GetReplicaLag(ctx, req)
+}
+
+// StreamChanges is served by primary, so followers get changes as soon as they are committed
+func (r *Replicas) StreamChanges(ctx context.Context, req *plutodbpb.StreamChangesRequest) (*plutodbpb.StreamChangesResponse, error) {
+	return r.primary.StreamChanges(ctx, req)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/mocks"
+	"github.com/qiwitech/qdp/proto/apipb"
+	"github.com/qiwitech/qdp/proto/gatepb"
+	"github.com/qiwitech/qdp/proto/plutodbpb"
+)
+
+func lagResp(lag time.Duration) *plutodbpb.GetReplicaLagResponse {
+	return &plutodbpb.GetReplicaLagResponse{Status: &plutodbpb.Status{}, Lag: lag.Nanoseconds()}
+}
+
+func histResp(n int) *plutodbpb.GetHistoryResponse {
+	return &plutodbpb.GetHistoryResponse{Status: &plutodbpb.Status{}, Token: string(rune('0' + n))}
+}
+
+func TestReplicasReadYourWrites(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	primary := mocks.NewMockPlutoDBServiceInterface(mock)
+	rep := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	now := time.Unix(1500000000, 0)
+	r := NewReplicas(primary)
+	r.now = func() time.Time { return now }
+	r.AddReplica("r1", rep)
+
+	primary.EXPECT().GetHistory(gomock.Any(), gomock.Any()).Return(histResp(0), nil).AnyTimes()
+	rep.EXPECT().GetHistory(gomock.Any(), gomock.Any()).Return(histResp(1), nil).AnyTimes()
+
+	get := func(acc uint64) string {
+		resp, err := r.GetHistory(context.TODO(), &plutodbpb.GetHistoryRequest{Account: acc})
+		assert.NoError(t, err)
+		return resp.Token
+	}
+
+	// not checked yet
+	assert.Equal(t, "0", get(1))
+
+	rep.EXPECT().GetReplicaLag(gomock.Any(), gomock.Any()).Return(lagResp(time.Second), nil)
+	r.Check(context.TODO())
+	assert.Equal(t, "1", get(1))
+
+	r.Wrote(1)
+	assert.Equal(t, "0", get(1))
+	assert.Equal(t, "1", get(2))
+
+	// replica could lag more since check
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, "0", get(1))
+
+	// caught up
+	rep.EXPECT().GetReplicaLag(gomock.Any(), gomock.Any()).Return(lagResp(time.Second), nil)
+	r.Check(context.TODO())
+	assert.Equal(t, "1", get(1))
+
+	// too old writes are forgotten
+	now = now.Add(r.MaxLag + time.Second)
+	rep.EXPECT().GetReplicaLag(gomock.Any(), gomock.Any()).Return(lagResp(0), nil)
+	r.Check(context.TODO())
+	assert.Empty(t, r.writes)
+}
+
+func TestReplicasLagAndFailures(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	primary := mocks.NewMockPlutoDBServiceInterface(mock)
+	r1 := mocks.NewMockPlutoDBServiceInterface(mock)
+	r2 := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	now := time.Unix(1500000000, 0)
+	r := NewReplicas(primary)
+	r.now = func() time.Time { return now }
+	r.AddReplica("r1", r1)
+	r.AddReplica("r2", r2)
+
+	// r2 lags too much
+	r1.EXPECT().GetReplicaLag(gomock.Any(), gomock.Any()).Return(lagResp(0), nil)
+	r2.EXPECT().GetReplicaLag(gomock.Any(), gomock.Any()).Return(lagResp(time.Minute), nil)
+	r.Check(context.TODO())
+
+	r1.EXPECT().GetStatement(gomock.Any(), gomock.Any()).Return(&plutodbpb.GetStatementResponse{Status: &plutodbpb.Status{}, TxnCount: 1}, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		resp, err := r.GetStatement(context.TODO(), &plutodbpb.GetStatementRequest{Account: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), resp.TxnCount)
+	}
+
+	// round robin
+	r1.EXPECT().GetReplicaLag(gomock.Any(), gomock.Any()).Return(lagResp(0), nil)
+	r2.EXPECT().GetReplicaLag(gomock.Any(), gomock.Any()).Return(lagResp(0), nil)
+	r.Check(context.TODO())
+
+	r1.EXPECT().GetBalanceAt(gomock.Any(), gomock.Any()).Return(&plutodbpb.GetBalanceAtResponse{Status: &plutodbpb.Status{}, Balance: 1}, nil)
+	r2.EXPECT().GetBalanceAt(gomock.Any(), gomock.Any()).Return(&plutodbpb.GetBalanceAtResponse{Status: &plutodbpb.Status{}, Balance: 2}, nil)
+	var sum int64
+	for i := 0; i < 2; i++ {
+		resp, err := r.GetBalanceAt(context.TODO(), &plutodbpb.GetBalanceAtRequest{Account: 1})
+		assert.NoError(t, err)
+		sum += resp.Balance
+	}
+	assert.Equal(t, int64(3), sum)
+
+	// failed replica is excluded and request is retried at primary
+	r1.EXPECT().GetPendingIncoming(gomock.Any(), gomock.Any()).Return(nil, errors.New("conn refused")).AnyTimes()
+	r2.EXPECT().GetPendingIncoming(gomock.Any(), gomock.Any()).Return(nil, errors.New("conn refused")).AnyTimes()
+	primary.EXPECT().GetPendingIncoming(gomock.Any(), gomock.Any()).Return(&plutodbpb.GetPendingIncomingResponse{Status: &plutodbpb.Status{}}, nil).Times(3)
+	for i := 0; i < 3; i++ {
+		_, err := r.GetPendingIncoming(context.TODO(), &plutodbpb.GetPendingIncomingRequest{Account: 1})
+		assert.NoError(t, err)
+	}
+	assert.Nil(t, r.pick(1))
+
+	// unreachable replica is not used
+	r1.EXPECT().GetReplicaLag(gomock.Any(), gomock.Any()).Return(nil, errors.New("conn refused"))
+	r2.EXPECT().GetReplicaLag(gomock.Any(), gomock.Any()).Return(lagResp(0), nil)
+	r.Check(context.TODO())
+
+	// data just put could be missing at replica
+	r2.EXPECT().GetData(gomock.Any(), gomock.Any()).Return(&plutodbpb.GetDataResponse{Status: &plutodbpb.Status{Code: plutodbpb.DBStatusCode_NOT_FOUND}}, nil)
+	primary.EXPECT().GetData(gomock.Any(), gomock.Any()).Return(&plutodbpb.GetDataResponse{Status: &plutodbpb.Status{}, Data: []byte("d")}, nil)
+	dresp, err := r.GetData(context.TODO(), &plutodbpb.GetDataRequest{Hash: []byte{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("d"), dresp.Data)
+
+	// writes go to primary
+	primary.EXPECT().PutData(gomock.Any(), gomock.Any()).Return(&plutodbpb.PutDataResponse{Status: &plutodbpb.Status{}}, nil)
+	_, err = r.PutData(context.TODO(), &plutodbpb.PutDataRequest{Data: []byte("d")})
+	assert.NoError(t, err)
+}
+
+func TestServiceNotifiesReplicas(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockProcessorServiceInterface(mock)
+	pdb := mocks.NewMockPlutoDBServiceInterface(mock)
+
+	g := NewService(proc)
+	r := NewReplicas(pdb)
+	r.AddReplica("r1", pdb)
+	g.SetPlutoDBReplicas(r)
+
+	proc.EXPECT().ProcessTransfer(gomock.Any(), gomock.Any()).Return(&gatepb.TransferResponse{Status: &gatepb.Status{}, TxnId: "txn_id"}, nil)
+	_, err := g.ProcessTransfer(context.TODO(), &apipb.TransferRequest{Sender: 1, Batch: []*apipb.TransferItem{{Receiver: 2}}})
+	assert.NoError(t, err)
+
+	proc.EXPECT().CloseAccount(gomock.Any(), gomock.Any()).Return(&gatepb.CloseAccountResponse{Status: &gatepb.Status{Code: 3}}, nil)
+	_, err = g.CloseAccount(context.TODO(), &apipb.CloseAccountRequest{Account: 4, SweepTo: 5})
+	assert.NoError(t, err)
+
+	assert.Len(t, r.writes, 2)
+	assert.Contains(t, r.writes, uint64(1))
+	assert.Contains(t, r.writes, uint64(2))
+}
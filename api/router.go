@@ -68,7 +68,9 @@ func (r *Router) decodeAndCheckReroute(st *gatepb.Status) (bool, error) {
 		if rmap == nil {
 			return false, errors.New("no routing info")
 		}
-		r.router.SetNodes(rmap.Nodes)
+		if !r.router.SetEpochNodes(rmap.Epoch, rmap.Nodes) {
+			return false, errors.Errorf("stale route map: epoch %d, current is %d", rmap.Epoch, r.router.Epoch())
+		}
 		return true, nil
 	}
 	return false, nil
@@ -105,6 +107,7 @@ func (r *Router) ProcessTransfer(ctx context.Context, req *gatepb.TransferReques
 	var res *gatepb.TransferResponse
 	err := r.routeCall(req.Sender, func(cl gatepb.ProcessorServiceInterface) (*gatepb.Status, error) {
 		var err error
+		req.RouteEpoch = r.router.Epoch()
 		res, err = cl.ProcessTransfer(ctx, req)
 		if res == nil {
 			return nil, err
@@ -118,6 +121,7 @@ func (r *Router) GetPrevHash(ctx context.Context, req *gatepb.GetPrevHashRequest
 	var res *gatepb.GetPrevHashResponse
 	err := r.routeCall(req.Account, func(cl gatepb.ProcessorServiceInterface) (*gatepb.Status, error) {
 		var err error
+		req.RouteEpoch = r.router.Epoch()
 		res, err = cl.GetPrevHash(ctx, req)
 		if res == nil {
 			return nil, err
@@ -131,6 +135,7 @@ func (r *Router) GetBalance(ctx context.Context, req *gatepb.GetBalanceRequest)
 	var res *gatepb.GetBalanceResponse
 	err := r.routeCall(req.Account, func(cl gatepb.ProcessorServiceInterface) (*gatepb.Status, error) {
 		var err error
+		req.RouteEpoch = r.router.Epoch()
 		res, err = cl.GetBalance(ctx, req)
 		if res == nil {
 			return nil, err
@@ -140,10 +145,25 @@ func (r *Router) GetBalance(ctx context.Context, req *gatepb.GetBalanceRequest)
 	return res, err
 }
 
+func (r *Router) GetPendingIncoming(ctx context.Context, req *gatepb.GetPendingIncomingRequest) (*gatepb.GetPendingIncomingResponse, error) {
+	var res *gatepb.GetPendingIncomingResponse
+	err := r.routeCall(req.Account, func(cl gatepb.ProcessorServiceInterface) (*gatepb.Status, error) {
+		var err error
+		req.RouteEpoch = r.router.Epoch()
+		res, err = cl.GetPendingIncoming(ctx, req)
+		if res == nil {
+			return nil, err
+		}
+		return res.Status, err
+	})
+	return res, err
+}
+
 func (r *Router) UpdateSettings(ctx context.Context, req *gatepb.SettingsRequest) (*gatepb.SettingsResponse, error) {
 	var res *gatepb.SettingsResponse
 	err := r.routeCall(req.Account, func(cl gatepb.ProcessorServiceInterface) (*gatepb.Status, error) {
 		var err error
+		req.RouteEpoch = r.router.Epoch()
 		res, err = cl.UpdateSettings(ctx, req)
 		if res == nil {
 			return nil, err
@@ -157,6 +177,7 @@ func (r *Router) GetLastSettings(ctx context.Context, req *gatepb.GetLastSetting
 	var res *gatepb.GetLastSettingsResponse
 	err := r.routeCall(req.Account, func(cl gatepb.ProcessorServiceInterface) (*gatepb.Status, error) {
 		var err error
+		req.RouteEpoch = r.router.Epoch()
 		res, err = cl.GetLastSettings(ctx, req)
 		if res == nil {
 			return nil, err
@@ -166,6 +187,20 @@ func (r *Router) GetLastSettings(ctx context.Context, req *gatepb.GetLastSetting
 	return res, err
 }
 
+func (r *Router) CloseAccount(ctx context.Context, req *gatepb.CloseAccountRequest) (*gatepb.CloseAccountResponse, error) {
+	var res *gatepb.CloseAccountResponse
+	err := r.routeCall(req.Account, func(cl gatepb.ProcessorServiceInterface) (*gatepb.Status, error) {
+		var err error
+		req.RouteEpoch = r.router.Epoch()
+		res, err = cl.CloseAccount(ctx, req)
+		if res == nil {
+			return nil, err
+		}
+		return res.Status, err
+	})
+	return res, err
+}
+
 func (r *Router) getURLForAccount(accID uint64) string {
 	key := fmt.Sprintf("%d", accID)
 	host := r.router.GetHostByKey(key)
@@ -176,6 +211,16 @@ func (r *Router) getURLForAccount(accID uint64) string {
 	return host
 }
 
+// Group groups accounts by owner node. It returns indexes of accounts for each node.
+func (r *Router) Group(accs []uint64) map[string][]int {
+	res := make(map[string][]int)
+	for i, acc := range accs {
+		url := r.getURLForAccount(acc)
+		res[url] = append(res[url], i)
+	}
+	return res
+}
+
 func getRouteMap(st *gatepb.Status) (*gatepb.RouteMap, error) {
 	if len(st.Details) == 0 {
 		return nil, nil
@@ -40,6 +40,20 @@ func TestRouterGetClientForAccount(t *testing.T) {
 	assert.True(t, returnedClient == cl)
 }
 
+func TestRouterGroup(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	router := mocks.NewMockRouter(mock)
+	r := NewRouter(router, nil)
+
+	router.EXPECT().GetHostByKey("1").Return("host-a")
+	router.EXPECT().GetHostByKey("2").Return("host-b")
+	router.EXPECT().GetHostByKey("3").Return("host-a")
+
+	assert.Equal(t, map[string][]int{"host-a": {0, 2}, "host-b": {1}}, r.Group([]uint64{1, 2, 3}))
+}
+
 func TestRouterCheckReroute(t *testing.T) {
 	mock := gomock.NewController(t)
 	defer mock.Finish()
@@ -60,11 +74,11 @@ func TestRouterCheckReroute(t *testing.T) {
 
 	// --
 	nodes := []string{"node_a", "node_b", "node_c"}
-	router.EXPECT().SetNodes(gomock.Any()).Do(func(got []string) {
+	router.EXPECT().SetEpochNodes(uint64(4), gomock.Any()).Do(func(epoch uint64, got []string) {
 		assert.Equal(t, nodes, got)
-	})
+	}).Return(true)
 
-	rt := &gatepb.RouteMap{Nodes: nodes, Target: "target"}
+	rt := &gatepb.RouteMap{Nodes: nodes, Target: "target", Epoch: 4}
 	m, _ := proto.Marshal(rt)
 	details := []*any.Any{{Value: m, TypeUrl: proto.MessageName(rt)}}
 
@@ -73,6 +87,15 @@ func TestRouterCheckReroute(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, ok)
 
+	// -- stale route map
+	router.EXPECT().SetEpochNodes(uint64(4), gomock.Any()).Return(false)
+	router.EXPECT().Epoch().Return(uint64(5))
+
+	ok, err = r.checkReroute(&gatepb.Status{Code: gatepb.TransferCode_SEE_OTHER, Details: details})
+
+	assert.EqualError(t, err, "stale route map: epoch 4, current is 5")
+	assert.False(t, ok)
+
 	func() {
 		details[0].TypeUrl = "qew"
 		defer func() {
@@ -187,6 +210,7 @@ func TestRouterProcessTransfer(t *testing.T) {
 	r := NewRouter(router, func(url string) gatepb.ProcessorServiceInterface {
 		return nil
 	})
+	router.EXPECT().Epoch().Return(uint64(3))
 	r.routeCall = func(acc uint64, f func(gatepb.ProcessorServiceInterface) (*gatepb.Status, error)) error {
 		assert.Equal(t, uint64(501), acc)
 
@@ -194,6 +218,7 @@ func TestRouterProcessTransfer(t *testing.T) {
 		cl.EXPECT().ProcessTransfer(gomock.Any(), gomock.Any()).Return(callRes, nil).Do(func(ctx context.Context, req *gatepb.TransferRequest) {
 			assert.True(t, context.TODO() == ctx)
 			assert.True(t, callReq == req)
+			assert.Equal(t, uint64(3), req.RouteEpoch)
 		})
 
 		_, err := f(cl)
@@ -218,6 +243,7 @@ func TestRouterGetPrevHash(t *testing.T) {
 	r := NewRouter(router, func(url string) gatepb.ProcessorServiceInterface {
 		return nil
 	})
+	router.EXPECT().Epoch().Return(uint64(3))
 	r.routeCall = func(acc uint64, f func(gatepb.ProcessorServiceInterface) (*gatepb.Status, error)) error {
 		assert.Equal(t, uint64(501), acc)
 
@@ -225,6 +251,7 @@ func TestRouterGetPrevHash(t *testing.T) {
 		cl.EXPECT().GetPrevHash(gomock.Any(), gomock.Any()).Return(callRes, nil).Do(func(ctx context.Context, req *gatepb.GetPrevHashRequest) {
 			assert.True(t, context.TODO() == ctx)
 			assert.True(t, callReq == req)
+			assert.Equal(t, uint64(3), req.RouteEpoch)
 		})
 
 		_, err := f(cl)
@@ -249,6 +276,7 @@ func TestRouterGetBalance(t *testing.T) {
 	r := NewRouter(router, func(url string) gatepb.ProcessorServiceInterface {
 		return nil
 	})
+	router.EXPECT().Epoch().Return(uint64(3))
 	r.routeCall = func(acc uint64, f func(gatepb.ProcessorServiceInterface) (*gatepb.Status, error)) error {
 		assert.Equal(t, uint64(501), acc)
 
@@ -256,6 +284,7 @@ func TestRouterGetBalance(t *testing.T) {
 		cl.EXPECT().GetBalance(gomock.Any(), gomock.Any()).Return(callRes, nil).Do(func(ctx context.Context, req *gatepb.GetBalanceRequest) {
 			assert.True(t, context.TODO() == ctx)
 			assert.True(t, callReq == req)
+			assert.Equal(t, uint64(3), req.RouteEpoch)
 		})
 
 		_, err := f(cl)
@@ -280,6 +309,7 @@ func TestRouterUpdateSettings(t *testing.T) {
 	r := NewRouter(router, func(url string) gatepb.ProcessorServiceInterface {
 		return nil
 	})
+	router.EXPECT().Epoch().Return(uint64(3))
 	r.routeCall = func(acc uint64, f func(gatepb.ProcessorServiceInterface) (*gatepb.Status, error)) error {
 		assert.Equal(t, uint64(501), acc)
 
@@ -287,6 +317,7 @@ func TestRouterUpdateSettings(t *testing.T) {
 		cl.EXPECT().UpdateSettings(gomock.Any(), gomock.Any()).Return(callRes, nil).Do(func(ctx context.Context, req *gatepb.SettingsRequest) {
 			assert.True(t, context.TODO() == ctx)
 			assert.True(t, callReq == req)
+			assert.Equal(t, uint64(3), req.RouteEpoch)
 		})
 
 		_, err := f(cl)
@@ -311,6 +342,7 @@ func TestRouterGetLastSettings(t *testing.T) {
 	r := NewRouter(router, func(url string) gatepb.ProcessorServiceInterface {
 		return nil
 	})
+	router.EXPECT().Epoch().Return(uint64(3))
 	r.routeCall = func(acc uint64, f func(gatepb.ProcessorServiceInterface) (*gatepb.Status, error)) error {
 		assert.Equal(t, uint64(501), acc)
 
@@ -318,6 +350,7 @@ func TestRouterGetLastSettings(t *testing.T) {
 		cl.EXPECT().GetLastSettings(gomock.Any(), gomock.Any()).Return(callRes, nil).Do(func(ctx context.Context, req *gatepb.GetLastSettingsRequest) {
 			assert.True(t, context.TODO() == ctx)
 			assert.True(t, callReq == req)
+			assert.Equal(t, uint64(3), req.RouteEpoch)
 		})
 
 		_, err := f(cl)
@@ -329,3 +362,36 @@ func TestRouterGetLastSettings(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, callRes == res)
 }
+
+func TestRouterCloseAccount(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	router := mocks.NewMockRouter(mock)
+
+	callReq := &gatepb.CloseAccountRequest{Account: 501}
+	callRes := &gatepb.CloseAccountResponse{Status: &gatepb.Status{}}
+
+	r := NewRouter(router, func(url string) gatepb.ProcessorServiceInterface {
+		return nil
+	})
+	router.EXPECT().Epoch().Return(uint64(3))
+	r.routeCall = func(acc uint64, f func(gatepb.ProcessorServiceInterface) (*gatepb.Status, error)) error {
+		assert.Equal(t, uint64(501), acc)
+
+		cl := mocks.NewMockProcessorServiceInterface(mock)
+		cl.EXPECT().CloseAccount(gomock.Any(), gomock.Any()).Return(callRes, nil).Do(func(ctx context.Context, req *gatepb.CloseAccountRequest) {
+			assert.True(t, context.TODO() == ctx)
+			assert.True(t, callReq == req)
+			assert.Equal(t, uint64(3), req.RouteEpoch)
+		})
+
+		_, err := f(cl)
+
+		return err
+	}
+
+	res, err := r.CloseAccount(context.TODO(), callReq)
+	assert.NoError(t, err)
+	assert.True(t, callRes == res)
+}
@@ -621,16 +621,17 @@ func txnsToProto(in []pt.Txn) []*archiverpb.Txn {
 	for i := range in {
 		t := in[i]
 		txns[i] = &archiverpb.Txn{
-			Id:         fmt.Sprintf("%d", t.ID),
-			Sender:     fmt.Sprintf("%d", t.Sender),
-			Receiver:   fmt.Sprintf("%d", t.Receiver),
-			Amount:     fmt.Sprintf("%d", t.Amount),
-			Balance:    fmt.Sprintf("%d", t.Balance),
-			SpentBy:    fmt.Sprintf("%d", t.SpentBy),
-			SettingsId: fmt.Sprintf("%d", t.SettingsID),
-			PrevHash:   hex.EncodeToString(t.PrevHash[:]),
-			Hash:       hex.EncodeToString(t.Hash[:]),
-			Sign:       hex.EncodeToString(t.Sign[:]),
+			Id:          fmt.Sprintf("%d", t.ID),
+			Sender:      fmt.Sprintf("%d", t.Sender),
+			Receiver:    fmt.Sprintf("%d", t.Receiver),
+			Amount:      fmt.Sprintf("%d", t.Amount),
+			Balance:     fmt.Sprintf("%d", t.Balance),
+			SpentBy:     fmt.Sprintf("%d", t.SpentBy),
+			SettingsId:  fmt.Sprintf("%d", t.SettingsID),
+			PrevHash:    hex.EncodeToString(t.PrevHash[:]),
+			Hash:        hex.EncodeToString(t.Hash[:]),
+			Sign:        hex.EncodeToString(t.Sign[:]),
+			ProcessedAt: fmt.Sprintf("%d", t.ProcessedAt),
 		}
 	}
 	return txns
@@ -646,5 +647,10 @@ func settingsToProto(t *pt.Settings) *archiverpb.Settings {
 		PublicKey:          hex.EncodeToString(t.PublicKey[:]),
 		Sign:               hex.EncodeToString(t.Sign[:]),
 		VerifyTransferSign: t.VerifyTransferSign,
+		ServerSequencing:   t.ServerSequencing,
+		Registered:         t.Registered,
+		Closed:             t.Closed,
+		SweepTo:            fmt.Sprintf("%d", t.SweepTo),
+		ProcessedAt:        fmt.Sprintf("%d", t.ProcessedAt),
 	}
 }
@@ -42,12 +42,13 @@ func pbTxnsToPt(v []*chainpb.Txn) []pt.Txn {
 	res := make([]pt.Txn, len(v))
 	for i, t := range v {
 		res[i] = pt.Txn{
-			ID:       pt.ID(t.ID),
-			Sender:   pt.AccID(t.Sender),
-			Receiver: pt.AccID(t.Receiver),
-			Amount:   t.Amount,
-			Balance:  t.Balance,
-			SpentBy:  pt.ID(t.SpentBy),
+			ID:          pt.ID(t.ID),
+			Sender:      pt.AccID(t.Sender),
+			Receiver:    pt.AccID(t.Receiver),
+			Amount:      t.Amount,
+			Balance:     t.Balance,
+			SpentBy:     pt.ID(t.SpentBy),
+			ProcessedAt: t.ProcessedAt,
 		}
 		copy(res[i].Hash[:], t.Hash)
 		copy(res[i].PrevHash[:], t.PrevHash)
@@ -64,7 +65,12 @@ func pbSettingsToPt(v *chainpb.Settings) *pt.Settings {
 		ID:                 pt.ID(v.ID),
 		Account:            pt.AccID(v.Account),
 		VerifyTransferSign: v.VerifyTransferSign,
+		ServerSequencing:   v.ServerSequencing,
+		Registered:         v.Registered,
+		Closed:             v.Closed,
+		SweepTo:            pt.AccID(v.SweepTo),
 		PublicKey:          dup(v.PublicKey),
+		ProcessedAt:        v.ProcessedAt,
 	}
 	copy(r.Hash[:], v.Hash)
 	copy(r.PrevHash[:], v.PrevHash)
@@ -0,0 +1,857 @@
+// Package boltchain is an embedded PlutoDB implementation on top of boltdb.
+// It has the same semantics as sqlchain and suits small installs and tests where no MySQL is available.
+package boltchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/boltdb/bolt"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/qiwitech/qdp/pagetoken"
+	"github.com/qiwitech/qdp/proto/chainpb"
+	"github.com/qiwitech/qdp/proto/plutodbpb"
+	"github.com/qiwitech/qdp/pt"
+)
+
+var (
+	txnsBucket     = []byte("txns")       // sender, id -> txn
+	incomingBucket = []byte("incoming")   // receiver, sender, id -> nothing
+	settBucket     = []byte("sett")       // account, id -> settings
+	dataBucket     = []byte("sett_data")  // hash -> settings user data
+	changesBucket  = []byte("changes")    // seq -> change kind, account, id
+	changeSeqs     = []byte("change_seq") // change kind, account, id -> seq
+)
+
+// change kinds
+const (
+	txnChange  = 't'
+	settChange = 's'
+)
+
+type DB struct {
+	db *bolt.DB
+}
+
+func New(db *bolt.DB) (*DB, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{txnsBucket, incomingBucket, settBucket, dataBucket, changesBucket, changeSeqs} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "create buckets")
+	}
+	return &DB{db: db}, nil
+}
+
+// Push stores new txns. Already stored txns could only be updated with spent_by,
+// updated txn is moved to the new change sequence number as in sqlchain.
+func (d *DB) Push(ctx context.Context, txns []pt.Txn) error {
+	if len(txns) == 0 {
+		return nil
+	}
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(txnsBucket)
+		in := tx.Bucket(incomingBucket)
+
+		for _, txn := range txns {
+			k := key(uint64(txn.Sender), uint64(txn.ID))
+
+			if v := b.Get(k); v != nil {
+				var old chainpb.Txn
+				if err := proto.Unmarshal(v, &old); err != nil {
+					return err
+				}
+				if old.SpentBy == uint64(txn.SpentBy) {
+					continue
+				}
+				old.SpentBy = uint64(txn.SpentBy)
+				if err := put(b, k, &old); err != nil {
+					return err
+				}
+				if err := addChange(tx, txnChange, k); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if txn.Hash == pt.ZeroHash {
+				txn.Hash = pt.GetHashDefault(&txn)
+			}
+			if err := put(b, k, txnToProto(&txn)); err != nil {
+				return err
+			}
+			if err := in.Put(key(uint64(txn.Receiver), uint64(txn.Sender), uint64(txn.ID)), []byte{}); err != nil {
+				return err
+			}
+			if err := addChange(tx, txnChange, k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (d *DB) PushSettings(ctx context.Context, sett *pt.Settings) error {
+	if sett == nil {
+		return nil
+	}
+	if sett.Hash == pt.ZeroHash {
+		sett.Hash = pt.GetSettingsHashDefault(sett)
+	}
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(settBucket)
+		k := key(uint64(sett.Account), uint64(sett.ID))
+		if b.Get(k) != nil {
+			return errors.Errorf("settings %v already exist", pt.NewSettingsID(sett.Account, sett.ID))
+		}
+		if err := put(b, k, settToProto(sett)); err != nil {
+			return err
+		}
+		return addChange(tx, settChange, k)
+	})
+}
+
+// Fetch returns account latest outgoing txns, unspent incoming ones and the last settings
+func (d *DB) Fetch(ctx context.Context, req *plutodbpb.FetchRequest) (*plutodbpb.FetchResponse, error) {
+	resp := &plutodbpb.FetchResponse{Status: &plutodbpb.Status{}}
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(txnsBucket).Cursor()
+		pref := key(req.Account)
+		for k, v := seekBefore(c, key(req.Account, math.MaxUint64)); bytes.HasPrefix(k, pref) && len(resp.Txns) < int(req.Limit); k, v = c.Prev() {
+			txn, err := unmarshalTxn(v)
+			if err != nil {
+				return err
+			}
+			resp.Txns = append(resp.Txns, txn)
+		}
+
+		in, err := incoming(tx, req.Account)
+		if err != nil {
+			return err
+		}
+		for _, txn := range in {
+			if txn.SpentBy == 0 {
+				resp.Txns = append(resp.Txns, txn)
+			}
+		}
+
+		sc := tx.Bucket(settBucket).Cursor()
+		k, v := seekBefore(sc, key(req.Account, math.MaxUint64))
+		if !bytes.HasPrefix(k, pref) {
+			return nil
+		}
+		resp.Settings = new(chainpb.Settings)
+		return proto.Unmarshal(v, resp.Settings)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetHistory returns account txns page. Response token is set if there could be more txns,
+// pages continued by token don't include txns processed after the first page.
+func (d *DB) GetHistory(ctx context.Context, req *plutodbpb.GetHistoryRequest) (*plutodbpb.GetHistoryResponse, error) {
+	tok := &pagetoken.Token{Account: req.Account, Filtered: hasHistoryFilters(req)}
+	if req.Token != "" {
+		t, err := pagetoken.Parse(req.Token)
+		if err == nil && (t.Account != req.Account || t.Filtered != tok.Filtered || t.Settings) {
+			err = pagetoken.ErrInvalid
+		}
+		if err != nil {
+			return &plutodbpb.GetHistoryResponse{Status: &plutodbpb.Status{Code: plutodbpb.DBStatusCode_INVALID_TOKEN, Message: err.Error()}}, nil
+		}
+		tok = t
+	}
+
+	var txns []*chainpb.Txn
+	var more bool
+	err := d.db.View(func(tx *bolt.Tx) (err error) {
+		if tok.Filtered {
+			txns, more, err = getHistoryFiltered(tx, req, tok)
+		} else {
+			txns, more, err = walkHistory(tx, req, tok)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &plutodbpb.GetHistoryResponse{
+		Status: &plutodbpb.Status{},
+		Txns:   txns,
+	}
+	if more {
+		resp.Token = tok.String()
+	}
+
+	return resp, nil
+}
+
+// walkHistory walks account chain from the token position.
+// Each outgoing txn is followed by incoming txns spent by it, unspent incoming txns are the first.
+func walkHistory(tx *bolt.Tx, req *plutodbpb.GetHistoryRequest, tok *pagetoken.Token) ([]*chainpb.Txn, bool, error) {
+	c := tx.Bucket(txnsBucket).Cursor()
+	pref := key(req.Account)
+
+	if req.Token == "" {
+		if k, _ := seekBefore(c, key(req.Account, math.MaxUint64)); bytes.HasPrefix(k, pref) {
+			tok.Head = binary.BigEndian.Uint64(k[8:])
+		}
+	}
+
+	in, err := incoming(tx, req.Account)
+	if err != nil {
+		return nil, false, err
+	}
+
+	limit := int(req.Limit)
+	var txns []*chainpb.Txn
+	for limit == 0 || len(txns) < limit {
+		if tok.Out {
+			bound := tok.ID
+			if bound == 0 {
+				bound = tok.Head + 1
+			}
+			k, v := seekBefore(c, key(req.Account, bound))
+			if !bytes.HasPrefix(k, pref) {
+				return txns, false, nil
+			}
+			out, err := unmarshalTxn(v)
+			if err != nil {
+				return nil, false, err
+			}
+
+			txns = append(txns, out)
+			tok.Out = false
+			tok.ID = out.ID
+			tok.HasKey = false
+			continue
+		}
+
+		// incoming txns spent by tok.ID; unspent at the first page could be spent by newer txns
+		for _, txn := range in {
+			if limit != 0 && len(txns) == limit {
+				break
+			}
+			if tok.ID == 0 && txn.SpentBy != 0 && txn.SpentBy <= tok.Head || tok.ID != 0 && txn.SpentBy != tok.ID {
+				continue
+			}
+			if tok.HasKey && (txn.Sender < tok.Sender || txn.Sender == tok.Sender && txn.ID <= tok.TxnID) {
+				continue
+			}
+
+			txns = append(txns, txn)
+			tok.HasKey = true
+			tok.Sender = txn.Sender
+			tok.TxnID = txn.ID
+		}
+		if limit != 0 && len(txns) == limit {
+			break
+		}
+
+		tok.Out = true
+		tok.HasKey = false
+	}
+
+	return txns, true, nil
+}
+
+// getHistoryFiltered returns account txns matching request filters ordered by processing time, latest first
+func getHistoryFiltered(tx *bolt.Tx, req *plutodbpb.GetHistoryRequest, tok *pagetoken.Token) ([]*chainpb.Txn, bool, error) {
+	all, err := accountTxns(tx, req.Account)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		a, b := all[i], all[j]
+		if a.ProcessedAt != b.ProcessedAt {
+			return a.ProcessedAt > b.ProcessedAt
+		}
+		if a.Sender != b.Sender {
+			return a.Sender < b.Sender
+		}
+		return a.ID > b.ID
+	})
+
+	var txns []*chainpb.Txn
+	for _, txn := range all {
+		if !matchHistory(req, txn) {
+			continue
+		}
+		if tok.HasKey && (txn.ProcessedAt > tok.ProcessedAt || txn.ProcessedAt == tok.ProcessedAt &&
+			(txn.Sender < tok.Sender || txn.Sender == tok.Sender && txn.ID >= tok.TxnID)) {
+			continue
+		}
+		txns = append(txns, txn)
+		if req.Limit != 0 && len(txns) == int(req.Limit) {
+			break
+		}
+	}
+
+	if req.Limit == 0 || len(txns) < int(req.Limit) {
+		return txns, false, nil
+	}
+
+	last := txns[len(txns)-1]
+	tok.HasKey = true
+	tok.ProcessedAt = last.ProcessedAt
+	tok.Sender = last.Sender
+	tok.TxnID = last.ID
+
+	return txns, true, nil
+}
+
+// GetStatement returns account balances at the period bounds, period totals and txns
+func (d *DB) GetStatement(ctx context.Context, req *plutodbpb.GetStatementRequest) (*plutodbpb.GetStatementResponse, error) {
+	resp := &plutodbpb.GetStatementResponse{Status: &plutodbpb.Status{}}
+
+	if req.ToTime != 0 && req.FromTime > req.ToTime {
+		resp.Status.Code = plutodbpb.DBStatusCode_BAD_REQUEST
+		resp.Status.Message = "from_time is after to_time"
+		return resp, nil
+	}
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		all, err := accountTxns(tx, req.Account)
+		if err != nil {
+			return err
+		}
+		in, err := incoming(tx, req.Account)
+		if err != nil {
+			return err
+		}
+
+		if req.FromTime != 0 {
+			resp.OpeningBalance = balanceAt(all, in, req.Account, req.FromTime)
+		}
+
+		to := req.ToTime
+		if to == 0 {
+			to = math.MaxInt64
+		}
+		resp.ClosingBalance = balanceAt(all, in, req.Account, to)
+
+		sort.Slice(all, func(i, j int) bool {
+			a, b := all[i], all[j]
+			if a.ProcessedAt != b.ProcessedAt {
+				return a.ProcessedAt < b.ProcessedAt
+			}
+			if a.Sender != b.Sender {
+				return a.Sender < b.Sender
+			}
+			return a.ID < b.ID
+		})
+
+		hreq := &plutodbpb.GetHistoryRequest{Account: req.Account, FromTime: req.FromTime, ToTime: req.ToTime}
+		for _, txn := range all {
+			if !matchHistory(hreq, txn) {
+				continue
+			}
+			if txn.Sender == req.Account {
+				resp.Debits += txn.Amount
+			}
+			if txn.Receiver == req.Account {
+				resp.Credits += txn.Amount
+			}
+			resp.TxnCount++
+			if !req.TotalsOnly {
+				resp.Txns = append(resp.Txns, txn)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetBalanceAt returns account balance right after outgoing txn or before the given time.
+// Balance is the last outgoing txn balance, incoming txns not spent by it are returned separately.
+func (d *DB) GetBalanceAt(ctx context.Context, req *plutodbpb.GetBalanceAtRequest) (*plutodbpb.GetBalanceAtResponse, error) {
+	resp := &plutodbpb.GetBalanceAtResponse{Status: &plutodbpb.Status{}}
+
+	if req.TxnId != 0 && req.Time != 0 {
+		resp.Status.Code = plutodbpb.DBStatusCode_BAD_REQUEST
+		resp.Status.Message = "either txn_id or time expected"
+		return resp, nil
+	}
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		t := req.Time
+		if req.TxnId != 0 {
+			v := tx.Bucket(txnsBucket).Get(key(req.Account, req.TxnId))
+			if v == nil {
+				resp.Status.Code = plutodbpb.DBStatusCode_BAD_REQUEST
+				resp.Status.Message = "txn not found"
+				return nil
+			}
+			txn, err := unmarshalTxn(v)
+			if err != nil {
+				return err
+			}
+			resp.TxnId, resp.Balance = txn.ID, txn.Balance
+			t = txn.ProcessedAt + 1
+		} else {
+			if t == 0 {
+				t = math.MaxInt64
+			}
+			out, err := outgoing(tx, req.Account)
+			if err != nil {
+				return err
+			}
+			resp.TxnId, resp.Balance = lastOutgoing(out, t)
+		}
+
+		in, err := incoming(tx, req.Account)
+		if err != nil {
+			return err
+		}
+		resp.PendingIncoming = pendingIncoming(in, t, resp.TxnId)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetPendingIncoming returns account incoming txns not spent yet, oldest first
+func (d *DB) GetPendingIncoming(ctx context.Context, req *plutodbpb.GetPendingIncomingRequest) (*plutodbpb.GetPendingIncomingResponse, error) {
+	resp := &plutodbpb.GetPendingIncomingResponse{Status: &plutodbpb.Status{}}
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		in, err := incoming(tx, req.Account)
+		if err != nil {
+			return err
+		}
+		for _, txn := range in {
+			if txn.SpentBy == 0 {
+				resp.Txns = append(resp.Txns, txn)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(resp.Txns, func(i, j int) bool {
+		return resp.Txns[i].ProcessedAt < resp.Txns[j].ProcessedAt
+	})
+
+	return resp, nil
+}
+
+// GetSettingsHistory returns account settings page, latest first.
+// Entry is marked as KeyChanged if its public key differs from the previous settings one.
+func (d *DB) GetSettingsHistory(ctx context.Context, req *plutodbpb.GetSettingsHistoryRequest) (*plutodbpb.GetSettingsHistoryResponse, error) {
+	bound := uint64(math.MaxUint64)
+	if req.Token != "" {
+		tok, err := pagetoken.Parse(req.Token)
+		if err == nil && (tok.Account != req.Account || !tok.Settings) {
+			err = pagetoken.ErrInvalid
+		}
+		if err != nil {
+			return &plutodbpb.GetSettingsHistoryResponse{Status: &plutodbpb.Status{Code: plutodbpb.DBStatusCode_INVALID_TOKEN, Message: err.Error()}}, nil
+		}
+		bound = tok.ID
+	}
+
+	var setts []*chainpb.Settings
+	err := d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(settBucket).Cursor()
+		pref := key(req.Account)
+		// one more to compare keys of the last entry and to know if there are more
+		for k, v := seekBefore(c, key(req.Account, bound)); bytes.HasPrefix(k, pref) && (req.Limit == 0 || len(setts) <= int(req.Limit)); k, v = c.Prev() {
+			s := new(chainpb.Settings)
+			if err := proto.Unmarshal(v, s); err != nil {
+				return err
+			}
+			setts = append(setts, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &plutodbpb.GetSettingsHistoryResponse{Status: &plutodbpb.Status{}}
+	for i, s := range setts {
+		if req.Limit != 0 && i == int(req.Limit) {
+			resp.Token = (&pagetoken.Token{Account: req.Account, Settings: true, ID: setts[i-1].ID}).String()
+			break
+		}
+		var prev []byte
+		if i+1 < len(setts) {
+			prev = setts[i+1].PublicKey
+		}
+		resp.Settings = append(resp.Settings, &plutodbpb.SettingsHistoryEntry{Settings: s, KeyChanged: !bytes.Equal(s.PublicKey, prev)})
+	}
+
+	return resp, nil
+}
+
+// PutData stores settings user data addressed by its hash
+func (d *DB) PutData(ctx context.Context, req *plutodbpb.PutDataRequest) (*plutodbpb.PutDataResponse, error) {
+	h := pt.GetDataHash(req.Data)
+
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(dataBucket).Put(h[:], req.Data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &plutodbpb.PutDataResponse{Status: &plutodbpb.Status{}, Hash: h[:]}, nil
+}
+
+// GetData returns settings user data by its hash
+func (d *DB) GetData(ctx context.Context, req *plutodbpb.GetDataRequest) (*plutodbpb.GetDataResponse, error) {
+	resp := &plutodbpb.GetDataResponse{Status: &plutodbpb.Status{}}
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(dataBucket).Get(req.Hash)
+		if v == nil {
+			resp.Status.Code = plutodbpb.DBStatusCode_NOT_FOUND
+			resp.Status.Message = "data not found"
+			return nil
+		}
+		resp.Data = append([]byte{}, v...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetReplicaLag is always zero, embedded db has no replicas
+func (d *DB) GetReplicaLag(ctx context.Context, req *plutodbpb.GetReplicaLagRequest) (*plutodbpb.GetReplicaLagResponse, error) {
+	return &plutodbpb.GetReplicaLagResponse{Status: &plutodbpb.Status{}}, nil
+}
+
+func (d *DB) GetTxnMulti(ctx context.Context, req *plutodbpb.GetTxnMultiRequest) (*plutodbpb.GetTxnMultiResponse, error) {
+	resp := &plutodbpb.GetTxnMultiResponse{
+		Status: &plutodbpb.Status{},
+		Txns:   make([]*chainpb.Txn, len(req.IDs)),
+		Found:  make([]bool, len(req.IDs)),
+	}
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(txnsBucket)
+		for i, id := range req.IDs {
+			v := b.Get(key(id.Account, id.ID))
+			if v == nil {
+				resp.Txns[i] = &chainpb.Txn{}
+				continue
+			}
+			txn, err := unmarshalTxn(v)
+			if err != nil {
+				return err
+			}
+			resp.Txns[i] = txn
+			resp.Found[i] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// maxChanges limits number of changes returned by StreamChanges
+const maxChanges = 1000
+
+// StreamChanges returns txns and settings stored after the cursor in commit order.
+// Writes are serialized by bolt, so sequence order is commit order.
+func (d *DB) StreamChanges(ctx context.Context, req *plutodbpb.StreamChangesRequest) (*plutodbpb.StreamChangesResponse, error) {
+	limit := req.Limit
+	if limit == 0 || limit > maxChanges {
+		limit = maxChanges
+	}
+
+	resp := &plutodbpb.StreamChangesResponse{Status: &plutodbpb.Status{}, Cursor: req.Cursor}
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(changesBucket).Cursor()
+		for k, v := c.Seek(key(req.Cursor + 1)); k != nil && len(resp.Changes) < int(limit); k, v = c.Next() {
+			ch := &plutodbpb.Change{Seq: binary.BigEndian.Uint64(k)}
+
+			switch v[0] {
+			case txnChange:
+				txn, err := unmarshalTxn(tx.Bucket(txnsBucket).Get(v[1:]))
+				if err != nil {
+					return err
+				}
+				ch.Txn = txn
+			case settChange:
+				var sett chainpb.Settings
+				if err := proto.Unmarshal(tx.Bucket(settBucket).Get(v[1:]), &sett); err != nil {
+					return err
+				}
+				ch.Settings = &sett
+			default:
+				return errors.Errorf("unknown change kind %q", v[0])
+			}
+
+			resp.Changes = append(resp.Changes, ch)
+			resp.Cursor = ch.Seq
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// addChange assigns the next sequence number to stored txn or settings.
+// Previous change of the same record is deleted, so the record is streamed once at its latest seq.
+func addChange(tx *bolt.Tx, kind byte, k []byte) error {
+	b := tx.Bucket(changesBucket)
+	seqs := tx.Bucket(changeSeqs)
+
+	ck := append([]byte{kind}, k...)
+	if old := seqs.Get(ck); old != nil {
+		if err := b.Delete(old); err != nil {
+			return err
+		}
+	}
+
+	seq, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	if err := b.Put(key(seq), ck); err != nil {
+		return err
+	}
+	return seqs.Put(ck, key(seq))
+}
+
+// outgoing returns account outgoing txns ordered by id
+func outgoing(tx *bolt.Tx, acc uint64) ([]*chainpb.Txn, error) {
+	var txns []*chainpb.Txn
+	c := tx.Bucket(txnsBucket).Cursor()
+	pref := key(acc)
+	for k, v := c.Seek(pref); bytes.HasPrefix(k, pref); k, v = c.Next() {
+		txn, err := unmarshalTxn(v)
+		if err != nil {
+			return nil, err
+		}
+		txns = append(txns, txn)
+	}
+	return txns, nil
+}
+
+// incoming returns account incoming txns ordered by sender and id
+func incoming(tx *bolt.Tx, acc uint64) ([]*chainpb.Txn, error) {
+	var txns []*chainpb.Txn
+	b := tx.Bucket(txnsBucket)
+	c := tx.Bucket(incomingBucket).Cursor()
+	pref := key(acc)
+	for k, _ := c.Seek(pref); bytes.HasPrefix(k, pref); k, _ = c.Next() {
+		v := b.Get(k[8:])
+		if v == nil {
+			return nil, errors.Errorf("incoming txn %x is missing", k)
+		}
+		txn, err := unmarshalTxn(v)
+		if err != nil {
+			return nil, err
+		}
+		txns = append(txns, txn)
+	}
+	return txns, nil
+}
+
+// accountTxns returns all account txns, each only once
+func accountTxns(tx *bolt.Tx, acc uint64) ([]*chainpb.Txn, error) {
+	txns, err := outgoing(tx, acc)
+	if err != nil {
+		return nil, err
+	}
+	in, err := incoming(tx, acc)
+	if err != nil {
+		return nil, err
+	}
+	for _, txn := range in {
+		if txn.Sender != acc {
+			txns = append(txns, txn)
+		}
+	}
+	return txns, nil
+}
+
+// balanceAt returns account balance before the given time including pending incoming txns
+func balanceAt(all, in []*chainpb.Txn, acc uint64, t int64) int64 {
+	var out []*chainpb.Txn
+	for _, txn := range all {
+		if txn.Sender == acc {
+			out = append(out, txn)
+		}
+	}
+	last, balance := lastOutgoing(out, t)
+	return balance + pendingIncoming(in, t, last)
+}
+
+// lastOutgoing returns id and balance of the last outgoing txn processed before t
+func lastOutgoing(out []*chainpb.Txn, t int64) (id uint64, balance int64) {
+	var last *chainpb.Txn
+	for _, txn := range out {
+		if txn.ProcessedAt >= t {
+			continue
+		}
+		if last == nil || txn.ProcessedAt > last.ProcessedAt || txn.ProcessedAt == last.ProcessedAt && txn.ID > last.ID {
+			last = txn
+		}
+	}
+	if last == nil {
+		return 0, 0
+	}
+	return last.ID, last.Balance
+}
+
+// pendingIncoming returns sum of incoming txns processed before t and not spent by outgoing txn last
+func pendingIncoming(in []*chainpb.Txn, t int64, last uint64) int64 {
+	var sum int64
+	for _, txn := range in {
+		if txn.ProcessedAt < t && (txn.SpentBy == 0 || txn.SpentBy > last) {
+			sum += txn.Amount
+		}
+	}
+	return sum
+}
+
+func hasHistoryFilters(req *plutodbpb.GetHistoryRequest) bool {
+	return req.FromTime != 0 || req.ToTime != 0 || req.Direction != plutodbpb.HistoryDirection_ALL ||
+		req.Counterparty != 0 || req.MinAmount != 0 || req.MaxAmount != 0
+}
+
+// matchHistory checks if account txn matches history request filters
+func matchHistory(req *plutodbpb.GetHistoryRequest, txn *chainpb.Txn) bool {
+	acc, cp := req.Account, req.Counterparty
+
+	switch req.Direction {
+	case plutodbpb.HistoryDirection_INCOMING:
+		if txn.Receiver != acc || cp != 0 && txn.Sender != cp {
+			return false
+		}
+	case plutodbpb.HistoryDirection_OUTGOING:
+		if txn.Sender != acc || cp != 0 && txn.Receiver != cp {
+			return false
+		}
+	default:
+		if cp != 0 && !(txn.Sender == acc && txn.Receiver == cp || txn.Sender == cp && txn.Receiver == acc) {
+			return false
+		}
+	}
+
+	if req.FromTime != 0 && txn.ProcessedAt < req.FromTime {
+		return false
+	}
+	if req.ToTime != 0 && txn.ProcessedAt >= req.ToTime {
+		return false
+	}
+	if req.MinAmount != 0 && txn.Amount < req.MinAmount {
+		return false
+	}
+	if req.MaxAmount != 0 && txn.Amount > req.MaxAmount {
+		return false
+	}
+
+	return true
+}
+
+// seekBefore moves cursor to the last key less than k
+func seekBefore(c *bolt.Cursor, k []byte) ([]byte, []byte) {
+	if ck, _ := c.Seek(k); ck == nil {
+		return c.Last()
+	}
+	return c.Prev()
+}
+
+// key makes big endian key, so keys are ordered as numbers
+func key(vs ...uint64) []byte {
+	k := make([]byte, 8*len(vs))
+	for i, v := range vs {
+		binary.BigEndian.PutUint64(k[8*i:], v)
+	}
+	return k
+}
+
+func put(b *bolt.Bucket, k []byte, m proto.Message) error {
+	v, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return b.Put(k, v)
+}
+
+func unmarshalTxn(v []byte) (*chainpb.Txn, error) {
+	txn := new(chainpb.Txn)
+	if err := proto.Unmarshal(v, txn); err != nil {
+		return nil, err
+	}
+	return txn, nil
+}
+
+func txnToProto(t *pt.Txn) *chainpb.Txn {
+	txn := &chainpb.Txn{
+		ID:          uint64(t.ID),
+		Sender:      uint64(t.Sender),
+		Receiver:    uint64(t.Receiver),
+		Amount:      t.Amount,
+		Balance:     t.Balance,
+		SpentBy:     uint64(t.SpentBy),
+		SettingsId:  uint64(t.SettingsID),
+		PrevHash:    t.PrevHash[:],
+		Hash:        t.Hash[:],
+		ProcessedAt: t.ProcessedAt,
+	}
+	if t.Sign != pt.ZeroSign {
+		txn.Sign = t.Sign[:]
+	}
+	return txn
+}
+
+func settToProto(s *pt.Settings) *chainpb.Settings {
+	return &chainpb.Settings{
+		ID:                 uint64(s.ID),
+		Account:            uint64(s.Account),
+		Hash:               s.Hash[:],
+		PrevHash:           s.PrevHash[:],
+		PublicKey:          s.PublicKey[:],
+		Sign:               s.Sign[:],
+		DataHash:           s.DataHash[:],
+		VerifyTransferSign: s.VerifyTransferSign,
+		ServerSequencing:   s.ServerSequencing,
+		Registered:         s.Registered,
+		Closed:             s.Closed,
+		SweepTo:            uint64(s.SweepTo),
+		ProcessedAt:        s.ProcessedAt,
+	}
+}
@@ -0,0 +1,310 @@
+package boltchain
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/bigchain"
+	"github.com/qiwitech/qdp/chain"
+	"github.com/qiwitech/qdp/preloader"
+	"github.com/qiwitech/qdp/processor"
+	"github.com/qiwitech/qdp/proto/chainpb"
+	"github.com/qiwitech/qdp/proto/plutodbpb"
+	"github.com/qiwitech/qdp/pt"
+	"github.com/qiwitech/qdp/pusher"
+	"github.com/qiwitech/qdp/pusher/seqpusher"
+)
+
+func createDB(t *testing.T) (*DB, func()) {
+	file, err := ioutil.TempFile(os.TempDir(), "boltchain_test_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	db, err := bolt.Open(file.Name(), 0666, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d, func() {
+		db.Close()
+		os.Remove(file.Name())
+	}
+}
+
+// fill makes 0 -> 20 (1000), 0 -> 20 (500), 20 -> 30 (100), 0 -> 20 (50) txns processed a second apart
+func fill(t *testing.T, d *DB) {
+	c := chain.NewChain()
+	p := processor.NewProcessor(c)
+	p.SetPusher(seqpusher.New(pusher.NewChainReceiversPusher(c), d))
+
+	now := time.Unix(1500000000, 0)
+	p.SetClock(func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	})
+
+	res, err := p.ProcessTransfer(context.TODO(), pt.NewSingleTransfer(0, 20, 1000))
+	assert.NoError(t, err)
+
+	tr := pt.NewSingleTransfer(0, 20, 500)
+	tr.PrevHash = res.Hash
+	res, err = p.ProcessTransfer(context.TODO(), tr)
+	assert.NoError(t, err)
+
+	_, err = p.ProcessTransfer(context.TODO(), pt.NewSingleTransfer(20, 30, 100))
+	assert.NoError(t, err)
+
+	tr = pt.NewSingleTransfer(0, 20, 50)
+	tr.PrevHash = res.Hash
+	_, err = p.ProcessTransfer(context.TODO(), tr)
+	assert.NoError(t, err)
+}
+
+func ids(txns []*chainpb.Txn) []pt.TxnID {
+	var r []pt.TxnID
+	for _, txn := range txns {
+		r = append(r, pt.NewTxnID(pt.AccID(txn.Sender), pt.ID(txn.ID)))
+	}
+	return r
+}
+
+func TestFetchPreload(t *testing.T) {
+	d, closer := createDB(t)
+	defer closer()
+
+	fill(t, d)
+
+	resp, err := d.Fetch(context.TODO(), &plutodbpb.FetchRequest{Account: 20, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []pt.TxnID{{AccID: 20, ID: 1}, {AccID: 0, ID: 3}}, ids(resp.Txns))
+
+	c := chain.NewChain()
+	prel := preloader.New(c, chain.NewSettingsChain(), bigchain.New(d))
+	assert.NoError(t, prel.Preload(context.TODO(), 20))
+	assert.Equal(t, int64(1450), c.GetBalance(20))
+}
+
+func TestGetHistory(t *testing.T) {
+	d, closer := createDB(t)
+	defer closer()
+
+	fill(t, d)
+
+	all := []pt.TxnID{{AccID: 0, ID: 3}, {AccID: 20, ID: 1}, {AccID: 0, ID: 1}, {AccID: 0, ID: 2}}
+
+	resp, err := d.GetHistory(context.TODO(), &plutodbpb.GetHistoryRequest{Account: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, all, ids(resp.Txns))
+
+	// page by page
+	var got []*chainpb.Txn
+	req := &plutodbpb.GetHistoryRequest{Account: 20, Limit: 1}
+	for i := 0; i < 10; i++ {
+		resp, err = d.GetHistory(context.TODO(), req)
+		assert.NoError(t, err)
+		got = append(got, resp.Txns...)
+		if resp.Token == "" {
+			break
+		}
+		req.Token = resp.Token
+	}
+	assert.Equal(t, all, ids(got))
+
+	// filtered
+	resp, err = d.GetHistory(context.TODO(), &plutodbpb.GetHistoryRequest{Account: 20, Direction: plutodbpb.HistoryDirection_INCOMING, MinAmount: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, []pt.TxnID{{AccID: 0, ID: 2}, {AccID: 0, ID: 1}}, ids(resp.Txns))
+
+	resp, err = d.GetHistory(context.TODO(), &plutodbpb.GetHistoryRequest{Account: 20, Counterparty: 30, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []pt.TxnID{{AccID: 20, ID: 1}}, ids(resp.Txns))
+	if assert.NotEmpty(t, resp.Token) {
+		resp, err = d.GetHistory(context.TODO(), &plutodbpb.GetHistoryRequest{Account: 20, Counterparty: 30, Limit: 1, Token: resp.Token})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Txns)
+		assert.Empty(t, resp.Token)
+	}
+
+	resp, err = d.GetHistory(context.TODO(), &plutodbpb.GetHistoryRequest{Account: 20, Token: "bad"})
+	assert.NoError(t, err)
+	assert.Equal(t, plutodbpb.DBStatusCode_INVALID_TOKEN, resp.Status.Code)
+}
+
+func TestGetStatementAndBalance(t *testing.T) {
+	d, closer := createDB(t)
+	defer closer()
+
+	fill(t, d)
+
+	resp, err := d.GetStatement(context.TODO(), &plutodbpb.GetStatementRequest{Account: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), resp.OpeningBalance)
+	assert.Equal(t, int64(1450), resp.ClosingBalance)
+	assert.Equal(t, int64(100), resp.Debits)
+	assert.Equal(t, int64(1550), resp.Credits)
+	assert.Equal(t, uint64(4), resp.TxnCount)
+	assert.Equal(t, []pt.TxnID{{AccID: 0, ID: 1}, {AccID: 0, ID: 2}, {AccID: 20, ID: 1}, {AccID: 0, ID: 3}}, ids(resp.Txns))
+
+	// from the second txn till the last one
+	from, to := time.Unix(1500000002, 0).UnixNano(), time.Unix(1500000004, 0).UnixNano()
+	resp, err = d.GetStatement(context.TODO(), &plutodbpb.GetStatementRequest{Account: 20, FromTime: from, ToTime: to, TotalsOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &plutodbpb.GetStatementResponse{Status: &plutodbpb.Status{}, OpeningBalance: 1000, ClosingBalance: 1400, Debits: 100, Credits: 500, TxnCount: 2}, resp)
+
+	bresp, err := d.GetBalanceAt(context.TODO(), &plutodbpb.GetBalanceAtRequest{Account: 20, TxnId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, &plutodbpb.GetBalanceAtResponse{Status: &plutodbpb.Status{}, Balance: 1400, TxnId: 1}, bresp)
+
+	bresp, err = d.GetBalanceAt(context.TODO(), &plutodbpb.GetBalanceAtRequest{Account: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, &plutodbpb.GetBalanceAtResponse{Status: &plutodbpb.Status{}, Balance: 1400, PendingIncoming: 50, TxnId: 1}, bresp)
+
+	bresp, err = d.GetBalanceAt(context.TODO(), &plutodbpb.GetBalanceAtRequest{Account: 20, TxnId: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, plutodbpb.DBStatusCode_BAD_REQUEST, bresp.Status.Code)
+
+	presp, err := d.GetPendingIncoming(context.TODO(), &plutodbpb.GetPendingIncomingRequest{Account: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, []pt.TxnID{{AccID: 0, ID: 3}}, ids(presp.Txns))
+}
+
+func TestGetTxnMulti(t *testing.T) {
+	d, closer := createDB(t)
+	defer closer()
+
+	fill(t, d)
+
+	resp, err := d.GetTxnMulti(context.TODO(), &plutodbpb.GetTxnMultiRequest{IDs: []*chainpb.TxnID{{Account: 20, ID: 1}, {Account: 0, ID: 2}}})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Txns, 2) {
+		assert.Equal(t, int64(100), resp.Txns[0].Amount)
+		assert.Equal(t, int64(500), resp.Txns[1].Amount)
+		assert.Equal(t, uint64(1), resp.Txns[1].SpentBy)
+	}
+
+	assert.Equal(t, []bool{true, true}, resp.Found)
+
+	resp, err = d.GetTxnMulti(context.TODO(), &plutodbpb.GetTxnMultiRequest{IDs: []*chainpb.TxnID{{Account: 20, ID: 2}, {Account: 20, ID: 1}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false, true}, resp.Found)
+	assert.Equal(t, []pt.TxnID{{AccID: 0, ID: 0}, {AccID: 20, ID: 1}}, ids(resp.Txns))
+}
+
+func TestSettings(t *testing.T) {
+	d, closer := createDB(t)
+	defer closer()
+
+	k1, k2 := pt.PublicKey{1}, pt.PublicKey{2}
+	for i, k := range []pt.PublicKey{k1, k1, k2} {
+		assert.NoError(t, d.PushSettings(context.TODO(), &pt.Settings{Account: 20, ID: pt.ID(i + 1), PublicKey: k}))
+	}
+	assert.Error(t, d.PushSettings(context.TODO(), &pt.Settings{Account: 20, ID: 3}))
+
+	fresp, err := d.Fetch(context.TODO(), &plutodbpb.FetchRequest{Account: 20, Limit: 10})
+	assert.NoError(t, err)
+	if assert.NotNil(t, fresp.Settings) {
+		assert.Equal(t, uint64(3), fresp.Settings.ID)
+	}
+
+	resp, err := d.GetSettingsHistory(context.TODO(), &plutodbpb.GetSettingsHistoryRequest{Account: 20, Limit: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Settings, 2) {
+		assert.Equal(t, uint64(3), resp.Settings[0].Settings.ID)
+		assert.True(t, resp.Settings[0].KeyChanged)
+		assert.Equal(t, uint64(2), resp.Settings[1].Settings.ID)
+		assert.False(t, resp.Settings[1].KeyChanged)
+	}
+
+	resp, err = d.GetSettingsHistory(context.TODO(), &plutodbpb.GetSettingsHistoryRequest{Account: 20, Limit: 2, Token: resp.Token})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Settings, 1) {
+		assert.Equal(t, uint64(1), resp.Settings[0].Settings.ID)
+		assert.True(t, resp.Settings[0].KeyChanged)
+	}
+	assert.Empty(t, resp.Token)
+
+	presp, err := d.PutData(context.TODO(), &plutodbpb.PutDataRequest{Data: []byte("profile")})
+	assert.NoError(t, err)
+	h := pt.GetDataHash([]byte("profile"))
+	assert.Equal(t, h[:], presp.Hash)
+
+	gresp, err := d.GetData(context.TODO(), &plutodbpb.GetDataRequest{Hash: h[:]})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("profile"), gresp.Data)
+
+	gresp, err = d.GetData(context.TODO(), &plutodbpb.GetDataRequest{Hash: []byte{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, plutodbpb.DBStatusCode_NOT_FOUND, gresp.Status.Code)
+}
+
+func TestStreamChanges(t *testing.T) {
+	d, closer := createDB(t)
+	defer closer()
+
+	fill(t, d)
+	assert.NoError(t, d.PushSettings(context.TODO(), &pt.Settings{Account: 30, ID: 1}))
+
+	// txns spent by 20/1 are moved after it
+	resp, err := d.StreamChanges(context.TODO(), &plutodbpb.StreamChangesRequest{Limit: 3})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Changes, 3)
+	assert.Equal(t, uint64(5), resp.Cursor)
+
+	var txns []*chainpb.Txn
+	for _, ch := range resp.Changes {
+		txns = append(txns, ch.Txn)
+	}
+	assert.Equal(t, []pt.TxnID{{AccID: 20, ID: 1}}, ids(txns[:1]))
+	for _, txn := range txns[1:] {
+		assert.Equal(t, uint64(0), txn.Sender)
+		assert.Equal(t, uint64(1), txn.SpentBy)
+	}
+
+	resp, err = d.StreamChanges(context.TODO(), &plutodbpb.StreamChangesRequest{Cursor: resp.Cursor})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Changes, 2) {
+		assert.Equal(t, uint64(3), resp.Changes[0].Txn.ID)
+		assert.Equal(t, uint64(30), resp.Changes[1].Settings.Account)
+	}
+	assert.Equal(t, uint64(7), resp.Cursor)
+
+	// up to date
+	resp, err = d.StreamChanges(context.TODO(), &plutodbpb.StreamChangesRequest{Cursor: resp.Cursor})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Changes)
+	assert.Equal(t, uint64(7), resp.Cursor)
+
+	// repeated push without changes isn't a change, spent_by update is
+	assert.NoError(t, d.Push(context.TODO(), []pt.Txn{{ID: 1, Sender: 0, Receiver: 20, SpentBy: 1}}))
+	assert.NoError(t, d.Push(context.TODO(), []pt.Txn{{ID: 3, Sender: 0, Receiver: 20, SpentBy: 2}}))
+
+	resp, err = d.StreamChanges(context.TODO(), &plutodbpb.StreamChangesRequest{Cursor: 7})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Changes, 1) {
+		assert.Equal(t, uint64(8), resp.Changes[0].Seq)
+		assert.Equal(t, uint64(3), resp.Changes[0].Txn.ID)
+		assert.Equal(t, uint64(2), resp.Changes[0].Txn.SpentBy)
+	}
+
+	// superseded change is gone
+	resp, err = d.StreamChanges(context.TODO(), &plutodbpb.StreamChangesRequest{})
+	assert.NoError(t, err)
+	var seqs []uint64
+	for _, ch := range resp.Changes {
+		seqs = append(seqs, ch.Seq)
+	}
+	assert.Equal(t, []uint64{3, 4, 5, 7, 8}, seqs)
+}
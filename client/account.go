@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	cli "gopkg.in/urfave/cli.v2"
+
+	"github.com/qiwitech/qdp/proto/apipb"
+	"github.com/qiwitech/qdp/pt"
+)
+
+// RegisterAccount sets Registered settings flag, so account could receive transfers
+// from nodes which require receivers to be registered.
+func RegisterAccount(cx *cli.Context) error {
+	args := cx.Args()
+
+	if args.Len() != 1 {
+		cli.ShowSubcommandHelp(cx)
+		return errors.New("expected exactly one argument")
+	}
+
+	u, err := accountFromArgs(args)
+	if err != nil {
+		cli.ShowSubcommandHelp(cx)
+		return err
+	}
+
+	if err := connect(); err != nil {
+		return err
+	}
+
+	s, err := api.GetLastSettings(context.TODO(), &apipb.GetLastSettingsRequest{Account: u})
+	if err != nil {
+		return err
+	}
+
+	if s.Registered {
+		// already registered
+		return nil
+	}
+
+	sreq := &apipb.SettingsRequest{
+		Account:            u,
+		PrevHash:           s.Hash,
+		DataHash:           s.DataHash,
+		VerifyTransferSign: s.VerifyTransferSign,
+		ServerSequencing:   s.ServerSequencing,
+		PublicKey:          s.PublicKey,
+		Registered:         true, // changed field
+	}
+
+	resp, err := updateSettings(cx, sreq)
+	if err != nil {
+		return err
+	}
+
+	err = inspectStatus(resp.Status)
+	if err != nil {
+		return err
+	}
+
+	printResponse(cx, resp)
+
+	return nil
+}
+
+// CloseAccount closes account sweeping its balance to another one
+func CloseAccount(cx *cli.Context) error {
+	args := cx.Args()
+
+	if args.Len() != 2 {
+		cli.ShowSubcommandHelp(cx)
+		return errors.New("expected exactly two arguments")
+	}
+
+	u, err := accountFromArgs(args)
+	if err != nil {
+		cli.ShowSubcommandHelp(cx)
+		return err
+	}
+
+	to, err := parseAccount(args.Get(1))
+	if err != nil {
+		cli.ShowSubcommandHelp(cx)
+		return err
+	}
+
+	if err := connect(); err != nil {
+		return err
+	}
+
+	s, err := api.GetLastSettings(context.TODO(), &apipb.GetLastSettingsRequest{Account: u})
+	if err != nil {
+		return err
+	}
+
+	req := &apipb.CloseAccountRequest{
+		Account:  u,
+		SweepTo:  to,
+		PrevHash: s.Hash,
+	}
+	if s.Closed {
+		// retry to sweep the rest
+		req.PrevHash = s.PrevHash
+	}
+
+	prv, err := LoadPrivateKey(u)
+	if err != nil {
+		return err
+	}
+
+	if prv != nil {
+		hash := CloseRequestHash(&apipb.SettingsRequest{
+			Account:            u,
+			PrevHash:           req.PrevHash,
+			DataHash:           s.DataHash,
+			VerifyTransferSign: s.VerifyTransferSign,
+			ServerSequencing:   s.ServerSequencing,
+			PublicKey:          s.PublicKey,
+			Registered:         s.Registered,
+		}, to)
+		sign, err := pt.SignTransfer(hash, prv)
+		if err != nil {
+			return err
+		}
+		req.Sign = sign.String()
+	}
+
+	if VerboseFlag {
+		printRequest(cx, req)
+	}
+
+	resp, err := api.CloseAccount(context.TODO(), req)
+	if err != nil {
+		return err
+	}
+
+	err = inspectStatus(resp.Status)
+	if err != nil {
+		return err
+	}
+
+	printResponse(cx, resp)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/proto/apipb"
+	"github.com/qiwitech/qdp/pt"
+)
+
+func TestRegisterAccount(t *testing.T) {
+	KeysDBFlag = ""
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := initMockAPI(ctrl)
+
+	c, stdOut, stdErr := mockCli([]string{"11111"})
+
+	api.EXPECT().GetLastSettings(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(&apipb.GetLastSettingsResponse{Status: &apipb.Status{}, Hash: "prev", ServerSequencing: true}, nil)
+	api.EXPECT().UpdateSettings(gomock.Any(), &apipb.SettingsRequest{
+		Account:          11111,
+		PrevHash:         "prev",
+		ServerSequencing: true,
+		Registered:       true,
+	}).
+		Times(1).
+		Return(&apipb.SettingsResponse{Status: &apipb.Status{}, SettingsId: "11111_2"}, nil)
+
+	err := RegisterAccount(c)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "{\n  \"status\": {},\n  \"settings_id\": \"11111_2\"\n}\n", stdOut())
+	assert.Equal(t, "", stdErr())
+}
+
+func TestCloseAccount(t *testing.T) {
+	KeysDBFlag = ""
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := initMockAPI(ctrl)
+
+	c, stdOut, stdErr := mockCli([]string{"11111", "22222"})
+
+	api.EXPECT().GetLastSettings(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(&apipb.GetLastSettingsResponse{Status: &apipb.Status{}, Hash: "prev", Registered: true}, nil)
+	api.EXPECT().CloseAccount(gomock.Any(), &apipb.CloseAccountRequest{
+		Account:  11111,
+		SweepTo:  22222,
+		PrevHash: "prev",
+	}).
+		Times(1).
+		Return(&apipb.CloseAccountResponse{Status: &apipb.Status{}, SettingsId: "11111_3", TxnId: "11111_5"}, nil)
+
+	err := CloseAccount(c)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "{\n  \"status\": {},\n  \"settings_id\": \"11111_3\",\n  \"txn_id\": \"11111_5\"\n}\n", stdOut())
+	assert.Equal(t, "", stdErr())
+}
+
+func TestCloseRequestHash(t *testing.T) {
+	s := &pt.Settings{
+		Account:    10,
+		PrevHash:   pt.HashFromString("123123"),
+		Registered: true,
+		Closed:     true,
+		SweepTo:    20,
+	}
+
+	h := CloseRequestHash(&apipb.SettingsRequest{
+		Account:    10,
+		PrevHash:   s.PrevHash.String(),
+		DataHash:   s.DataHash.String(),
+		Registered: true,
+	}, 20)
+
+	assert.Equal(t, pt.GetSettingsRequestHashDefault(s), h)
+}
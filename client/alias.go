@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	cli "gopkg.in/urfave/cli.v2"
+
+	"github.com/qiwitech/qdp/proto/apipb"
+	"github.com/qiwitech/qdp/pt"
+)
+
+// RegisterAlias binds alias to account. Account private key is required to sign request
+func RegisterAlias(cx *cli.Context) error {
+	args := cx.Args()
+
+	if args.Len() != 2 {
+		cli.ShowSubcommandHelp(cx)
+		return errors.New("expected exactly two arguments")
+	}
+
+	u, err := accountFromArgs(args)
+	if err != nil {
+		cli.ShowSubcommandHelp(cx)
+		return err
+	}
+
+	// server stores and checks sign of normalized alias
+	alias := pt.NormalizeAlias(strings.TrimPrefix(args.Get(1), "@"))
+
+	prv, err := LoadPrivateKey(u)
+	if err != nil {
+		return err
+	}
+	if prv == nil {
+		return errors.New("no private key for account")
+	}
+
+	sign, err := pt.SignTransfer(pt.GetAliasHashDefault(alias, pt.AccID(u)), prv)
+	if err != nil {
+		return err
+	}
+
+	req := &apipb.RegisterAliasRequest{Alias: alias, Account: u, Sign: sign.String()}
+
+	if err := connect(); err != nil {
+		return err
+	}
+
+	if VerboseFlag {
+		printRequest(cx, req)
+	}
+
+	resp, err := api.RegisterAlias(context.TODO(), req)
+	if err != nil {
+		return err
+	}
+
+	err = inspectStatus(resp.Status)
+	if err != nil {
+		return err
+	}
+
+	printResponse(cx, resp)
+
+	return nil
+}
+
+// ResolveAlias prints account alias is bound to
+func ResolveAlias(cx *cli.Context) error {
+	args := cx.Args()
+
+	if args.Len() != 1 {
+		cli.ShowSubcommandHelp(cx)
+		return errors.New("expected exactly one argument")
+	}
+
+	if err := connect(); err != nil {
+		return err
+	}
+
+	resp, err := api.ResolveAlias(context.TODO(), &apipb.ResolveAliasRequest{Alias: strings.TrimPrefix(args.First(), "@")})
+	if err != nil {
+		return err
+	}
+
+	err = inspectStatus(resp.Status)
+	if err != nil {
+		return err
+	}
+
+	printResponse(cx, resp)
+
+	return nil
+}
+
+// parseAccount parses account number or resolves alias.
+// Argument starting with @ is always an alias. With --hex aliases must start with @,
+// so that alias looking like hex number (face, cafe) isn't taken for account.
+func parseAccount(s string) (uint64, error) {
+	if !strings.HasPrefix(s, "@") {
+		base := 10
+		if HexFlag {
+			base = 16
+		}
+		u, err := strconv.ParseUint(s, base, 64)
+		if err == nil {
+			return u, nil
+		}
+		if ne, ok := err.(*strconv.NumError); !ok || ne.Err != strconv.ErrSyntax {
+			return 0, err
+		}
+		if HexFlag {
+			return 0, fmt.Errorf("bad hex account %q, aliases must start with @ in hex mode", s)
+		}
+	}
+
+	if err := connect(); err != nil {
+		return 0, err
+	}
+
+	resp, err := api.ResolveAlias(context.TODO(), &apipb.ResolveAliasRequest{Alias: strings.TrimPrefix(s, "@")})
+	if err != nil {
+		return 0, err
+	}
+
+	err = inspectStatus(resp.Status)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Account, nil
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/proto/apipb"
+)
+
+func TestTransferAlias(t *testing.T) {
+	KeysDBFlag = ""
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := initMockAPI(ctrl)
+
+	c, stdOut, stdErr := mockCli([]string{"@alice", "bob@example.com", "10", "33333", "20"})
+
+	api.EXPECT().ResolveAlias(gomock.Any(), &apipb.ResolveAliasRequest{Alias: "alice"}).
+		Times(1).
+		Return(&apipb.ResolveAliasResponse{Status: &apipb.Status{}, Account: 11111}, nil)
+	api.EXPECT().ResolveAlias(gomock.Any(), &apipb.ResolveAliasRequest{Alias: "bob@example.com"}).
+		Times(1).
+		Return(&apipb.ResolveAliasResponse{Status: &apipb.Status{}, Account: 22222}, nil)
+	api.EXPECT().GetPrevHash(gomock.Any(), &apipb.GetPrevHashRequest{Account: 11111}).
+		Times(1).
+		Return(&apipb.GetPrevHashResponse{Status: &apipb.Status{}, Hash: "prev_hash"}, nil)
+	api.EXPECT().GetLastSettings(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(&apipb.GetLastSettingsResponse{Status: &apipb.Status{}}, nil)
+	api.EXPECT().ProcessTransfer(gomock.Any(), gomock.Any()).
+		Do(func(_ interface{}, req *apipb.TransferRequest) {
+			assert.Equal(t, uint64(11111), req.Sender)
+			assert.Equal(t, []*apipb.TransferItem{{Receiver: 22222, Amount: 10}, {Receiver: 33333, Amount: 20}}, req.Batch)
+		}).
+		Times(1).
+		Return(&apipb.TransferResponse{Status: &apipb.Status{}, Hash: "txn_hash"}, nil)
+
+	err := Transfer(c)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "{\n  \"status\": {},\n  \"hash\": \"txn_hash\"\n}\n", stdOut())
+	assert.Equal(t, "", stdErr())
+}
+
+func TestParseAccountAliasNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := initMockAPI(ctrl)
+
+	api.EXPECT().ResolveAlias(gomock.Any(), &apipb.ResolveAliasRequest{Alias: "carol"}).
+		Times(1).
+		Return(&apipb.ResolveAliasResponse{Status: &apipb.Status{Code: apipb.TransferCode_ALIAS_NOT_FOUND, Message: "not found"}}, nil)
+
+	_, err := parseAccount("carol")
+	assert.EqualError(t, err, "not found")
+
+	_, err = parseAccount("99999999999999999999")
+	assert.Error(t, err)
+}
+
+func TestParseAccountHexAlias(t *testing.T) {
+	HexFlag = true
+	defer func() { HexFlag = false }()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := initMockAPI(ctrl)
+
+	// hex looking alias is never resolved without @
+	acc, err := parseAccount("face")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0xface), acc)
+
+	api.EXPECT().ResolveAlias(gomock.Any(), &apipb.ResolveAliasRequest{Alias: "face"}).
+		Times(1).
+		Return(&apipb.ResolveAliasResponse{Status: &apipb.Status{}, Account: 11111}, nil)
+
+	acc, err = parseAccount("@face")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(11111), acc)
+
+	_, err = parseAccount("carol")
+	assert.EqualError(t, err, `bad hex account "carol", aliases must start with @ in hex mode`)
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	cli "gopkg.in/urfave/cli.v2"
+
+	"github.com/qiwitech/qdp/handoff"
+	"github.com/qiwitech/qdp/replica"
+)
+
+// Handoff moves accounts range starting at shard point to another plutos node
+func Handoff(cx *cli.Context) error {
+	args := cx.Args()
+	if args.Len() != 2 {
+		cli.ShowSubcommandHelp(cx)
+		return ErrArguments
+	}
+
+	shard, err := strconv.ParseUint(args.Get(0), 10, 64)
+	if err != nil {
+		return err
+	}
+	host := args.Get(1)
+
+	node := cx.String("node")
+	if node == "" {
+		return fmt.Errorf("--node is required")
+	}
+
+	err = handoff.Migrate(context.TODO(), node, shard, host)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cx.App.Writer, "range %d moved to %s\n", shard, host)
+
+	return nil
+}
+
+// Promote promotes hot-standby replica and gives it all ranges of failed node
+func Promote(cx *cli.Context) error {
+	args := cx.Args()
+	if args.Len() != 2 {
+		cli.ShowSubcommandHelp(cx)
+		return ErrArguments
+	}
+
+	failed, standby := args.Get(0), args.Get(1)
+
+	node := cx.String("node")
+	if node == "" {
+		return fmt.Errorf("--node is required")
+	}
+
+	err := replica.PromoteNode(context.TODO(), standby)
+	if err != nil {
+		return err
+	}
+
+	err = handoff.Failover(context.TODO(), node, failed, standby)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cx.App.Writer, "%s promoted instead of %s\n", standby, failed)
+
+	return nil
+}
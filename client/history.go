@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"time"
 
+	"github.com/pkg/errors"
 	cli "gopkg.in/urfave/cli.v2"
 
 	"github.com/qiwitech/qdp/proto/apipb"
@@ -18,11 +20,37 @@ func GetHistory(cx *cli.Context) error {
 	lim := cx.Int("limit")
 	token := cx.String("token")
 
+	req := &apipb.GetHistoryRequest{Account: u, Limit: uint32(lim), Token: token}
+
+	now := time.Now()
+	if req.FromTime, err = parseTimeArg(cx.String("from"), now); err != nil {
+		return errors.Wrap(err, "from")
+	}
+	if req.ToTime, err = parseTimeArg(cx.String("to"), now); err != nil {
+		return errors.Wrap(err, "to")
+	}
+
+	switch in, out := cx.Bool("in"), cx.Bool("out"); {
+	case in && !out:
+		req.Direction = apipb.HistoryDirection_INCOMING
+	case out && !in:
+		req.Direction = apipb.HistoryDirection_OUTGOING
+	}
+
+	if with := cx.String("with"); with != "" {
+		if req.Counterparty, err = parseAccount(with); err != nil {
+			return errors.Wrap(err, "with")
+		}
+	}
+
+	req.MinAmount = cx.Int64("min")
+	req.MaxAmount = cx.Int64("max")
+
 	if err := connect(); err != nil {
 		return err
 	}
 
-	resp, err := api.GetHistory(context.TODO(), &apipb.GetHistoryRequest{Account: u, Limit: uint32(lim), Token: token})
+	resp, err := api.GetHistory(context.TODO(), req)
 	if err != nil {
 		return err
 	}
@@ -32,7 +60,44 @@ func GetHistory(cx *cli.Context) error {
 		return err
 	}
 
+	// follow next page tokens
+	for cx.Bool("all") && resp.Token != "" {
+		req.Token = resp.Token
+
+		page, err := api.GetHistory(context.TODO(), req)
+		if err != nil {
+			return err
+		}
+
+		err = inspectStatus(page.Status)
+		if err != nil {
+			return err
+		}
+
+		resp.Txns = append(resp.Txns, page.Txns...)
+		resp.Token = page.Token
+	}
+
 	printResponse(cx, resp)
 
 	return nil
 }
+
+// parseTimeArg accepts RFC3339 time, date (2006-01-02) or duration back from now (168h)
+// and returns RFC3339 time
+func parseTimeArg(s string, now time.Time) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.Format(time.RFC3339Nano), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t.Format(time.RFC3339Nano), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return "", errors.Errorf("unsupported time format %q", s)
+	}
+	return now.Add(-d).Format(time.RFC3339Nano), nil
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeArg(t *testing.T) {
+	now := time.Date(2017, 7, 21, 10, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		arg, res string
+	}{
+		{"", ""},
+		{"2017-07-14T10:00:00Z", "2017-07-14T10:00:00Z"},
+		{"2017-07-14T10:00:00.5+03:00", "2017-07-14T10:00:00.5+03:00"},
+		{"168h", "2017-07-14T10:00:00Z"},
+		{"90m", "2017-07-21T08:30:00Z"},
+	} {
+		res, err := parseTimeArg(tc.arg, now)
+		assert.NoError(t, err, tc.arg)
+		assert.Equal(t, tc.res, res, tc.arg)
+	}
+
+	res, err := parseTimeArg("2017-07-14", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2017, 7, 14, 0, 0, 0, 0, time.Local).Format(time.RFC3339), res)
+
+	_, err = parseTimeArg("last week", now)
+	assert.EqualError(t, err, `unsupported time format "last week"`)
+}
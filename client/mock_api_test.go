@@ -31,6 +31,28 @@ func (_m *MockAPIServiceInterface) EXPECT() *_MockAPIServiceInterfaceRecorder {
 	return _m.recorder
 }
 
+func (_m *MockAPIServiceInterface) BulkProcessTransfer(_param0 context.Context, _param1 *apipb.BulkTransferRequest) (*apipb.BulkTransferResponse, error) {
+	ret := _m.ctrl.Call(_m, "BulkProcessTransfer", _param0, _param1)
+	ret0, _ := ret[0].(*apipb.BulkTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockAPIServiceInterfaceRecorder) BulkProcessTransfer(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BulkProcessTransfer", arg0, arg1)
+}
+
+func (_m *MockAPIServiceInterface) CloseAccount(_param0 context.Context, _param1 *apipb.CloseAccountRequest) (*apipb.CloseAccountResponse, error) {
+	ret := _m.ctrl.Call(_m, "CloseAccount", _param0, _param1)
+	ret0, _ := ret[0].(*apipb.CloseAccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockAPIServiceInterfaceRecorder) CloseAccount(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CloseAccount", arg0, arg1)
+}
+
 func (_m *MockAPIServiceInterface) GetBalance(_param0 context.Context, _param1 *apipb.GetBalanceRequest) (*apipb.GetBalanceResponse, error) {
 	ret := _m.ctrl.Call(_m, "GetBalance", _param0, _param1)
 	ret0, _ := ret[0].(*apipb.GetBalanceResponse)
@@ -42,6 +64,17 @@ func (_mr *_MockAPIServiceInterfaceRecorder) GetBalance(arg0, arg1 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBalance", arg0, arg1)
 }
 
+func (_m *MockAPIServiceInterface) GetBalanceAt(_param0 context.Context, _param1 *apipb.GetBalanceAtRequest) (*apipb.GetBalanceAtResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetBalanceAt", _param0, _param1)
+	ret0, _ := ret[0].(*apipb.GetBalanceAtResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockAPIServiceInterfaceRecorder) GetBalanceAt(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBalanceAt", arg0, arg1)
+}
+
 func (_m *MockAPIServiceInterface) GetByMetaKey(_param0 context.Context, _param1 *apipb.GetByMetaKeyRequest) (*apipb.GetByMetaKeyResponse, error) {
 	ret := _m.ctrl.Call(_m, "GetByMetaKey", _param0, _param1)
 	ret0, _ := ret[0].(*apipb.GetByMetaKeyResponse)
@@ -75,6 +108,17 @@ func (_mr *_MockAPIServiceInterfaceRecorder) GetLastSettings(arg0, arg1 interfac
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetLastSettings", arg0, arg1)
 }
 
+func (_m *MockAPIServiceInterface) GetPendingIncoming(_param0 context.Context, _param1 *apipb.GetPendingIncomingRequest) (*apipb.GetPendingIncomingResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetPendingIncoming", _param0, _param1)
+	ret0, _ := ret[0].(*apipb.GetPendingIncomingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockAPIServiceInterfaceRecorder) GetPendingIncoming(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetPendingIncoming", arg0, arg1)
+}
+
 func (_m *MockAPIServiceInterface) GetPrevHash(_param0 context.Context, _param1 *apipb.GetPrevHashRequest) (*apipb.GetPrevHashResponse, error) {
 	ret := _m.ctrl.Call(_m, "GetPrevHash", _param0, _param1)
 	ret0, _ := ret[0].(*apipb.GetPrevHashResponse)
@@ -86,6 +130,28 @@ func (_mr *_MockAPIServiceInterfaceRecorder) GetPrevHash(arg0, arg1 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetPrevHash", arg0, arg1)
 }
 
+func (_m *MockAPIServiceInterface) GetSettingsHistory(_param0 context.Context, _param1 *apipb.GetSettingsHistoryRequest) (*apipb.GetSettingsHistoryResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetSettingsHistory", _param0, _param1)
+	ret0, _ := ret[0].(*apipb.GetSettingsHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockAPIServiceInterfaceRecorder) GetSettingsHistory(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetSettingsHistory", arg0, arg1)
+}
+
+func (_m *MockAPIServiceInterface) GetStatement(_param0 context.Context, _param1 *apipb.GetStatementRequest) (*apipb.GetStatementResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetStatement", _param0, _param1)
+	ret0, _ := ret[0].(*apipb.GetStatementResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockAPIServiceInterfaceRecorder) GetStatement(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStatement", arg0, arg1)
+}
+
 func (_m *MockAPIServiceInterface) ProcessTransfer(_param0 context.Context, _param1 *apipb.TransferRequest) (*apipb.TransferResponse, error) {
 	ret := _m.ctrl.Call(_m, "ProcessTransfer", _param0, _param1)
 	ret0, _ := ret[0].(*apipb.TransferResponse)
@@ -108,6 +174,28 @@ func (_mr *_MockAPIServiceInterfaceRecorder) PutMeta(arg0, arg1 interface{}) *go
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "PutMeta", arg0, arg1)
 }
 
+func (_m *MockAPIServiceInterface) RegisterAlias(_param0 context.Context, _param1 *apipb.RegisterAliasRequest) (*apipb.RegisterAliasResponse, error) {
+	ret := _m.ctrl.Call(_m, "RegisterAlias", _param0, _param1)
+	ret0, _ := ret[0].(*apipb.RegisterAliasResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockAPIServiceInterfaceRecorder) RegisterAlias(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RegisterAlias", arg0, arg1)
+}
+
+func (_m *MockAPIServiceInterface) ResolveAlias(_param0 context.Context, _param1 *apipb.ResolveAliasRequest) (*apipb.ResolveAliasResponse, error) {
+	ret := _m.ctrl.Call(_m, "ResolveAlias", _param0, _param1)
+	ret0, _ := ret[0].(*apipb.ResolveAliasResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockAPIServiceInterfaceRecorder) ResolveAlias(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ResolveAlias", arg0, arg1)
+}
+
 func (_m *MockAPIServiceInterface) SearchMeta(_param0 context.Context, _param1 *apipb.SearchMetaRequest) (*apipb.SearchMetaResponse, error) {
 	ret := _m.ctrl.Call(_m, "SearchMeta", _param0, _param1)
 	ret0, _ := ret[0].(*apipb.SearchMetaResponse)
@@ -119,6 +207,17 @@ func (_mr *_MockAPIServiceInterfaceRecorder) SearchMeta(arg0, arg1 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SearchMeta", arg0, arg1)
 }
 
+func (_m *MockAPIServiceInterface) StreamChanges(_param0 context.Context, _param1 *apipb.StreamChangesRequest) (*apipb.StreamChangesResponse, error) {
+	ret := _m.ctrl.Call(_m, "StreamChanges", _param0, _param1)
+	ret0, _ := ret[0].(*apipb.StreamChangesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockAPIServiceInterfaceRecorder) StreamChanges(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StreamChanges", arg0, arg1)
+}
+
 func (_m *MockAPIServiceInterface) UpdateSettings(_param0 context.Context, _param1 *apipb.SettingsRequest) (*apipb.SettingsResponse, error) {
 	ret := _m.ctrl.Call(_m, "UpdateSettings", _param0, _param1)
 	ret0, _ := ret[0].(*apipb.SettingsResponse)
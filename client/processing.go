@@ -4,6 +4,7 @@ import (
 	"context"
 	"math/rand"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	cli "gopkg.in/urfave/cli.v2"
@@ -49,12 +50,6 @@ func Transfer(cx *cli.Context) error {
 		return err
 	}
 
-	h, err := getPrevHash(req.Sender)
-	if err != nil {
-		return errors.Wrap(err, "prev hash")
-	}
-	req.PrevHash = h
-
 	sett, err := api.GetLastSettings(context.TODO(), &apipb.GetLastSettingsRequest{Account: u})
 	if err != nil {
 		return err
@@ -66,6 +61,15 @@ func Transfer(cx *cli.Context) error {
 
 	req.SettingsId = sett.Id
 
+	// transfers are chained by server, prev hash isn't needed
+	if !sett.ServerSequencing {
+		h, err := getPrevHash(req.Sender)
+		if err != nil {
+			return errors.Wrap(err, "prev hash")
+		}
+		req.PrevHash = h
+	}
+
 	for i := 0; i < RepeatFlag; i++ {
 		err = SignTransfer(req)
 		if err != nil {
@@ -84,7 +88,9 @@ func Transfer(cx *cli.Context) error {
 
 		printResponse(cx, resp)
 
-		req.PrevHash = resp.Hash
+		if !sett.ServerSequencing {
+			req.PrevHash = resp.Hash
+		}
 	}
 
 	return nil
@@ -127,6 +133,10 @@ func Balance(cx *cli.Context) error {
 		return err
 	}
 
+	if cx.String("at") != "" || cx.Int64("txn") != 0 {
+		return balanceAt(cx, u)
+	}
+
 	if err := connect(); err != nil {
 		return err
 	}
@@ -146,6 +156,60 @@ func Balance(cx *cli.Context) error {
 	return nil
 }
 
+// balanceAt prints account balance at the past txn or time
+func balanceAt(cx *cli.Context, u uint64) error {
+	at, err := parseTimeArg(cx.String("at"), time.Now())
+	if err != nil {
+		return errors.Wrap(err, "at")
+	}
+
+	if err := connect(); err != nil {
+		return err
+	}
+
+	resp, err := api.GetBalanceAt(context.TODO(), &apipb.GetBalanceAtRequest{Account: u, TxnId: uint64(cx.Int64("txn")), Time: at})
+	if err != nil {
+		return err
+	}
+
+	err = inspectStatus(resp.Status)
+	if err != nil {
+		return err
+	}
+
+	printResponse(cx, resp)
+
+	return nil
+}
+
+func PendingIncoming(cx *cli.Context) error {
+	args := cx.Args()
+
+	u, err := accountFromArgs(args)
+	if err != nil {
+		cli.ShowSubcommandHelp(cx)
+		return err
+	}
+
+	if err := connect(); err != nil {
+		return err
+	}
+
+	resp, err := api.GetPendingIncoming(context.TODO(), &apipb.GetPendingIncomingRequest{Account: u, FromDb: cx.Bool("db")})
+	if err != nil {
+		return err
+	}
+
+	err = inspectStatus(resp.Status)
+	if err != nil {
+		return err
+	}
+
+	printResponse(cx, resp)
+
+	return nil
+}
+
 func getPrevHash(u uint64) (string, error) {
 	req := &apipb.GetPrevHashRequest{Account: u}
 
@@ -165,13 +229,8 @@ func parseTransferItems(req *apipb.TransferRequest, a []string) error {
 	if len(a) < 2 || len(a)%2 != 0 {
 		return ErrArguments
 	}
-	base := 10
-	if HexFlag {
-		base = 16
-	}
-
 	for i := 0; i < len(a); i += 2 {
-		u, err := strconv.ParseUint(a[i], base, 64)
+		u, err := parseAccount(a[i])
 		if err != nil {
 			return err
 		}
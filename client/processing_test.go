@@ -40,6 +40,37 @@ func TestTransfer(t *testing.T) {
 	assert.Equal(t, "", stdErr())
 }
 
+func TestTransferServerSequencing(t *testing.T) {
+	KeysDBFlag = ""
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := initMockAPI(ctrl)
+
+	// arguments
+	c, stdOut, stdErr := mockCli([]string{"11111", "22222", "10"})
+
+	// api. No GetPrevHash call
+	api.EXPECT().GetLastSettings(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(&apipb.GetLastSettingsResponse{Status: &apipb.Status{}, Id: 3, ServerSequencing: true}, nil)
+	api.EXPECT().ProcessTransfer(gomock.Any(), &apipb.TransferRequest{
+		Sender:     11111,
+		Batch:      []*apipb.TransferItem{{Receiver: 22222, Amount: 10}},
+		SettingsId: 3,
+	}).
+		Times(1).
+		Return(&apipb.TransferResponse{Status: &apipb.Status{}, TxnId: "11111_5", Hash: "txn_hash"}, nil)
+
+	// call
+	err := Transfer(c)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "{\n  \"status\": {},\n  \"txn_id\": \"11111_5\",\n  \"hash\": \"txn_hash\"\n}\n", stdOut())
+	assert.Equal(t, "", stdErr())
+}
+
 func TestParseTransferItems(t *testing.T) {
 	testData := map[*apipb.TransferRequest][]string{
 		{
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/btcsuite/btcd/btcec"
 	cli "gopkg.in/urfave/cli.v2"
@@ -26,7 +27,7 @@ func GetLastSettings(cx *cli.Context) error {
 		return err
 	}
 
-	resp, err := api.GetLastSettings(context.TODO(), &apipb.GetLastSettingsRequest{Account: u})
+	resp, err := api.GetLastSettings(context.TODO(), &apipb.GetLastSettingsRequest{Account: u, WithData: cx.Bool("data")})
 	if err != nil {
 		return err
 	}
@@ -41,6 +42,54 @@ func GetLastSettings(cx *cli.Context) error {
 	return nil
 }
 
+func GetSettingsHistory(cx *cli.Context) error {
+	args := cx.Args()
+
+	u, err := accountFromArgs(args)
+	if err != nil {
+		cli.ShowSubcommandHelp(cx)
+		return err
+	}
+
+	if err := connect(); err != nil {
+		return err
+	}
+
+	req := &apipb.GetSettingsHistoryRequest{Account: u, Limit: uint32(cx.Int("limit")), Token: cx.String("token")}
+
+	resp, err := api.GetSettingsHistory(context.TODO(), req)
+	if err != nil {
+		return err
+	}
+
+	err = inspectStatus(resp.Status)
+	if err != nil {
+		return err
+	}
+
+	// follow next page tokens
+	for cx.Bool("all") && resp.Token != "" {
+		req.Token = resp.Token
+
+		page, err := api.GetSettingsHistory(context.TODO(), req)
+		if err != nil {
+			return err
+		}
+
+		err = inspectStatus(page.Status)
+		if err != nil {
+			return err
+		}
+
+		resp.Settings = append(resp.Settings, page.Settings...)
+		resp.Token = page.Token
+	}
+
+	printResponse(cx, resp)
+
+	return nil
+}
+
 func Keygen(cx *cli.Context) error {
 	args := cx.Args()
 
@@ -72,7 +121,9 @@ func Keygen(cx *cli.Context) error {
 		PrevHash:           s.Hash,
 		DataHash:           s.DataHash,
 		VerifyTransferSign: s.VerifyTransferSign,
+		ServerSequencing:   s.ServerSequencing,
 		PublicKey:          pubb, // changed field
+		Registered:         s.Registered,
 	}
 
 	resp, err := updateSettings(cx, sreq)
@@ -106,15 +157,10 @@ func UpdateVerifyTransferSign(cx *cli.Context) error {
 		return err
 	}
 
-	var val bool
-	switch args.Get(1) {
-	case "true", "t", "1", "y", "yes":
-		val = true
-	case "false", "f", "0", "n", "no":
-		// already false
-	default:
+	val, err := parseBool(args.Get(1))
+	if err != nil {
 		cli.ShowSubcommandHelp(cx)
-		return fmt.Errorf("unsupported value: %v", args.Get(1))
+		return err
 	}
 
 	if err := connect(); err != nil {
@@ -136,7 +182,64 @@ func UpdateVerifyTransferSign(cx *cli.Context) error {
 		PrevHash:           s.Hash,
 		DataHash:           s.DataHash,
 		VerifyTransferSign: val, // changed field
+		ServerSequencing:   s.ServerSequencing,
 		PublicKey:          s.PublicKey,
+		Registered:         s.Registered,
+	}
+
+	resp, err := updateSettings(cx, sreq)
+	err = inspectStatus(resp.Status)
+	if err != nil {
+		return err
+	}
+
+	printResponse(cx, resp)
+
+	return nil
+}
+
+func UpdateServerSequencing(cx *cli.Context) error {
+	args := cx.Args()
+
+	if args.Len() > 2 {
+		cli.ShowSubcommandHelp(cx)
+		return errors.New("expected max two arguments")
+	}
+
+	u, err := accountFromArgs(args)
+	if err != nil {
+		cli.ShowSubcommandHelp(cx)
+		return err
+	}
+
+	val, err := parseBool(args.Get(1))
+	if err != nil {
+		cli.ShowSubcommandHelp(cx)
+		return err
+	}
+
+	if err := connect(); err != nil {
+		return err
+	}
+
+	s, err := api.GetLastSettings(context.TODO(), &apipb.GetLastSettingsRequest{Account: u})
+	if err != nil {
+		return err
+	}
+
+	if s.ServerSequencing == val {
+		// already set to equal value
+		return nil
+	}
+
+	sreq := &apipb.SettingsRequest{
+		Account:            u,
+		PrevHash:           s.Hash,
+		DataHash:           s.DataHash,
+		VerifyTransferSign: s.VerifyTransferSign,
+		ServerSequencing:   val, // changed field
+		PublicKey:          s.PublicKey,
+		Registered:         s.Registered,
 	}
 
 	resp, err := updateSettings(cx, sreq)
@@ -164,6 +267,33 @@ func UpdateDataHash(cx *cli.Context) error {
 		return err
 	}
 
+	return updateDataHash(cx, u, args.Get(1), nil)
+}
+
+// UpdateData stores file content as settings user data and sets DataHash to its hash
+func UpdateData(cx *cli.Context) error {
+	args := cx.Args()
+
+	if args.Len() != 2 {
+		cli.ShowSubcommandHelp(cx)
+		return errors.New("expected exactly two arguments")
+	}
+
+	u, err := accountFromArgs(args)
+	if err != nil {
+		cli.ShowSubcommandHelp(cx)
+		return err
+	}
+
+	data, err := ioutil.ReadFile(args.Get(1))
+	if err != nil {
+		return err
+	}
+
+	return updateDataHash(cx, u, pt.GetDataHash(data).String(), data)
+}
+
+func updateDataHash(cx *cli.Context, u uint64, hash string, data []byte) error {
 	if err := connect(); err != nil {
 		return err
 	}
@@ -173,7 +303,7 @@ func UpdateDataHash(cx *cli.Context) error {
 		return err
 	}
 
-	if s.DataHash == args.Get(1) {
+	if s.DataHash == hash && data == nil {
 		// already set to equal value
 		return nil
 	}
@@ -181,12 +311,18 @@ func UpdateDataHash(cx *cli.Context) error {
 	sreq := &apipb.SettingsRequest{
 		Account:            u,
 		PrevHash:           s.Hash,
-		DataHash:           args.Get(1), // changed field
+		DataHash:           hash, // changed field
+		Data:               data,
 		VerifyTransferSign: s.VerifyTransferSign,
+		ServerSequencing:   s.ServerSequencing,
 		PublicKey:          s.PublicKey,
+		Registered:         s.Registered,
 	}
 
 	resp, err := updateSettings(cx, sreq)
+	if err != nil {
+		return err
+	}
 	err = inspectStatus(resp.Status)
 	if err != nil {
 		return err
@@ -223,3 +359,14 @@ func updateSettings(cx *cli.Context, sreq *apipb.SettingsRequest) (*apipb.Settin
 
 	return resp, nil
 }
+
+func parseBool(s string) (bool, error) {
+	switch s {
+	case "true", "t", "1", "y", "yes":
+		return true, nil
+	case "false", "f", "0", "n", "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported value: %v", s)
+	}
+}
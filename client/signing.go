@@ -156,6 +156,10 @@ func TransferRequestHash(t *apipb.TransferRequest) pt.Hash {
 	if err != nil {
 		panic("prev hash format")
 	}
+	if len(ph) == 0 {
+		// empty prev hash is zero hash for processor
+		ph = pt.ZeroHash[:]
+	}
 	h.Write(ph)
 
 	order.PutUint64(buf, uint64(t.SettingsId))
@@ -166,6 +170,16 @@ func TransferRequestHash(t *apipb.TransferRequest) pt.Hash {
 }
 
 func SettingsRequestHash(s *apipb.SettingsRequest) pt.Hash {
+	return settingsRequestHash(s, false, 0)
+}
+
+// CloseRequestHash is a hash of settings request closing account.
+// s is a request built from last account settings.
+func CloseRequestHash(s *apipb.SettingsRequest, sweepTo uint64) pt.Hash {
+	return settingsRequestHash(s, true, sweepTo)
+}
+
+func settingsRequestHash(s *apipb.SettingsRequest, closed bool, sweepTo uint64) pt.Hash {
 	h := sha256.New()
 
 	order := binary.BigEndian
@@ -176,10 +190,18 @@ func SettingsRequestHash(s *apipb.SettingsRequest) pt.Hash {
 	order.PutUint64(buf, uint64(s.Account))
 	h.Write(buf[:8])
 
+	buf[0] = 0
 	if s.VerifyTransferSign {
-		buf[0] = 1
-	} else {
-		buf[0] = 0
+		buf[0] |= 1
+	}
+	if s.ServerSequencing {
+		buf[0] |= 2
+	}
+	if s.Registered {
+		buf[0] |= 4
+	}
+	if closed {
+		buf[0] |= 8
 	}
 	h.Write(buf[:1])
 
@@ -201,6 +223,11 @@ func SettingsRequestHash(s *apipb.SettingsRequest) pt.Hash {
 	}
 	h.Write(hash)
 
+	if closed {
+		order.PutUint64(buf, sweepTo)
+		h.Write(buf[:8])
+	}
+
 	_ = h.Sum(buf[:0])
 	return hbuf
 }
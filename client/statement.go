@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v2"
+
+	"github.com/qiwitech/qdp/proto/apipb"
+)
+
+func GetStatement(cx *cli.Context) error {
+	args := cx.Args()
+	u, err := accountFromArgs(args)
+	if err != nil {
+		cli.ShowSubcommandHelp(cx)
+		return err
+	}
+
+	req := &apipb.GetStatementRequest{Account: u, TotalsOnly: cx.Bool("totals")}
+
+	now := time.Now()
+	if req.FromTime, err = parseTimeArg(cx.String("from"), now); err != nil {
+		return errors.Wrap(err, "from")
+	}
+	if req.ToTime, err = parseTimeArg(cx.String("to"), now); err != nil {
+		return errors.Wrap(err, "to")
+	}
+
+	if err := connect(); err != nil {
+		return err
+	}
+
+	resp, err := api.GetStatement(context.TODO(), req)
+	if err != nil {
+		return err
+	}
+
+	err = inspectStatus(resp.Status)
+	if err != nil {
+		return err
+	}
+
+	if cx.Bool("csv") {
+		return writeStatementCSV(cx.App.Writer, req, resp)
+	}
+
+	printResponse(cx, resp)
+
+	return nil
+}
+
+// writeStatementCSV writes statement as a table with running balance.
+// The first row is opening balance, the last one is closing balance with period totals.
+func writeStatementCSV(w io.Writer, req *apipb.GetStatementRequest, resp *apipb.GetStatementResponse) error {
+	acc := strconv.FormatUint(req.Account, 10)
+	fmtInt := func(v int64) string { return strconv.FormatInt(v, 10) }
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"processed_at", "sender", "id", "receiver", "debit", "credit", "balance"})
+	cw.Write([]string{req.FromTime, "opening balance", "", "", "", "", fmtInt(resp.OpeningBalance)})
+
+	balance := resp.OpeningBalance
+	for _, t := range resp.Txns {
+		amount, err := strconv.ParseInt(t.Amount, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "txn %s:%s amount", t.Sender, t.Id)
+		}
+
+		var debit, credit string
+		switch acc {
+		case t.Sender:
+			debit = t.Amount
+			balance -= amount
+		case t.Receiver:
+			credit = t.Amount
+			balance += amount
+		}
+
+		cw.Write([]string{t.ProcessedAt, t.Sender, t.Id, t.Receiver, debit, credit, fmtInt(balance)})
+	}
+
+	cw.Write([]string{req.ToTime, "closing balance", "", "", fmtInt(resp.Debits), fmtInt(resp.Credits), fmtInt(resp.ClosingBalance)})
+	cw.Flush()
+
+	return cw.Error()
+}
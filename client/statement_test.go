@@ -0,0 +1,59 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/proto/apipb"
+)
+
+func TestGetStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := initMockAPI(ctrl)
+
+	c, stdOut, stdErr := mockCli([]string{"11111"})
+
+	api.EXPECT().GetStatement(gomock.Any(), &apipb.GetStatementRequest{Account: 11111}).
+		Times(1).
+		Return(&apipb.GetStatementResponse{Status: &apipb.Status{}, ClosingBalance: 10, Credits: 10, TxnCount: 1}, nil)
+
+	err := GetStatement(c)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "{\n  \"status\": {},\n  \"closing_balance\": 10,\n  \"credits\": 10,\n  \"txn_count\": 1\n}\n", stdOut())
+	assert.Equal(t, "", stdErr())
+}
+
+func TestWriteStatementCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := writeStatementCSV(&buf,
+		&apipb.GetStatementRequest{Account: 1, FromTime: "2017-07-01T00:00:00Z", ToTime: "2017-08-01T00:00:00Z"},
+		&apipb.GetStatementResponse{
+			OpeningBalance: 100,
+			ClosingBalance: 130,
+			Debits:         20,
+			Credits:        50,
+			TxnCount:       2,
+			Txns: []*apipb.Txn{
+				{ProcessedAt: "2017-07-02T10:00:00Z", Sender: "2", Id: "5", Receiver: "1", Amount: "50"},
+				{ProcessedAt: "2017-07-03T10:00:00Z", Sender: "1", Id: "8", Receiver: "3", Amount: "20"},
+			},
+		})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `processed_at,sender,id,receiver,debit,credit,balance
+2017-07-01T00:00:00Z,opening balance,,,,,100
+2017-07-02T10:00:00Z,2,5,1,,50,150
+2017-07-03T10:00:00Z,1,8,3,20,,130
+2017-08-01T00:00:00Z,closing balance,,,20,50,130
+`, buf.String())
+
+	err = writeStatementCSV(&buf, &apipb.GetStatementRequest{Account: 1}, &apipb.GetStatementResponse{Txns: []*apipb.Txn{{Sender: "1", Id: "1", Amount: "x"}}})
+	assert.Error(t, err)
+}
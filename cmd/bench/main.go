@@ -102,6 +102,7 @@ var (
 	kgen      = flag.Bool("keygen", false, "generate private key before transfer if not exists")
 	hexrep    = flag.Bool("hex", false, "use account hex representation")
 	mixedf    = flag.String("mixed", "1:transfer,10:history", "mixed generator")
+	hpages    = flag.Int("history-pages", 1, "history pages to load per request following next page tokens")
 )
 
 func init() {
@@ -591,21 +592,27 @@ type History struct {
 }
 
 func (w *History) Request(ctx context.Context, u uint64) (interface{}, error) {
-	resp, err := w.cl.GetHistory(ctx, &apipb.GetHistoryRequest{
+	req := &apipb.GetHistoryRequest{
 		Account: u,
 		Limit:   20,
-	})
-	if err != nil {
-		return nil, err
-	}
-	if resp.Status == nil {
-		return nil, errors.New("no status")
-	}
-	if resp.Status != nil && resp.Status.Code != 0 {
-		return nil, errors.New(resp.Status.Message)
 	}
+	for page := 0; ; page++ {
+		resp, err := w.cl.GetHistory(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Status == nil {
+			return nil, errors.New("no status")
+		}
+		if resp.Status != nil && resp.Status.Code != 0 {
+			return nil, errors.New(resp.Status.Message)
+		}
 
-	return resp.Status, nil
+		if resp.Token == "" || page+1 >= *hpages {
+			return resp.Status, nil
+		}
+		req.Token = resp.Token
+	}
 }
 
 func balance(addr string) (Worker, error) {
@@ -662,6 +669,8 @@ func (w *Keygen) Request(ctx context.Context, u uint64) (interface{}, error) {
 		PrevHash:           s.Hash,
 		DataHash:           s.DataHash,
 		VerifyTransferSign: s.VerifyTransferSign,
+		ServerSequencing:   s.ServerSequencing,
+		Registered:         s.Registered,
 		PublicKey:          pubb, // changed field
 	}
 
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/eapache/go-resiliency/breaker"
@@ -29,9 +31,15 @@ var (
 	// TODO(outself): validate!
 	gate   = flag.String("gate", ":31337", "gate url")
 	pdb    = flag.String("plutodb", ":38388", "plutodb url")
+	pdbrep = flag.String("plutodb-replicas", "", "comma separated plutodb read replica urls (sqldb -replica)")
+	maxLag = flag.Duration("replica-max-lag", 5*time.Second, "max plutodb replica lag to read from it")
 	mdb    = flag.String("metadb", "", "metadb url")
 	listen = flag.String("listen", ":9090", "http addr")
 	simple = flag.Bool("simple-router", false, "use simple router instead of static")
+
+	bulkParallelism = flag.Int("bulk-parallelism", 16, "number of bulk transfers processed concurrently at each node")
+	maxSettingsData = flag.Int("max-settings-data", 64<<10, "max size of settings user data stored in plutodb")
+	maxChangesWait  = flag.Duration("max-changes-wait", 30*time.Second, "max time StreamChanges waits for new changes")
 )
 var (
 	Version = "dev"
@@ -133,17 +141,32 @@ func main() {
 		r = router.NewStatic("")
 	}
 
-	// make routing table from address
+	// make routing table from address, the real one with its epoch is taken from nodes replies
 	nodes := []string{"0=" + *gate}
-	r.SetNodes(nodes)
+	r.SetEpochNodes(0, nodes)
 
 	a := api.NewService(api.NewRouter(r, clientFn))
+	a.BulkParallelism = *bulkParallelism
+	a.MaxSettingsDataSize = *maxSettingsData
+	a.MaxChangesWait = *maxChangesWait
 
 	if *pdb != "" {
 		g := tcprpc.NewClient(*pdb)
 		plutodb := plutodbpb.NewTCPRPCPlutoDBServiceClient(g, "v1/")
 
-		a.SetPlutoDBClient(plutodb)
+		if *pdbrep == "" {
+			a.SetPlutoDBClient(plutodb)
+		} else {
+			r := api.NewReplicas(plutodb)
+			r.MaxLag = *maxLag
+			for _, addr := range strings.Split(*pdbrep, ",") {
+				g := tcprpc.NewClient(addr)
+				r.AddReplica(addr, plutodbpb.NewTCPRPCPlutoDBServiceClient(g, "v1/"))
+			}
+			go r.Run(context.Background(), time.Second)
+
+			a.SetPlutoDBReplicas(r)
+		}
 	}
 
 	if *mdb != "" {
@@ -43,8 +43,21 @@ func main() {
 			// TODO(nik): name it lasthash?
 			Name:        "balance",
 			Usage:       "<account>",
-			Description: "loads account balance",
+			Description: "loads account balance. Past balance is loaded from the database, incoming transactions not spent at that point are returned separately",
 			Action:      client.Balance,
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "at", Usage: "balance before that time: RFC3339 time, date (2006-01-02) or duration ago (24h)"},
+				&cli.Int64Flag{Name: "txn", Usage: "balance right after that account outgoing transaction id"},
+			},
+		},
+		{
+			Name:        "pending",
+			Usage:       "<account>",
+			Description: "lists account incoming transactions which are not spent yet and their total",
+			Action:      client.PendingIncoming,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{Name: "db", Usage: "read from the database instead of the processing node"},
+			},
 		},
 		{
 			Name:  "settings",
@@ -54,6 +67,20 @@ func main() {
 					Name:   "last",
 					Usage:  "<account> - show current account settings",
 					Action: client.GetLastSettings,
+					Flags: []cli.Flag{
+						&cli.BoolFlag{Name: "data", Usage: "load user data stored with settings"},
+					},
+				},
+				{
+					Name:        "history",
+					Usage:       "<account> - show account settings history, latest first",
+					Description: "loads account settings history from the database: key rotations, flags, data hashes and signs",
+					Action:      client.GetSettingsHistory,
+					Flags: []cli.Flag{
+						&cli.IntFlag{Name: "limit", Aliases: []string{"l"}, Value: 10},
+						&cli.StringFlag{Name: "token", Aliases: []string{"t"}, Usage: "next page token from the previous response"},
+						&cli.BoolFlag{Name: "all", Usage: "load all pages"},
+					},
 				},
 				{
 					Name:        "keygen",
@@ -67,23 +94,90 @@ func main() {
 					Description: "change VerifyTransferSign field on settings",
 					Action:      client.UpdateVerifyTransferSign,
 				},
+				{
+					Name:        "sequencing",
+					Usage:       "<account> <true|yes|t|y|1 or false|no|f|n|0> - update settings ServerSequencing field",
+					Description: "change ServerSequencing field on settings. Transfers are chained by server in order they come and prev hash is ignored if it's set",
+					Action:      client.UpdateServerSequencing,
+				},
 				{
 					Name:        "datahash",
 					Usage:       "<account> <hash> - update settings DataHash field",
 					Description: "change DataHash field on settings",
 					Action:      client.UpdateDataHash,
 				},
+				{
+					Name:        "data",
+					Usage:       "<account> <file> - store file as settings user data",
+					Description: "store file content in the database and set DataHash field to its hash",
+					Action:      client.UpdateData,
+				},
 			},
 			Action: client.GetLastSettings,
 		},
+		{
+			Name:  "account",
+			Usage: "Perform account lifecycle operations",
+			Subcommands: []*cli.Command{
+				{
+					Name:        "register",
+					Usage:       "<account> - register account",
+					Description: "set Registered field on settings. Nodes could be configured to accept transfers to registered accounts only",
+					Action:      client.RegisterAccount,
+				},
+				{
+					Name:        "close",
+					Usage:       "<account> <sweep_to> - close account",
+					Description: "sweep remaining balance to sweep_to account and forbid any further activity. Could be retried with the same arguments",
+					Action:      client.CloseAccount,
+				},
+			},
+		},
+		{
+			Name:  "alias",
+			Usage: "Perform account aliases operations",
+			Subcommands: []*cli.Command{
+				{
+					Name:        "register",
+					Usage:       "<account> <alias> - bind alias to account",
+					Description: "bind human readable alias (phone, email, nickname) to account. Request is signed by account private key. Aliases could be used wherever account is expected, alias starting with @ is never parsed as a number",
+					Action:      client.RegisterAlias,
+				},
+				{
+					Name:   "resolve",
+					Usage:  "<alias> - show account alias is bound to",
+					Action: client.ResolveAlias,
+				},
+			},
+		},
 		{
 			Name:        "history",
 			Usage:       "<account>",
-			Description: "loads history for account",
+			Description: "loads history for account. Filters narrow it down to matching transactions, latest first",
 			Action:      client.GetHistory,
 			Flags: []cli.Flag{
 				&cli.IntFlag{Name: "limit", Aliases: []string{"l"}, Value: 10},
-				&cli.StringFlag{Name: "token", Aliases: []string{"t"}},
+				&cli.StringFlag{Name: "token", Aliases: []string{"t"}, Usage: "next page token from the previous response"},
+				&cli.BoolFlag{Name: "all", Usage: "load all pages"},
+				&cli.StringFlag{Name: "from", Usage: "processed at or after: RFC3339 time, date (2006-01-02) or duration ago (168h)"},
+				&cli.StringFlag{Name: "to", Usage: "processed before: RFC3339 time, date (2006-01-02) or duration ago (24h)"},
+				&cli.BoolFlag{Name: "in", Usage: "incoming transactions only"},
+				&cli.BoolFlag{Name: "out", Usage: "outgoing transactions only"},
+				&cli.StringFlag{Name: "with", Usage: "counterparty account or alias"},
+				&cli.Int64Flag{Name: "min", Usage: "min amount"},
+				&cli.Int64Flag{Name: "max", Usage: "max amount"},
+			},
+		},
+		{
+			Name:        "statement",
+			Usage:       "<account>",
+			Description: "loads account statement for the period: opening and closing balances, totals and transactions",
+			Action:      client.GetStatement,
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "from", Usage: "period start: RFC3339 time, date (2006-01-02) or duration ago (720h)"},
+				&cli.StringFlag{Name: "to", Usage: "period end, exclusive: RFC3339 time, date (2006-01-02) or duration ago"},
+				&cli.BoolFlag{Name: "totals", Usage: "balances and totals only"},
+				&cli.BoolFlag{Name: "csv", Usage: "print as CSV table with running balance"},
 			},
 		},
 		{
@@ -92,6 +186,24 @@ func main() {
 			Description: "retrieves transaction by meta key",
 			Action:      client.GetByMetaKey,
 		},
+		{
+			Name:        "handoff",
+			Usage:       "<shard> <host>",
+			Description: "moves accounts range starting at shard point to another plutos node",
+			Action:      client.Handoff,
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "node", Aliases: []string{"n"}, Usage: "any plutos node of the cluster", EnvVars: []string{"PLUTOS"}},
+			},
+		},
+		{
+			Name:        "promote",
+			Usage:       "<failed host> <standby host>",
+			Description: "promotes hot-standby replica and gives it all accounts ranges of failed plutos node",
+			Action:      client.Promote,
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "node", Aliases: []string{"n"}, Usage: "any alive plutos node of the cluster", EnvVars: []string{"PLUTOS"}},
+			},
+		},
 	}
 	app.Flags = []cli.Flag{
 		&cli.StringFlag{Name: "keysdb", Aliases: []string{"d"}, Value: ".plutoclientdb", Destination: &client.KeysDBFlag},
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
@@ -11,14 +12,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/boltdb/bolt"
 	"github.com/facebookgo/flagenv"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/qiwitech/tcprpc"
 	"golang.org/x/net/trace"
 
 	"github.com/qiwitech/qdp/bigchain"
+	"github.com/qiwitech/qdp/boltchain"
 	"github.com/qiwitech/qdp/chain"
 	"github.com/qiwitech/qdp/gate"
+	"github.com/qiwitech/qdp/handoff"
 	"github.com/qiwitech/qdp/preloader"
 	"github.com/qiwitech/qdp/processor"
 	"github.com/qiwitech/qdp/proto/gatepb"
@@ -28,20 +33,37 @@ import (
 	"github.com/qiwitech/qdp/pusher"
 	"github.com/qiwitech/qdp/pusher/remotepusher"
 	"github.com/qiwitech/qdp/pusher/seqpusher"
+	"github.com/qiwitech/qdp/registry"
+	"github.com/qiwitech/qdp/replica"
 	"github.com/qiwitech/qdp/router"
 )
 
 var (
 	nodes    = flag.String("nodes", ":31337", "cluster nodes")
+	epoch    = flag.Uint64("epoch", 0, "initial routing table epoch")
 	selfAddr = flag.String("self", "", "self address for router")
 	listen   = flag.String("listen", ":31337", "http addr")
 
-	discoverSvc = flag.String("discover", "", "discover swarm service:port to update router")
+	discoverSvc      = flag.String("discover", "", "discover nodes to update router from: swarm service:port, srv://{name} or file://{path}")
+	discoverInterval = flag.Duration("discover-interval", 10*time.Second, "interval to check discovery source")
+	discoverStable   = flag.Int("discover-stable", 3, "apply discovered nodes only after they were seen the same this number of times in a row")
+	routerState      = flag.String("router-state", "", "file to persist router configuration to")
+	peerCheck        = flag.Bool("peer-check", false, "refuse to start if router configuration differs from the most of peers")
 
-	dbAddr = flag.String("db", "", "DB addr")
+	dbAddr   = flag.String("db", "", "DB addr")
+	boltFile = flag.String("bolt", "", "embedded boltdb file to use as DB instead of -db (single node installs)")
 
 	pushTo = flag.String("push", "", "comma separated addresses to push to")
 
+	replicas = flag.String("replicas", "", "comma separated addresses of hot-standby replicas to push committed data to")
+	standby  = flag.Bool("standby", false, "run as hot-standby replica (requires -db)")
+
+	forward        = flag.Bool("forward", false, "forward misrouted requests to owner node instead of SEE_OTHER response")
+	forwardMaxHops = flag.Uint("forward-max-hops", 2, "maximum number of times request could be forwarded")
+
+	requireRegistered = flag.Bool("require-registered", false, "reject transfers to unregistered or closed accounts (requires -db)")
+	registryTTL       = flag.Duration("registry-ttl", time.Minute, "time to cache account open state for")
+
 	threads    = flag.Int("threads", 997, "threads for processor")
 	routerType = flag.String("router", "static", "type of router (simple|static)")
 )
@@ -127,17 +149,58 @@ func main() {
 		panic("undefined router")
 	}
 	if *nodes != "" {
-		r.SetNodes(strings.Split(*nodes, ","))
+		r.SetEpochNodes(*epoch, strings.Split(*nodes, ","))
+	}
+
+	if *routerState != "" {
+		sr, ok := r.(router.StateRouter)
+		if !ok {
+			panic(fmt.Sprintf("%T router state can't be persisted", r))
+		}
+
+		st, err := router.LoadState(*routerState)
+		if err != nil {
+			panic(err)
+		}
+		if st != nil {
+			if router.RestoreState(sr, st) {
+				log.Printf("router state restored from %v: self %q, nodes %q, epoch %d", *routerState, st.Self, st.Nodes, st.Epoch)
+			} else {
+				log.Printf("router state from %v is ignored: nodes %q at epoch %d conflict with flags", *routerState, st.Nodes, st.Epoch)
+			}
+		}
+
+		if err := router.SaveState(*routerState, router.State(sr)); err != nil {
+			panic(err)
+		}
+
+		r = router.NewPersistent(sr, *routerState)
 	}
 
 	if ur, ok := r.(router.UpdatableRouter); ok {
+		if *peerCheck {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err := router.ComparePeers(ctx, router.State(ur))
+			cancel()
+			if err != nil {
+				log.Fatalf("router: %v", err)
+			}
+		}
+
 		log.Printf("%T is updatable router, set /cfg/router handler", r)
 		http.Handle("/cfg/router", router.Handler(ur))
 		if *discoverSvc != "" {
+			src, err := router.NewSource(*discoverSvc)
+			if err != nil {
+				panic(err)
+			}
+
+			d := router.NewDiscovery(ur, src)
+			d.Interval = *discoverInterval
+			d.Stable = *discoverStable
+
 			time.AfterFunc(5*time.Second, func() {
-				if err := router.UpdateRouter(ur, *discoverSvc); err != nil {
-					log.Printf("router update: %v", err)
-				}
+				d.Run(context.Background())
 			})
 		}
 	} else {
@@ -157,21 +220,59 @@ func main() {
 	sp := processor.NewSettingsProcessor(sc)
 
 	var (
-		pushers  []pt.Pusher
-		spushers []pt.SettingsPusher
+		pushers   []pt.Pusher
+		spushers  []pt.SettingsPusher
+		receivers = []pt.Pusher{pusher.NewChainReceiversPusher(c)}
+		guard     *handoff.Guard
+		prel      *preloader.Preloader
 	)
 
-	if *dbAddr != "" {
-		dburl := *dbAddr
-		db := remotepusher.NewDBPusher(dburl)
+	var (
+		db interface {
+			pt.Pusher
+			pt.SettingsPusher
+		}
+		bc pt.BigChain
+	)
+	switch {
+	case *dbAddr != "" && *boltFile != "":
+		panic("-db and -bolt are mutually exclusive")
+	case *dbAddr != "":
+		db = remotepusher.NewDBPusher(*dbAddr)
+		bc = newBigchain(*dbAddr)
+	case *boltFile != "":
+		bdb, err := newBoltchain(*boltFile)
+		if err != nil {
+			panic(err)
+		}
+		db = bdb
+		bc = bigchain.New(bdb)
+	}
 
-		prel := preloader.New(c, sc, newBigchain(dburl))
+	if db != nil {
+		prel = preloader.New(c, sc, bc)
 
 		p.SetPreloader(prel)
 		sp.SetPreloader(prel)
 
+		// accounts could be moved to another node only if we have reliable storage to reload them from
+		guard = handoff.NewGuard()
+		http.Handle("/cfg/handoff/", handoff.Handler(handoff.NewNode(guard, prel)))
+
 		pushers = append(pushers, db)
 		spushers = append(spushers, db)
+
+		if *requireRegistered {
+			reg := registry.New(bc)
+			reg.TTL = *registryTTL
+			reg.SetRouter(r)
+			http.Handle("/cfg/registry/", registry.Handler(reg))
+
+			p.SetRegistry(reg)
+			sp.SetRegistry(reg)
+		}
+	} else if *requireRegistered {
+		panic("-require-registered requires -db or -bolt to check accounts state")
 	}
 
 	if *pushTo != "" {
@@ -187,6 +288,23 @@ func main() {
 		}
 	}
 
+	if *replicas != "" {
+		for _, addr := range strings.Split(*replicas, ",") {
+			if addr == "" {
+				continue
+			}
+
+			// own committed data
+			cl := remotepusher.NewPrefixDBPusher(addr, replica.Prefix)
+			rp := replica.NewPusher(addr, cl, cl)
+			pushers = append(pushers, rp)
+			spushers = append(spushers, rp)
+
+			// data pushed to our accounts by other nodes
+			receivers = append(receivers, replica.NewPusher(addr, remotepusher.NewHTTPClient(addr), nil))
+		}
+	}
+
 	pushers = append(pushers, remotepusher.NewRoutedPusher(r))
 
 	if len(pushers) == 1 {
@@ -205,8 +323,22 @@ func main() {
 
 	g := gate.NewGate(p, sp)
 	g.SetRouter(r)
+	g.SetGuard(guard)
+
+	if *forward {
+		f := gate.NewForwarder(func(host string) gatepb.ProcessorServiceInterface {
+			return gatepb.NewTCPRPCProcessorServiceClient(tcprpc.NewClient(host), "v1/")
+		})
+		f.MaxHops = uint32(*forwardMaxHops)
+		g.SetForwarder(f)
+	}
 
-	ps := remotepusher.NewService(pusher.NewChainReceiversPusher(c))
+	var ps *remotepusher.Service
+	if len(receivers) == 1 {
+		ps = remotepusher.NewService(receivers[0])
+	} else {
+		ps = remotepusher.NewService(seqpusher.New(receivers...))
+	}
 
 	tcprpc.RegisterHostnameHandler()
 	http.Handle("/metrics", promhttp.Handler())
@@ -215,6 +347,20 @@ func main() {
 	gatepb.RegisterProcessorServiceHandlers(server, "v1/", g)
 	pusherpb.RegisterPusherServiceHandlers(server, "v1/", ps)
 
+	if *standby {
+		if prel == nil {
+			panic("standby replica requires -db or -bolt to preload accounts from")
+		}
+
+		rep := replica.New(c, sc, prel)
+		pusherpb.RegisterPusherServiceHandlers(server, replica.Prefix, remotepusher.NewService(rep))
+		pusherpb.RegisterSettingsPusherServiceHandlers(server, replica.Prefix, remotepusher.NewSettingsService(rep))
+
+		h := replica.Handler(rep)
+		http.Handle("/cfg/replica", h)
+		http.Handle("/cfg/replica/", h)
+	}
+
 	if err := server.Serve(lis); err != nil {
 		// skip closing server error
 		if err != http.ErrServerClosed {
@@ -228,3 +374,11 @@ func newBigchain(baseurl string) pt.BigChain {
 	cl := plutodbpb.NewTCPRPCPlutoDBServiceClient(g, "v1/")
 	return bigchain.New(cl)
 }
+
+func newBoltchain(file string) (*boltchain.DB, error) {
+	db, err := bolt.Open(file, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "open bolt")
+	}
+	return boltchain.New(db)
+}
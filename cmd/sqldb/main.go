@@ -1,20 +1,28 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"flag"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/boltdb/bolt"
 	"github.com/facebookgo/flagenv"
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/qiwitech/qdp/boltchain"
 	"github.com/qiwitech/qdp/proto/plutodbpb"
 	"github.com/qiwitech/qdp/proto/pusherpb"
+	"github.com/qiwitech/qdp/pt"
 	"github.com/qiwitech/qdp/pusher/remotepusher"
 	"github.com/qiwitech/qdp/sqlchain"
 	"github.com/qiwitech/tcprpc"
@@ -30,9 +38,13 @@ var (
 	listen = flag.String("listen", ":38388", "http addr")
 	dbauth = flag.String("auth", "", "db auth data (user:pass)")
 	dbaddr = flag.String("dbaddr", ":3306", "mysql db address")
+	dbtype = flag.String("dialect", "mysql", "sql db dialect: mysql or postgres")
 	dbname = flag.String("dbname", "plutodb", "db name")
 	create = flag.String("createuser", "", "create new user and grant permissions (user:pass)")
 	drop   = flag.Bool("drop", false, "drop database before start")
+	boltf  = flag.String("bolt", "", "serve from embedded boltdb file instead of mysql")
+	mgonly = flag.Bool("migrate-only", false, "migrate db schema to the latest version and exit")
+	rdonly = flag.Bool("replica", false, "serve reads from read replica db: no pushes, schema is not migrated")
 	//	meta   = flag.Bool("meta", false, "enable metadb handler")
 )
 
@@ -50,14 +62,94 @@ func main() {
 		return true, true
 	}
 
-	lis, err := net.Listen("tcp", *listen)
+	if *boltf != "" && (*mgonly || *rdonly) {
+		fmt.Fprintf(os.Stderr, "-bolt can't be used with -migrate-only or -replica\n")
+		os.Exit(2) // restart won't help, flags must be fixed
+	}
+
+	if *boltf != "" {
+		bdb, err := bolt.Open(*boltf, 0644, &bolt.Options{Timeout: time.Second})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "bolt open: %v\n", err)
+			os.Exit(1) // docker doesn't restart container if error code != 1
+		}
+
+		p, err := boltchain.New(bdb)
+		if err != nil {
+			panic(err)
+		}
+
+		serve(p)
+		return
+	}
+
+	dialect, err := sqlchain.DialectByName(*dbtype)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1) // docker doesn't restart container if error code != 1
+	}
+
+	var db *sql.DB
+	if dialect == sqlchain.Postgres {
+		db = openPostgres(dialect)
+	} else {
+		db = openMySQL()
+		if *create != "" {
+			createuser(db)
+			return
+		}
+	}
+
+	if *rdonly {
+		p, err := sqlchain.NewReadOnly(db, dialect)
+		if errors.Cause(err) == sqlchain.ErrSchemaTooNew {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(2) // restart won't help, newer binary is needed
+		}
+		if err != nil {
+			panic(err)
+		}
+
+		listenAndServe(tcprpc.NewServer(), p)
+		return
+	}
+
+	if *mgonly {
+		from, to, err := sqlchain.Migrate(context.Background(), db, dialect)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Schema migrated from version %d to %d\n", from, to)
+		return
+	}
+
+	p, err := sqlchain.NewDialect(db, dialect)
+	if errors.Cause(err) == sqlchain.ErrSchemaTooNew {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2) // restart won't help, newer binary is needed
+	}
 	if err != nil {
 		panic(err)
 	}
 
-	fmt.Printf("Start server on %v. Commit: %s, Version: %s\n", lis.Addr(), Commit, Version)
+	/*
+		if *meta {
+			p := metadb.New(sdb)
+			svc := metadb.NewMetaDBService(p)
+			metadbpb.RegisterMetaDBServiceHandlers(server, "v1/", svc)
+		}
+	*/
 
-	server := tcprpc.NewServer()
+	serve(p)
+}
+
+// openMySQL connects to mysql and creates database if needed unless it's a replica
+func openMySQL() *sql.DB {
+	if *rdonly {
+		// replica database is replicated from primary
+		return openMySQLDB()
+	}
 
 	db, err := sql.Open("mysql", *dbauth+"@tcp("+*dbaddr+")/")
 	if err != nil {
@@ -79,38 +171,52 @@ func main() {
 		os.Exit(1) // docker doesn't restart container if error code != 1
 	}
 
-	_, err = db.Exec(fmt.Sprintf("USE %s", *dbname))
+	db.Close()
+
+	return openMySQLDB()
+}
+
+// openMySQLDB connects to *dbname database. USE would affect only one pooled connection.
+func openMySQLDB() *sql.DB {
+	db, err := sql.Open("mysql", *dbauth+"@tcp("+*dbaddr+")/"+*dbname)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "sql exec: %v\n", err)
+		fmt.Fprintf(os.Stderr, "sql open: %v\n", err)
 		os.Exit(1) // docker doesn't restart container if error code != 1
 	}
 
-	if *create != "" {
-		createuser(db)
-		return
-	}
+	return db
+}
+
+type store interface {
+	pt.Pusher
+	pt.SettingsPusher
+	plutodbpb.PlutoDBServiceInterface
+}
+
+func serve(p store) {
+	server := tcprpc.NewServer()
+
+	pusherpb.RegisterPusherServiceHandlers(server, "v1/", remotepusher.NewService(p))
+	pusherpb.RegisterSettingsPusherServiceHandlers(server, "v1/", remotepusher.NewSettingsService(p))
 
-	p, err := sqlchain.New(db)
+	listenAndServe(server, p)
+}
+
+// listenAndServe adds PlutoDB service to server and serves requests
+func listenAndServe(server *tcprpc.Server, p plutodbpb.PlutoDBServiceInterface) {
+	lis, err := net.Listen("tcp", *listen)
 	if err != nil {
 		panic(err)
 	}
 
-	/*
-		if *meta {
-			p := metadb.New(sdb)
-			svc := metadb.NewMetaDBService(p)
-			metadbpb.RegisterMetaDBServiceHandlers(server, "v1/", svc)
-		}
-	*/
+	fmt.Printf("Start server on %v. Commit: %s, Version: %s\n", lis.Addr(), Commit, Version)
 
-	pusherpb.RegisterPusherServiceHandlers(server, "v1/", remotepusher.NewService(p))
-	pusherpb.RegisterSettingsPusherServiceHandlers(server, "v1/", remotepusher.NewSettingsService(p))
 	plutodbpb.RegisterPlutoDBServiceHandlers(server, "v1/", p)
 
 	http.Handle("/metrics", promhttp.Handler())
 	server.HandleHTTP(http.DefaultServeMux)
 
-	if err := server.Serve(lis); err != nil {
+	if err = server.Serve(lis); err != nil {
 		// skip closing server error
 		//	if err != http.ErrServerClosed {
 		panic(err)
@@ -118,6 +224,32 @@ func main() {
 	}
 }
 
+// openPostgres connects to existing database, it's not created as mysql one
+func openPostgres(dialect sqlchain.Dialect) *sql.DB {
+	if *drop || *create != "" {
+		fmt.Fprintf(os.Stderr, "-drop and -createuser are supported by mysql only\n")
+		os.Exit(1) // docker doesn't restart container if error code != 1
+	}
+
+	u := url.URL{Scheme: "postgres", Host: *dbaddr, Path: "/" + *dbname, RawQuery: "sslmode=disable"}
+	if *dbauth != "" {
+		a := strings.SplitN(*dbauth, ":", 2)
+		if len(a) == 2 {
+			u.User = url.UserPassword(a[0], a[1])
+		} else {
+			u.User = url.User(a[0])
+		}
+	}
+
+	db, err := sql.Open(dialect.Name(), u.String())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sql open: %v\n", err)
+		os.Exit(1) // docker doesn't restart container if error code != 1
+	}
+
+	return db
+}
+
 func createuser(db *sql.DB) {
 	u := strings.SplitN(*create, ":", 2)
 	if len(u) != 2 {
@@ -0,0 +1,114 @@
+package gate
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/qiwitech/qdp/proto/gatepb"
+)
+
+var (
+	ForwardedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "plutos",
+		Subsystem: "gate",
+		Name:      "forwarded_requests",
+		Help:      "number of requests forwarded to owner node by hop number",
+	}, []string{"method", "hop"})
+
+	ForwardErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "plutos",
+		Subsystem: "gate",
+		Name:      "forward_errors",
+		Help:      "number of failed forwards by hop number",
+	}, []string{"method", "hop"})
+
+	ForwardLoops = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "plutos",
+		Subsystem: "gate",
+		Name:      "forward_loops",
+		Help:      "number of requests not forwarded because of hops limit",
+	}, []string{"method"})
+
+	ForwardDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "plutos",
+		Subsystem: "gate",
+		Name:      "forward_duration_seconds",
+		Help:      "forwarded request latency by hop number",
+	}, []string{"method", "hop"})
+)
+
+func init() {
+	prometheus.MustRegister(ForwardedRequests, ForwardErrors, ForwardLoops, ForwardDuration)
+}
+
+// ClientFunc creates gate client for node
+type ClientFunc func(host string) gatepb.ProcessorServiceInterface
+
+// Forwarder forwards misrouted requests to the owner node instead of responding with SEE_OTHER,
+// so clients don't have to implement routing.
+type Forwarder struct {
+	mu      sync.Mutex
+	clients map[string]gatepb.ProcessorServiceInterface
+	client  ClientFunc
+
+	// MaxHops is a maximum number of times request could be forwarded.
+	// Routing tables may differ while they are updated, so it protects from forwarding loops.
+	MaxHops uint32
+}
+
+func NewForwarder(client ClientFunc) *Forwarder {
+	return &Forwarder{
+		clients: make(map[string]gatepb.ProcessorServiceInterface),
+		client:  client,
+		MaxHops: 2,
+	}
+}
+
+func (f *Forwarder) getClient(host string) gatepb.ProcessorServiceInterface {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+
+	cl, ok := f.clients[host]
+	if !ok {
+		cl = f.client(host)
+		f.clients[host] = cl
+	}
+
+	return cl
+}
+
+// Forward calls f with client of node host. hops is a number of times request was already forwarded.
+// st is a SEE_OTHER status prepared by Gate. It's left as is (with updated message) if request couldn't be forwarded,
+// so routing aware clients still could handle it.
+// It returns true if request was forwarded and call should replace response.
+func (f *Forwarder) Forward(ctx context.Context, method, host string, hops uint32, st *gatepb.Status,
+	call func(gatepb.ProcessorServiceInterface) (*gatepb.Status, error)) bool {
+	if hops >= f.MaxHops {
+		ForwardLoops.WithLabelValues(method).Inc()
+		st.Message = errors.Errorf("route error: forwarded too many times (%d), see other node %s", hops, host).Error()
+		return false
+	}
+
+	hop := strconv.FormatUint(uint64(hops+1), 10)
+	ForwardedRequests.WithLabelValues(method, hop).Inc()
+
+	start := time.Now()
+	rst, err := call(f.getClient(host))
+	ForwardDuration.WithLabelValues(method, hop).Observe(time.Since(start).Seconds())
+
+	if err == nil && rst == nil {
+		err = errors.New("no status")
+	}
+	if err != nil {
+		ForwardErrors.WithLabelValues(method, hop).Inc()
+		st.Message = errors.Wrapf(err, "route error: forward to %s", host).Error()
+		return false
+	}
+
+	return true
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/pkg/errors"
 
+	"github.com/qiwitech/qdp/handoff"
 	"github.com/qiwitech/qdp/preloader"
 	"github.com/qiwitech/qdp/processor"
+	"github.com/qiwitech/qdp/proto/chainpb"
 	"github.com/qiwitech/qdp/proto/gatepb"
 	"github.com/qiwitech/qdp/pt"
 )
@@ -20,6 +22,8 @@ type Gate struct {
 	processor         pt.TransferProcessor
 	settingsProcessor pt.SettingsProcessor
 	router            pt.Router
+	guard             *handoff.Guard
+	forwarder         *Forwarder
 }
 
 func NewGate(processor pt.TransferProcessor, settingsProcessor pt.SettingsProcessor) *Gate {
@@ -30,6 +34,16 @@ func (g *Gate) SetRouter(router pt.Router) {
 	g.router = router
 }
 
+// SetGuard sets accounts handoff guard. Requests for accounts being migrated are rejected with RETRY code.
+func (g *Gate) SetGuard(guard *handoff.Guard) {
+	g.guard = guard
+}
+
+// SetForwarder enables proxy mode. Misrouted requests are forwarded to the owner node instead of SEE_OTHER response.
+func (g *Gate) SetForwarder(f *Forwarder) {
+	g.forwarder = f
+}
+
 func transferFromProto(req *gatepb.TransferRequest) (*pt.Transfer, error) {
 	if len(req.Batch) == 0 {
 		return nil, errors.New("validator: empty batch, no receivers")
@@ -84,6 +98,8 @@ func settingsFromProto(req *gatepb.SettingsRequest) (*pt.Settings, error) {
 		Account: pt.AccID(req.Account),
 		//PublicKey:          pt.PublicKey(req.PublicKey),
 		VerifyTransferSign: req.VerifyTransferSign,
+		ServerSequencing:   req.ServerSequencing,
+		Registered:         req.Registered,
 	}
 
 	if err := validateHexLen(req.PrevHash, len(pt.ZeroHash), "prev_hash"); err != nil {
@@ -110,15 +126,58 @@ func settingsFromProto(req *gatepb.SettingsRequest) (*pt.Settings, error) {
 	return s, nil
 }
 
+func closeFromProto(req *gatepb.CloseAccountRequest) (*pt.Settings, error) {
+	s := &pt.Settings{
+		Account: pt.AccID(req.Account),
+		SweepTo: pt.AccID(req.SweepTo),
+	}
+
+	if err := validateHexLen(req.PrevHash, len(pt.ZeroHash), "prev_hash"); err != nil {
+		return nil, err
+	}
+
+	if err := validateHexLen(req.Sign, len(pt.ZeroSign), "sign"); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if s.PrevHash, err = pt.GetHashFromString(req.PrevHash); err != nil {
+		return nil, errors.Wrap(err, "validator")
+	}
+
+	if s.Sign, err = pt.GetSignFromString(req.Sign); err != nil {
+		return nil, errors.Wrap(err, "validator")
+	}
+
+	return s, nil
+}
+
 // ProcessTransfer checks if it is responsible for Sender account and if so processes requests
 func (g *Gate) ProcessTransfer(ctx context.Context, req *gatepb.TransferRequest) (*gatepb.TransferResponse, error) {
 	res := &gatepb.TransferResponse{
 		Status: &gatepb.Status{Code: gatepb.TransferCode_OK},
 	}
 
-	if !g.checkRouting(res.Status, req.Sender) {
+	if !g.checkRouting(res.Status, req.Sender, req.RouteEpoch) {
+		var fres *gatepb.TransferResponse
+		if g.forward(ctx, "ProcessTransfer", res.Status, req.Sender, req.Hops, func(cl gatepb.ProcessorServiceInterface) (st *gatepb.Status, err error) {
+			fwd := *req
+			fwd.Hops++
+			fwd.RouteEpoch = g.router.Epoch()
+
+			fres, err = cl.ProcessTransfer(ctx, &fwd)
+			return fres.GetStatus(), err
+		}) {
+			return fres, nil
+		}
+
+		return res, nil
+	}
+
+	if !g.enter(res.Status, req.Sender) {
 		return res, nil
 	}
+	defer g.leave(req.Sender)
 
 	t, err := transferFromProto(req)
 	if err != nil {
@@ -147,6 +206,12 @@ func (g *Gate) ProcessTransfer(ctx context.Context, req *gatepb.TransferRequest)
 		case processor.ErrInvalidPrevHash:
 			res.Status.Code = gatepb.TransferCode_INVALID_PREV_HASH
 
+		case processor.ErrAccountClosed:
+			res.Status.Code = gatepb.TransferCode_ACCOUNT_CLOSED
+
+		case processor.ErrInvalidReceiver:
+			res.Status.Code = gatepb.TransferCode_INVALID_RECEIVER
+
 		case preloader.ErrLoading:
 			res.Status.Code = gatepb.TransferCode_RETRY
 
@@ -170,10 +235,27 @@ func (g *Gate) UpdateSettings(ctx context.Context, req *gatepb.SettingsRequest)
 		Status: &gatepb.Status{Code: gatepb.TransferCode_OK},
 	}
 
-	if !g.checkRouting(res.Status, req.Account) {
+	if !g.checkRouting(res.Status, req.Account, req.RouteEpoch) {
+		var fres *gatepb.SettingsResponse
+		if g.forward(ctx, "UpdateSettings", res.Status, req.Account, req.Hops, func(cl gatepb.ProcessorServiceInterface) (st *gatepb.Status, err error) {
+			fwd := *req
+			fwd.Hops++
+			fwd.RouteEpoch = g.router.Epoch()
+
+			fres, err = cl.UpdateSettings(ctx, &fwd)
+			return fres.GetStatus(), err
+		}) {
+			return fres, nil
+		}
+
 		return res, nil
 	}
 
+	if !g.enter(res.Status, req.Account) {
+		return res, nil
+	}
+	defer g.leave(req.Account)
+
 	s, err := settingsFromProto(req)
 	if err != nil {
 		res.Status.Code = gatepb.TransferCode_BAD_REQUEST
@@ -190,6 +272,12 @@ func (g *Gate) UpdateSettings(ctx context.Context, req *gatepb.SettingsRequest)
 		case processor.ErrInvalidSettingsPrevHash:
 			res.Status.Code = gatepb.TransferCode_INVALID_PREV_HASH
 
+		case processor.ErrSettingsClosed:
+			res.Status.Code = gatepb.TransferCode_ACCOUNT_CLOSED
+
+		case processor.ErrCloseBySettings, processor.ErrUnregister:
+			res.Status.Code = gatepb.TransferCode_BAD_REQUEST
+
 		case preloader.ErrLoading:
 			res.Status.Code = gatepb.TransferCode_RETRY
 
@@ -206,15 +294,124 @@ func (g *Gate) UpdateSettings(ctx context.Context, req *gatepb.SettingsRequest)
 	return res, nil
 }
 
+// CloseAccount writes closing settings and sweeps remaining balance.
+// It could be retried with the same request if balance was not swept because of some error.
+func (g *Gate) CloseAccount(ctx context.Context, req *gatepb.CloseAccountRequest) (*gatepb.CloseAccountResponse, error) {
+	res := &gatepb.CloseAccountResponse{
+		Status: &gatepb.Status{Code: gatepb.TransferCode_OK},
+	}
+
+	if !g.checkRouting(res.Status, req.Account, req.RouteEpoch) {
+		var fres *gatepb.CloseAccountResponse
+		if g.forward(ctx, "CloseAccount", res.Status, req.Account, req.Hops, func(cl gatepb.ProcessorServiceInterface) (st *gatepb.Status, err error) {
+			fwd := *req
+			fwd.Hops++
+			fwd.RouteEpoch = g.router.Epoch()
+
+			fres, err = cl.CloseAccount(ctx, &fwd)
+			return fres.GetStatus(), err
+		}) {
+			return fres, nil
+		}
+
+		return res, nil
+	}
+
+	if !g.enter(res.Status, req.Account) {
+		return res, nil
+	}
+	defer g.leave(req.Account)
+
+	s, err := closeFromProto(req)
+	if err != nil {
+		res.Status.Code = gatepb.TransferCode_BAD_REQUEST
+		res.Status.Message = errors.Wrap(err, "gate").Error()
+		return res, nil
+	}
+
+	sres, err := g.settingsProcessor.CloseAccount(ctx, s)
+	if err != nil {
+		res.Status.Message = errors.Wrap(err, "gate").Error()
+		cause := errors.Cause(err)
+
+		switch cause {
+		case processor.ErrInvalidSettingsPrevHash:
+			res.Status.Code = gatepb.TransferCode_INVALID_PREV_HASH
+
+		case processor.ErrInvalidSign:
+			res.Status.Code = gatepb.TransferCode_INVALID_SIGN
+
+		case processor.ErrSettingsClosed:
+			res.Status.Code = gatepb.TransferCode_ACCOUNT_CLOSED
+
+		case processor.ErrInvalidSweepAccount:
+			res.Status.Code = gatepb.TransferCode_INVALID_RECEIVER
+
+		case preloader.ErrLoading:
+			res.Status.Code = gatepb.TransferCode_RETRY
+
+		default:
+			res.Status.Code = gatepb.TransferCode_INTERNAL_ERROR
+		}
+
+		return res, nil
+	}
+
+	res.SettingsId = sres.SettingsID.String()
+	res.Hash = sres.Hash.String()
+
+	tres, err := g.processor.Sweep(ctx, s.Account)
+
+	res.TxnHash = tres.Hash.String()
+
+	if err != nil {
+		res.Status.Message = errors.Wrap(err, "gate: sweep").Error()
+		cause := errors.Cause(err)
+
+		switch cause {
+		case preloader.ErrLoading:
+			res.Status.Code = gatepb.TransferCode_RETRY
+
+		default:
+			res.Status.Code = gatepb.TransferCode_INTERNAL_ERROR
+		}
+
+		return res, nil
+	}
+
+	if tres.TxnID != (pt.TxnID{}) {
+		res.TxnId = tres.TxnID.String()
+	}
+
+	return res, nil
+}
+
 func (g *Gate) GetPrevHash(ctx context.Context, req *gatepb.GetPrevHashRequest) (*gatepb.GetPrevHashResponse, error) {
 	res := &gatepb.GetPrevHashResponse{
 		Status: &gatepb.Status{Code: gatepb.TransferCode_OK},
 	}
 
 	// TODO: draft
-	if !g.checkRouting(res.Status, req.Account) {
+	if !g.checkRouting(res.Status, req.Account, req.RouteEpoch) {
+		var fres *gatepb.GetPrevHashResponse
+		if g.forward(ctx, "GetPrevHash", res.Status, req.Account, req.Hops, func(cl gatepb.ProcessorServiceInterface) (st *gatepb.Status, err error) {
+			fwd := *req
+			fwd.Hops++
+			fwd.RouteEpoch = g.router.Epoch()
+
+			fres, err = cl.GetPrevHash(ctx, &fwd)
+			return fres.GetStatus(), err
+		}) {
+			return fres, nil
+		}
+
+		return res, nil
+	}
+
+	if !g.enter(res.Status, req.Account) {
 		return res, nil
 	}
+	defer g.leave(req.Account)
 
 	h, err := g.processor.GetPrevHash(ctx, pt.AccID(req.Account))
 	if err != nil {
@@ -238,9 +435,26 @@ func (g *Gate) GetBalance(ctx context.Context, req *gatepb.GetBalanceRequest) (*
 		Status: &gatepb.Status{Code: gatepb.TransferCode_OK},
 	}
 
-	if !g.checkRouting(res.Status, req.Account) {
+	if !g.checkRouting(res.Status, req.Account, req.RouteEpoch) {
+		var fres *gatepb.GetBalanceResponse
+		if g.forward(ctx, "GetBalance", res.Status, req.Account, req.Hops, func(cl gatepb.ProcessorServiceInterface) (st *gatepb.Status, err error) {
+			fwd := *req
+			fwd.Hops++
+			fwd.RouteEpoch = g.router.Epoch()
+
+			fres, err = cl.GetBalance(ctx, &fwd)
+			return fres.GetStatus(), err
+		}) {
+			return fres, nil
+		}
+
+		return res, nil
+	}
+
+	if !g.enter(res.Status, req.Account) {
 		return res, nil
 	}
+	defer g.leave(req.Account)
 
 	b, err := g.processor.GetBalance(ctx, pt.AccID(req.Account))
 	if err != nil {
@@ -259,15 +473,76 @@ func (g *Gate) GetBalance(ctx context.Context, req *gatepb.GetBalanceRequest) (*
 	return res, nil
 }
 
+func (g *Gate) GetPendingIncoming(ctx context.Context, req *gatepb.GetPendingIncomingRequest) (*gatepb.GetPendingIncomingResponse, error) {
+	res := &gatepb.GetPendingIncomingResponse{
+		Status: &gatepb.Status{Code: gatepb.TransferCode_OK},
+	}
+
+	if !g.checkRouting(res.Status, req.Account, req.RouteEpoch) {
+		var fres *gatepb.GetPendingIncomingResponse
+		if g.forward(ctx, "GetPendingIncoming", res.Status, req.Account, req.Hops, func(cl gatepb.ProcessorServiceInterface) (st *gatepb.Status, err error) {
+			fwd := *req
+			fwd.Hops++
+			fwd.RouteEpoch = g.router.Epoch()
+
+			fres, err = cl.GetPendingIncoming(ctx, &fwd)
+			return fres.GetStatus(), err
+		}) {
+			return fres, nil
+		}
+
+		return res, nil
+	}
+
+	if !g.enter(res.Status, req.Account) {
+		return res, nil
+	}
+	defer g.leave(req.Account)
+
+	txns, err := g.processor.GetPendingIncoming(ctx, pt.AccID(req.Account))
+	if err != nil {
+		res.Status.Message = errors.Wrap(err, "gate").Error()
+		cause := errors.Cause(err)
+		switch cause {
+		case preloader.ErrLoading:
+			res.Status.Code = gatepb.TransferCode_RETRY
+		default:
+			res.Status.Code = gatepb.TransferCode_INTERNAL_ERROR
+		}
+		return res, nil
+	}
+
+	res.Txns = txnsToProto(txns)
+
+	return res, nil
+}
+
 func (g *Gate) GetLastSettings(ctx context.Context, req *gatepb.GetLastSettingsRequest) (*gatepb.GetLastSettingsResponse, error) {
 	res := &gatepb.GetLastSettingsResponse{
 		Status: &gatepb.Status{Code: gatepb.TransferCode_OK},
 	}
 
 	// TODO: draft
-	if !g.checkRouting(res.Status, req.Account) {
+	if !g.checkRouting(res.Status, req.Account, req.RouteEpoch) {
+		var fres *gatepb.GetLastSettingsResponse
+		if g.forward(ctx, "GetLastSettings", res.Status, req.Account, req.Hops, func(cl gatepb.ProcessorServiceInterface) (st *gatepb.Status, err error) {
+			fwd := *req
+			fwd.Hops++
+			fwd.RouteEpoch = g.router.Epoch()
+
+			fres, err = cl.GetLastSettings(ctx, &fwd)
+			return fres.GetStatus(), err
+		}) {
+			return fres, nil
+		}
+
+		return res, nil
+	}
+
+	if !g.enter(res.Status, req.Account) {
 		return res, nil
 	}
+	defer g.leave(req.Account)
 
 	s, err := g.settingsProcessor.GetLastSettings(ctx, pt.AccID(req.Account))
 	if err != nil {
@@ -291,24 +566,100 @@ func (g *Gate) GetLastSettings(ctx context.Context, req *gatepb.GetLastSettingsR
 	res.PrevHash = s.PrevHash.String()
 	res.DataHash = s.DataHash.String()
 	res.VerifyTransferSign = s.VerifyTransferSign
+	res.ServerSequencing = s.ServerSequencing
+	res.Registered = s.Registered
+	res.Closed = s.Closed
+	res.SweepTo = uint64(s.SweepTo)
 	res.Sign = s.Sign.String()
 	res.PublicKey = s.PublicKey.String()
 
 	return res, nil
 }
 
-func (g *Gate) checkRouting(st *gatepb.Status, acc uint64) bool {
+// checkRouting checks if account belongs to this node and request was routed with the same routing table.
+// epoch == 0 means client doesn't know routing table version.
+func (g *Gate) checkRouting(st *gatepb.Status, acc uint64, epoch uint64) bool {
 	if g.router == nil {
 		return true
 	}
 	node := g.router.GetHostByKey(fmt.Sprintf("%d", pt.AccID(acc)))
+	if epoch != 0 {
+		if cur := g.router.Epoch(); epoch != cur {
+			st.Code = gatepb.TransferCode_SEE_OTHER
+			st.Message = errors.Errorf("route error: epoch mismatch %d, current is %d", epoch, cur).Error()
+			g.setRouteMap(st, node)
+			return false
+		}
+	}
 	if !g.router.IsSelf(node) {
 		st.Code = gatepb.TransferCode_SEE_OTHER
 		st.Message = errors.Errorf("route error: see other node %s", node).Error()
-		rt := &gatepb.RouteMap{Nodes: g.router.Nodes(), Target: node}
-		m, _ := proto.Marshal(rt)
-		st.Details = []*any.Any{{Value: m, TypeUrl: proto.MessageName(rt)}}
+		g.setRouteMap(st, node)
+		return false
+	}
+	return true
+}
+
+// forward forwards misrouted request to the owner node if proxy mode is enabled.
+// It returns true if request was forwarded and response was got.
+func (g *Gate) forward(ctx context.Context, method string, st *gatepb.Status, acc uint64, hops uint32,
+	call func(gatepb.ProcessorServiceInterface) (*gatepb.Status, error)) bool {
+	if g.forwarder == nil || st.Code != gatepb.TransferCode_SEE_OTHER {
+		return false
+	}
+
+	node := g.router.GetHostByKey(fmt.Sprintf("%d", pt.AccID(acc)))
+	if node == "" || g.router.IsSelf(node) {
+		// epoch mismatch only, client must update its routing table
+		return false
+	}
+
+	return g.forwarder.Forward(ctx, method, node, hops, st, call)
+}
+
+// enter registers request at handoff guard. It returns false if account is being migrated.
+func (g *Gate) enter(st *gatepb.Status, acc uint64) bool {
+	if g.guard == nil {
+		return true
+	}
+	if !g.guard.Enter(pt.AccID(acc)) {
+		st.Code = gatepb.TransferCode_RETRY
+		st.Message = "handoff: account is being migrated to another node"
 		return false
 	}
 	return true
 }
+
+func (g *Gate) leave(acc uint64) {
+	if g.guard == nil {
+		return
+	}
+	g.guard.Leave(pt.AccID(acc))
+}
+
+func (g *Gate) setRouteMap(st *gatepb.Status, node string) {
+	rt := &gatepb.RouteMap{Nodes: g.router.Nodes(), Target: node, Epoch: g.router.Epoch()}
+	m, _ := proto.Marshal(rt)
+	st.Details = []*any.Any{{Value: m, TypeUrl: proto.MessageName(rt)}}
+}
+
+func txnsToProto(in []pt.Txn) []*chainpb.Txn {
+	txns := make([]*chainpb.Txn, len(in))
+	for i := range in {
+		t := in[i]
+		txns[i] = &chainpb.Txn{
+			ID:          uint64(t.ID),
+			Sender:      uint64(t.Sender),
+			Receiver:    uint64(t.Receiver),
+			Amount:      t.Amount,
+			Balance:     t.Balance,
+			SpentBy:     uint64(t.SpentBy),
+			SettingsId:  uint64(t.SettingsID),
+			PrevHash:    t.PrevHash[:],
+			Hash:        t.Hash[:],
+			Sign:        t.Sign[:],
+			ProcessedAt: t.ProcessedAt,
+		}
+	}
+	return txns
+}
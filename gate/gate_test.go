@@ -6,12 +6,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/qiwitech/qdp/chain"
 	"github.com/qiwitech/qdp/mocks"
+	"github.com/qiwitech/qdp/preloader"
 	"github.com/qiwitech/qdp/processor"
+	"github.com/qiwitech/qdp/proto/chainpb"
 	"github.com/qiwitech/qdp/proto/gatepb"
 	"github.com/qiwitech/qdp/pt"
 )
@@ -74,7 +77,9 @@ func TestProcessTransferBadRequest(t *testing.T) {
 }
 
 func TestProcessTransfer(t *testing.T) {
-	g := NewGate(processor.NewProcessor(chain.NewChain()), nil)
+	p := processor.NewProcessor(chain.NewChain())
+	p.SetClock(testClock)
+	g := NewGate(p, nil)
 
 	res, err := g.ProcessTransfer(context.TODO(), &gatepb.TransferRequest{
 		Sender: 4,
@@ -87,7 +92,7 @@ func TestProcessTransfer(t *testing.T) {
 		TxnId:   "4_1",
 		Account: 4,
 		Id:      1,
-		Hash:    "d7914f58c8e27e7a3b21aa5cf74021ae01dfb4958bbbf255473add1cc4123e28",
+		Hash:    "4439334416de8a39b9292467ed4b6fe51ba2752be020af549bb04beb01fd1bd6",
 	}, res)
 }
 
@@ -132,7 +137,9 @@ func TestTransferFromProto(t *testing.T) {
 }
 
 func TestUpdateSettings(t *testing.T) {
-	g := NewGate(nil, processor.NewSettingsProcessor(chain.NewSettingsChain()))
+	sp := processor.NewSettingsProcessor(chain.NewSettingsChain())
+	sp.SetClock(testClock)
+	g := NewGate(nil, sp)
 
 	res, err := g.UpdateSettings(context.TODO(), &gatepb.SettingsRequest{})
 
@@ -140,7 +147,7 @@ func TestUpdateSettings(t *testing.T) {
 	assert.Equal(t, &gatepb.SettingsResponse{
 		Status:     &gatepb.Status{Code: gatepb.TransferCode_OK},
 		SettingsId: "0_1",
-		Hash:       "3d31945c4761c707e118a725421f324af313207fcb9db862c8e952ade42c9ee6",
+		Hash:       "13f7fc4d2eb8b439d3063cfb79d7f4c8ece5fdfc301445878ea4c14c9d16aec9",
 	}, res)
 }
 
@@ -170,6 +177,7 @@ func TestUpdateSettingsRoutingError(t *testing.T) {
 	r.EXPECT().GetHostByKey(gomock.Any()).Return("another-txn-host")
 	r.EXPECT().IsSelf(gomock.Any()).Return(false)
 	r.EXPECT().Nodes().Return([]string{"another-txn-host"})
+	r.EXPECT().Epoch().Return(uint64(0))
 
 	res, err := g.UpdateSettings(context.TODO(), &gatepb.SettingsRequest{})
 
@@ -284,6 +292,7 @@ func TestGetBalance(t *testing.T) {
 	r.EXPECT().GetHostByKey(gomock.Any()).Return("another-txn-host")
 	r.EXPECT().IsSelf(gomock.Any()).Return(false)
 	r.EXPECT().Nodes().Return([]string{"another-txn-host"})
+	r.EXPECT().Epoch().Return(uint64(0))
 
 	res, err = g.GetBalance(context.TODO(), &gatepb.GetBalanceRequest{Account: 10})
 	// TODO(outself): add details route-map matching
@@ -292,6 +301,100 @@ func TestGetBalance(t *testing.T) {
 	assert.Equal(t, "route error: see other node another-txn-host", res.Status.Message)
 }
 
+func TestGetPendingIncoming(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockTransferProcessor(mock)
+
+	g := NewGate(proc, nil)
+
+	ctx := context.TODO()
+
+	proc.EXPECT().GetPendingIncoming(ctx, pt.AccID(5)).Return([]pt.Txn{
+		{ID: 3, Sender: 1, Receiver: 5, Amount: 10, SpentBy: 0, ProcessedAt: 100},
+	}, nil)
+
+	res, err := g.GetPendingIncoming(ctx, &gatepb.GetPendingIncomingRequest{Account: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, &gatepb.GetPendingIncomingResponse{
+		Status: &gatepb.Status{},
+		Txns: []*chainpb.Txn{{
+			ID: 3, Sender: 1, Receiver: 5, Amount: 10, ProcessedAt: 100,
+			PrevHash: pt.ZeroHash[:], Hash: pt.ZeroHash[:], Sign: pt.ZeroSign[:],
+		}},
+	}, res)
+
+	proc.EXPECT().GetPendingIncoming(ctx, pt.AccID(5)).Return(nil, preloader.ErrLoading)
+
+	res, err = g.GetPendingIncoming(ctx, &gatepb.GetPendingIncomingRequest{Account: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, gatepb.TransferCode_RETRY, res.Status.Code)
+}
+
+func TestCloseAccount(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockTransferProcessor(mock)
+	sproc := mocks.NewMockSettingsProcessor(mock)
+
+	g := NewGate(proc, sproc)
+
+	zero := "0000000000000000000000000000000000000000000000000000000000000000"
+
+	// bad request
+	res, err := g.CloseAccount(context.TODO(), &gatepb.CloseAccountRequest{Account: 10, PrevHash: "qwe"})
+	assert.NoError(t, err)
+	assert.Equal(t, gatepb.TransferCode_BAD_REQUEST, res.Status.Code)
+
+	// closed and swept
+	sproc.EXPECT().CloseAccount(gomock.Any(), &pt.Settings{Account: 10, SweepTo: 20}).
+		Return(pt.SettingsResult{SettingsID: pt.NewSettingsID(10, 2)}, nil)
+	proc.EXPECT().Sweep(gomock.Any(), pt.AccID(10)).Return(pt.TransferResult{TxnID: pt.NewTxnID(10, 5), SettingsId: 2}, nil)
+
+	res, err = g.CloseAccount(context.TODO(), &gatepb.CloseAccountRequest{Account: 10, SweepTo: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, &gatepb.CloseAccountResponse{
+		Status:     &gatepb.Status{Code: gatepb.TransferCode_OK},
+		SettingsId: "10_2",
+		Hash:       zero,
+		TxnId:      "10_5",
+		TxnHash:    zero,
+	}, res)
+
+	// nothing to sweep
+	sproc.EXPECT().CloseAccount(gomock.Any(), gomock.Any()).Return(pt.SettingsResult{SettingsID: pt.NewSettingsID(10, 2)}, nil)
+	proc.EXPECT().Sweep(gomock.Any(), pt.AccID(10)).Return(pt.TransferResult{SettingsId: 2}, nil)
+
+	res, err = g.CloseAccount(context.TODO(), &gatepb.CloseAccountRequest{Account: 10, SweepTo: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, gatepb.TransferCode_OK, res.Status.Code)
+	assert.Equal(t, "", res.TxnId)
+
+	// already closed to another account
+	sproc.EXPECT().CloseAccount(gomock.Any(), gomock.Any()).Return(pt.SettingsResult{}, processor.ErrSettingsClosed)
+
+	res, err = g.CloseAccount(context.TODO(), &gatepb.CloseAccountRequest{Account: 10, SweepTo: 30})
+	assert.NoError(t, err)
+	assert.Equal(t, &gatepb.Status{
+		Code:    gatepb.TransferCode_ACCOUNT_CLOSED,
+		Message: "gate: settings processor: account is closed",
+	}, res.Status)
+
+	// sweep error
+	sproc.EXPECT().CloseAccount(gomock.Any(), gomock.Any()).Return(pt.SettingsResult{SettingsID: pt.NewSettingsID(10, 2)}, nil)
+	proc.EXPECT().Sweep(gomock.Any(), pt.AccID(10)).Return(pt.TransferResult{}, errors.New("push"))
+
+	res, err = g.CloseAccount(context.TODO(), &gatepb.CloseAccountRequest{Account: 10, SweepTo: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, &gatepb.Status{
+		Code:    gatepb.TransferCode_INTERNAL_ERROR,
+		Message: "gate: sweep: push",
+	}, res.Status)
+	assert.Equal(t, "10_2", res.SettingsId)
+}
+
 func TestGetLastSettings(t *testing.T) {
 	mock := gomock.NewController(t)
 	defer mock.Finish()
@@ -342,6 +445,7 @@ func TestGetLastSettings(t *testing.T) {
 	r.EXPECT().GetHostByKey(gomock.Any()).Return("another-txn-host")
 	r.EXPECT().IsSelf(gomock.Any()).Return(false)
 	r.EXPECT().Nodes().Return([]string{"another-txn-host"})
+	r.EXPECT().Epoch().Return(uint64(0))
 
 	res, err = g.GetLastSettings(context.TODO(), &gatepb.GetLastSettingsRequest{Account: 10})
 	// TODO(outself): add details route-map matching
@@ -417,6 +521,7 @@ func TestRouting(t *testing.T) {
 	r.EXPECT().GetHostByKey(gomock.Any()).Return("another-host")
 	r.EXPECT().IsSelf(gomock.Any()).Return(false)
 	r.EXPECT().Nodes().Return([]string{"another-host"})
+	r.EXPECT().Epoch().Return(uint64(0))
 
 	res, err := g.GetPrevHash(context.TODO(), &gatepb.GetPrevHashRequest{Account: 10})
 	// TODO(outself): add details route-map matching
@@ -427,6 +532,7 @@ func TestRouting(t *testing.T) {
 	r.EXPECT().GetHostByKey(gomock.Any()).Return("another-txn-host")
 	r.EXPECT().IsSelf(gomock.Any()).Return(false)
 	r.EXPECT().Nodes().Return([]string{"another-txn-host"})
+	r.EXPECT().Epoch().Return(uint64(0))
 
 	// TODO(outself): prev_hash without routing case
 
@@ -448,3 +554,94 @@ func TestRouting(t *testing.T) {
 		Hash:   "0000000000000000000000000000000000000000000000000000000000000000",
 	}, res2)
 }
+
+func TestRoutingEpochMismatch(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockTransferProcessor(mock)
+	r := mocks.NewMockRouter(mock)
+
+	g := NewGate(proc, nil)
+	g.SetRouter(r)
+
+	r.EXPECT().GetHostByKey(gomock.Any()).Return("self-host")
+	r.EXPECT().Epoch().Return(uint64(5)).Times(2)
+	r.EXPECT().Nodes().Return([]string{"self-host"})
+
+	res, err := g.GetBalance(context.TODO(), &gatepb.GetBalanceRequest{Account: 10, RouteEpoch: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, gatepb.TransferCode_SEE_OTHER, res.Status.Code)
+	assert.Equal(t, "route error: epoch mismatch 4, current is 5", res.Status.Message)
+
+	var rt gatepb.RouteMap
+	if assert.Len(t, res.Status.Details, 1) {
+		assert.NoError(t, proto.Unmarshal(res.Status.Details[0].Value, &rt))
+	}
+	assert.Equal(t, gatepb.RouteMap{Nodes: []string{"self-host"}, Target: "self-host", Epoch: 5}, rt)
+
+	r.EXPECT().GetHostByKey(gomock.Any()).Return("self-host")
+	r.EXPECT().Epoch().Return(uint64(5))
+	r.EXPECT().IsSelf(gomock.Any()).Return(true)
+	proc.EXPECT().GetBalance(context.TODO(), pt.AccID(10)).Return(int64(7), nil)
+
+	res, err = g.GetBalance(context.TODO(), &gatepb.GetBalanceRequest{Account: 10, RouteEpoch: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), res.Balance)
+}
+
+func TestRoutingForward(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	proc := mocks.NewMockTransferProcessor(mock)
+	r := mocks.NewMockRouter(mock)
+	cl := mocks.NewMockProcessorServiceInterface(mock)
+
+	g := NewGate(proc, nil)
+	g.SetRouter(r)
+
+	var hosts []string
+	g.SetForwarder(NewForwarder(func(host string) gatepb.ProcessorServiceInterface {
+		hosts = append(hosts, host)
+		return cl
+	}))
+
+	// forwarded
+	r.EXPECT().GetHostByKey(gomock.Any()).Return("another-host").Times(2)
+	r.EXPECT().IsSelf("another-host").Return(false).Times(2)
+	r.EXPECT().Nodes().Return([]string{"another-host"})
+	r.EXPECT().Epoch().Return(uint64(3)).Times(2)
+	cl.EXPECT().GetBalance(context.TODO(), &gatepb.GetBalanceRequest{Account: 10, RouteEpoch: 3, Hops: 1}).
+		Return(&gatepb.GetBalanceResponse{Status: &gatepb.Status{}, Balance: 7}, nil)
+
+	res, err := g.GetBalance(context.TODO(), &gatepb.GetBalanceRequest{Account: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, &gatepb.GetBalanceResponse{Status: &gatepb.Status{}, Balance: 7}, res)
+
+	// hops limit
+	r.EXPECT().GetHostByKey(gomock.Any()).Return("another-host").Times(2)
+	r.EXPECT().IsSelf("another-host").Return(false).Times(2)
+	r.EXPECT().Nodes().Return([]string{"another-host"})
+	r.EXPECT().Epoch().Return(uint64(3))
+
+	res, err = g.GetBalance(context.TODO(), &gatepb.GetBalanceRequest{Account: 10, Hops: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, gatepb.TransferCode_SEE_OTHER, res.Status.Code)
+	assert.Equal(t, "route error: forwarded too many times (2), see other node another-host", res.Status.Message)
+
+	// forward error
+	r.EXPECT().GetHostByKey(gomock.Any()).Return("another-host").Times(2)
+	r.EXPECT().IsSelf("another-host").Return(false).Times(2)
+	r.EXPECT().Nodes().Return([]string{"another-host"})
+	r.EXPECT().Epoch().Return(uint64(3)).Times(2)
+	cl.EXPECT().ProcessTransfer(context.TODO(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	res2, err := g.ProcessTransfer(context.TODO(), &gatepb.TransferRequest{Sender: 10, Batch: []*gatepb.TransferItem{{}}})
+	assert.NoError(t, err)
+	assert.Equal(t, gatepb.TransferCode_SEE_OTHER, res2.Status.Code)
+	assert.Equal(t, "route error: forward to another-host: connection refused", res2.Status.Message)
+
+	// clients are pooled
+	assert.Equal(t, []string{"another-host"}, hosts)
+}
@@ -3,6 +3,7 @@ package gate
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/any"
@@ -15,9 +16,14 @@ import (
 	"github.com/qiwitech/qdp/pt"
 )
 
+func testClock() time.Time {
+	return time.Unix(1500000000, 0)
+}
+
 func TestProcessorIntegration(t *testing.T) {
 	c := chain.NewChain()
 	p := processor.NewProcessor(c)
+	p.SetClock(testClock)
 	g := NewGate(p, nil)
 
 	c.PutTo(10, []pt.Txn{{
@@ -38,7 +44,7 @@ func TestProcessorIntegration(t *testing.T) {
 		TxnId:   "10_2",
 		Account: 10,
 		Id:      2,
-		Hash:    "c2345333b8a30ef05830c11bd18420ada30720b860c213452b1badab54e5f557",
+		Hash:    "f849a63e0d0799152acf86c884f68eb98f99beb8a87231f73c42391620432823",
 	}, resp)
 }
 
@@ -48,6 +54,7 @@ func TestRouterIntegrationSelfNode(t *testing.T) {
 
 	c := chain.NewChain()
 	p := processor.NewProcessor(c)
+	p.SetClock(testClock)
 	g := NewGate(p, nil)
 
 	r := mocks.NewMockRouter(mock)
@@ -80,7 +87,7 @@ func TestRouterIntegrationSelfNode(t *testing.T) {
 		TxnId:   "10_2",
 		Account: 10,
 		Id:      2,
-		Hash:    "c2345333b8a30ef05830c11bd18420ada30720b860c213452b1badab54e5f557",
+		Hash:    "f849a63e0d0799152acf86c884f68eb98f99beb8a87231f73c42391620432823",
 	}, resp)
 }
 
@@ -90,6 +97,7 @@ func TestRouterIntegrationReroute(t *testing.T) {
 
 	c := chain.NewChain()
 	p := processor.NewProcessor(c)
+	p.SetClock(testClock)
 	g := NewGate(p, nil)
 
 	r := mocks.NewMockRouter(mock)
@@ -112,6 +120,7 @@ func TestRouterIntegrationReroute(t *testing.T) {
 		assert.Equal(t, "some_host", h)
 	}).Return(false)
 	r.EXPECT().Nodes().Return([]string{"host_a", "host_b", "host_c"})
+	r.EXPECT().Epoch().Return(uint64(0))
 
 	resp, err := g.ProcessTransfer(context.TODO(), &gatepb.TransferRequest{
 		Sender: 10,
@@ -0,0 +1,63 @@
+package handoff
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/qiwitech/qdp/router"
+)
+
+// Failover gives all ranges of failed node to standby host.
+// Unlike Migrate failed node is not asked for anything and unavailable nodes are skipped.
+// node is any alive node of the cluster to get actual routing table from.
+func Failover(ctx context.Context, node, failed, standby string) error {
+	var cur router.HTTPData
+	err := call(ctx, "GET", node, "/cfg/router", nil, &cur)
+	if err != nil {
+		return errors.Wrap(err, "get routing table")
+	}
+
+	nodes := make([]string, len(cur.Nodes))
+	replaced := 0
+	for i, n := range cur.Nodes {
+		p := strings.SplitN(n, "=", 2)
+		if p[len(p)-1] == failed {
+			p[len(p)-1] = standby
+			replaced++
+		}
+		nodes[i] = strings.Join(p, "=")
+	}
+	if replaced == 0 {
+		return errors.Errorf("node %v is not found at routing table", failed)
+	}
+
+	epoch := cur.Epoch + 1
+
+	log.Printf("failover: move %d ranges from %v to %v, epoch %d", replaced, failed, standby, epoch)
+
+	for _, n := range hosts(cur.Nodes, nodes) {
+		if n == failed {
+			continue
+		}
+
+		var d router.HTTPData
+		err = call(ctx, "GET", n, "/cfg/router", nil, &d)
+		if err == nil {
+			d.Nodes = nodes
+			d.Epoch = epoch
+
+			err = call(ctx, "POST", n, "/cfg/router", &d, nil)
+		}
+		if err != nil && n == standby {
+			return errors.Wrapf(err, "set routing table at standby %v", n)
+		}
+		if err != nil {
+			log.Printf("failover: set routing table at %v: %v", n, err)
+		}
+	}
+
+	return nil
+}
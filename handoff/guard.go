@@ -0,0 +1,161 @@
+// Package handoff implements moving of accounts range ownership between plutos nodes.
+//
+// Migration goes in several steps driven by Migrate:
+//  1. new owner stops accepting requests for the range (Prepare)
+//  2. old owner stops accepting requests for the range, waits for requests in progress
+//     (they push their txns synchronously, so it's all pushed after that) and drops range from its cache (Release)
+//  3. routing table with increased epoch is set at all nodes
+//  4. old owner unfreezes the range, so it responds with actual routing table from now (Finish)
+//  5. new owner preloads accounts released by old owner from BigChain and starts accepting requests (Accept)
+//
+// Incoming txns are not guarded, receivers are pushed after txn is stored to BigChain.
+// So new owner preloads accounts after old owner finished, and incoming txns pushed to the old owner
+// by senders with the previous table are loaded from BigChain.
+//
+// If any step after Release fails, the range is given back: new owner drops what it has loaded,
+// the previous table is set at all nodes at the next epoch and both nodes unfreeze the range.
+package handoff
+
+import (
+	"context"
+	"sync"
+
+	"github.com/qiwitech/qdp/pt"
+)
+
+// Range is an accounts range [From, To).
+// If To <= From range is wrapped around the end of accounts space (it's how StaticRouter works for the last shard).
+type Range struct {
+	From uint64
+	To   uint64
+}
+
+// Contains checks if account belongs to the range
+func (r Range) Contains(acc pt.AccID) bool {
+	a := uint64(acc)
+	if r.From < r.To {
+		return r.From <= a && a < r.To
+	}
+	return a >= r.From || a < r.To
+}
+
+// Guard tracks requests in progress and blocks requests for ranges being migrated
+type Guard struct {
+	mu       sync.Mutex
+	frozen   []Range
+	inflight map[pt.AccID]int
+	done     chan struct{}
+}
+
+func NewGuard() *Guard {
+	return &Guard{
+		inflight: make(map[pt.AccID]int),
+		done:     make(chan struct{}),
+	}
+}
+
+// Enter registers request for account. It returns false if account is frozen.
+// Leave must be called for each successful Enter.
+func (g *Guard) Enter(acc pt.AccID) bool {
+	defer g.mu.Unlock()
+	g.mu.Lock()
+
+	if g.isFrozen(acc) {
+		return false
+	}
+
+	g.inflight[acc]++
+
+	return true
+}
+
+// Leave marks request for account as finished
+func (g *Guard) Leave(acc pt.AccID) {
+	defer g.mu.Unlock()
+	g.mu.Lock()
+
+	g.inflight[acc]--
+	if g.inflight[acc] > 0 {
+		return
+	}
+
+	delete(g.inflight, acc)
+
+	close(g.done)
+	g.done = make(chan struct{})
+}
+
+// Freeze stops accepting new requests for the range and waits until all requests in progress are finished.
+// Range stays frozen until Unfreeze even if ctx was canceled. Freezing frozen range again only waits.
+func (g *Guard) Freeze(ctx context.Context, r Range) error {
+	g.mu.Lock()
+	if !g.hasFrozen(r) {
+		g.frozen = append(g.frozen, r)
+	}
+	g.mu.Unlock()
+
+	for {
+		g.mu.Lock()
+		busy := g.isBusy(r)
+		done := g.done
+		g.mu.Unlock()
+
+		if !busy {
+			return nil
+		}
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Unfreeze allows requests for the range again
+func (g *Guard) Unfreeze(r Range) {
+	defer g.mu.Unlock()
+	g.mu.Lock()
+
+	for i, f := range g.frozen {
+		if f == r {
+			g.frozen = append(g.frozen[:i], g.frozen[i+1:]...)
+			return
+		}
+	}
+}
+
+// IsFrozen checks if account belongs to some frozen range
+func (g *Guard) IsFrozen(acc pt.AccID) bool {
+	defer g.mu.Unlock()
+	g.mu.Lock()
+
+	return g.isFrozen(acc)
+}
+
+func (g *Guard) isFrozen(acc pt.AccID) bool {
+	for _, r := range g.frozen {
+		if r.Contains(acc) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Guard) hasFrozen(r Range) bool {
+	for _, f := range g.frozen {
+		if f == r {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Guard) isBusy(r Range) bool {
+	for acc := range g.inflight {
+		if r.Contains(acc) {
+			return true
+		}
+	}
+	return false
+}
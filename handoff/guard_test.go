@@ -0,0 +1,89 @@
+package handoff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/pt"
+)
+
+func TestRangeContains(t *testing.T) {
+	r := Range{From: 10, To: 20}
+	assert.False(t, r.Contains(9))
+	assert.True(t, r.Contains(10))
+	assert.True(t, r.Contains(19))
+	assert.False(t, r.Contains(20))
+
+	// last shard
+	r = Range{From: 10, To: 0}
+	assert.False(t, r.Contains(9))
+	assert.True(t, r.Contains(10))
+	assert.True(t, r.Contains(1<<63))
+
+	// wrapped around
+	r = Range{From: 10, To: 5}
+	assert.True(t, r.Contains(4))
+	assert.False(t, r.Contains(5))
+	assert.False(t, r.Contains(9))
+	assert.True(t, r.Contains(10))
+}
+
+func TestGuardFreeze(t *testing.T) {
+	g := NewGuard()
+	r := Range{From: 10, To: 20}
+
+	assert.True(t, g.Enter(15))
+	assert.True(t, g.Enter(25))
+
+	frozen := make(chan error, 1)
+	go func() {
+		frozen <- g.Freeze(context.TODO(), r)
+	}()
+
+	// wait for freeze to start
+	for !g.IsFrozen(15) {
+		time.Sleep(time.Millisecond)
+	}
+
+	assert.False(t, g.Enter(16))
+	assert.True(t, g.Enter(26))
+
+	select {
+	case <-frozen:
+		t.Fatalf("freeze must wait for requests in progress")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	g.Leave(25)
+	g.Leave(15)
+
+	select {
+	case err := <-frozen:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatalf("freeze wasn't finished")
+	}
+
+	// frozen again by rollback, single unfreeze is enough
+	assert.NoError(t, g.Freeze(context.TODO(), r))
+
+	g.Unfreeze(r)
+	assert.True(t, g.Enter(pt.AccID(16)))
+}
+
+func TestGuardFreezeCanceled(t *testing.T) {
+	g := NewGuard()
+	r := Range{From: 10, To: 20}
+
+	assert.True(t, g.Enter(15))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := g.Freeze(ctx, r)
+	assert.EqualError(t, err, "context canceled")
+	assert.True(t, g.IsFrozen(15))
+}
@@ -0,0 +1,245 @@
+package handoff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/qiwitech/qdp/router"
+)
+
+// Migrate moves accounts range starting at shard point to host.
+// If there is no such shard point at the routing table it's inserted, so existing range is split.
+// node is any node of the cluster to get actual routing table from.
+func Migrate(ctx context.Context, node string, shard uint64, host string) error {
+	var cur router.HTTPData
+	err := call(ctx, "GET", node, "/cfg/router", nil, &cur)
+	if err != nil {
+		return errors.Wrap(err, "get routing table")
+	}
+
+	nodes, r, from, err := Reassign(cur.Nodes, shard, host)
+	if err != nil {
+		return errors.Wrap(err, "reassign")
+	}
+
+	epoch := cur.Epoch + 1
+
+	log.Printf("handoff: move range %v from %v to %v, epoch %d", r, from, host, epoch)
+
+	unfreeze := func() {
+		for _, n := range []string{host, from} {
+			if err := call(ctx, "POST", n, "/cfg/handoff/finish", &HTTPData{Range: r}, nil); err != nil {
+				log.Printf("handoff: finish %v: %v", n, err)
+			}
+		}
+	}
+
+	err = call(ctx, "POST", host, "/cfg/handoff/prepare", &HTTPData{Range: r}, nil)
+	if err != nil {
+		unfreeze()
+		return errors.Wrap(err, "prepare")
+	}
+
+	var released HTTPData
+	err = call(ctx, "POST", from, "/cfg/handoff/release", &HTTPData{Range: r}, &released)
+	if err != nil {
+		unfreeze()
+		return errors.Wrap(err, "release")
+	}
+
+	log.Printf("handoff: %d accounts released by %v", len(released.Accounts), from)
+
+	// rollback gives the range back to from. ctx could be canceled already, so it's not used.
+	// Host drops accounts it could have loaded, the previous table is set at all nodes
+	// at the next epoch (some of them could have the new one) and the range is unfrozen.
+	rollback := func() {
+		bg := context.Background()
+
+		log.Printf("handoff: rollback range %v to %v, epoch %d", r, from, epoch+1)
+
+		if err := call(bg, "POST", host, "/cfg/handoff/release", &HTTPData{Range: r}, nil); err != nil {
+			log.Printf("handoff: rollback release %v: %v", host, err)
+		}
+		for _, n := range hosts(cur.Nodes, nodes) {
+			if err := setTable(bg, n, cur.Nodes, epoch+1); err != nil {
+				log.Printf("handoff: rollback routing table at %v: %v", n, err)
+			}
+		}
+		unfreeze()
+	}
+
+	for _, n := range hosts(cur.Nodes, nodes) {
+		err = setTable(ctx, n, nodes, epoch)
+		if err != nil {
+			rollback()
+			return errors.Wrapf(err, "set routing table at %v", n)
+		}
+	}
+
+	// accounts are loaded only after the old owner refers requests to host,
+	// so incoming txns pushed to the old owner before that are loaded too
+	err = call(ctx, "POST", from, "/cfg/handoff/finish", &HTTPData{Range: r}, nil)
+	if err != nil {
+		rollback()
+		return errors.Wrap(err, "finish")
+	}
+
+	err = call(ctx, "POST", host, "/cfg/handoff/accept", &HTTPData{Range: r, Accounts: released.Accounts}, nil)
+	if err != nil {
+		rollback()
+		return errors.Wrap(err, "accept")
+	}
+
+	return nil
+}
+
+// setTable sets routing table at node keeping its self
+func setTable(ctx context.Context, node string, nodes []string, epoch uint64) error {
+	var d router.HTTPData
+	err := call(ctx, "GET", node, "/cfg/router", nil, &d)
+	if err != nil {
+		return errors.Wrap(err, "get routing table")
+	}
+
+	d.Nodes = nodes
+	d.Epoch = epoch
+
+	return call(ctx, "POST", node, "/cfg/router", &d, nil)
+}
+
+// Reassign sets host as owner of the range starting at shard point.
+// nodes are in StaticRouter format ("{shard}={host}").
+// It returns new routing table, range being moved and its previous owner.
+func Reassign(nodes []string, shard uint64, host string) ([]string, Range, string, error) {
+	type point struct {
+		shard uint64
+		host  string
+	}
+
+	points := make([]point, 0, len(nodes)+1)
+	for _, n := range nodes {
+		s := strings.SplitN(n, "=", 2)
+		if len(s) != 2 {
+			return nil, Range{}, "", errors.Errorf("bad node format: %q, expected '{shard}={host}'", n)
+		}
+		p, err := strconv.ParseUint(s[0], 10, 64)
+		if err != nil {
+			return nil, Range{}, "", errors.Wrapf(err, "parse shard %q", s[0])
+		}
+		points = append(points, point{shard: p, host: s[1]})
+	}
+	if len(points) == 0 {
+		return nil, Range{}, "", errors.New("empty routing table")
+	}
+
+	sort.Slice(points, func(i, j int) bool { return points[i].shard < points[j].shard })
+
+	l := len(points)
+	idx := sort.Search(l, func(i int) bool { return shard < points[i].shard })
+	// owner of the range containing shard point
+	from := points[(idx-1+l)%l].host
+
+	if idx == 0 || points[idx-1].shard != shard {
+		points = append(points, point{})
+		copy(points[idx+1:], points[idx:])
+		points[idx] = point{shard: shard}
+		l++
+	} else {
+		idx--
+	}
+
+	if from == host {
+		return nil, Range{}, "", errors.Errorf("range is already owned by %v", host)
+	}
+
+	points[idx].host = host
+
+	r := Range{From: shard, To: points[(idx+1)%l].shard}
+
+	res := make([]string, l)
+	for i, p := range points {
+		res[i] = fmt.Sprintf("%d=%s", p.shard, p.host)
+	}
+
+	return res, r, from, nil
+}
+
+func hosts(tables ...[]string) []string {
+	var res []string
+	seen := make(map[string]struct{})
+	for _, t := range tables {
+		for _, n := range t {
+			if i := strings.Index(n, "="); i != -1 {
+				n = n[i+1:]
+			}
+			if _, ok := seen[n]; ok {
+				continue
+			}
+			seen[n] = struct{}{}
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+func call(ctx context.Context, method, host, path string, req, res interface{}) error {
+	var body bytes.Buffer
+	if req != nil {
+		err := json.NewEncoder(&body).Encode(req)
+		if err != nil {
+			return errors.Wrap(err, "encode request")
+		}
+	}
+
+	u := url.URL{Scheme: "http", Host: host, Path: path}
+	hreq, err := http.NewRequest(method, u.String(), &body)
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+	hreq = hreq.WithContext(ctx)
+
+	resp, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("bad response status: %v", resp.Status)
+	}
+
+	var raw json.RawMessage
+	err = json.NewDecoder(resp.Body).Decode(&raw)
+	if err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+
+	var e struct {
+		Error string `json:"error"`
+	}
+	_ = json.Unmarshal(raw, &e)
+	if e.Error != "" {
+		return errors.New(e.Error)
+	}
+
+	if res == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(raw, res)
+	if err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package handoff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/pt"
+	"github.com/qiwitech/qdp/router"
+)
+
+func TestReassign(t *testing.T) {
+	table := []string{"0=a", "100=b", "200=c"}
+
+	nodes, r, from, err := Reassign(table, 100, "d")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0=a", "100=d", "200=c"}, nodes)
+	assert.Equal(t, Range{From: 100, To: 200}, r)
+	assert.Equal(t, "b", from)
+
+	// split
+	nodes, r, from, err = Reassign(table, 150, "d")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0=a", "100=b", "150=d", "200=c"}, nodes)
+	assert.Equal(t, Range{From: 150, To: 200}, r)
+	assert.Equal(t, "b", from)
+
+	// last
+	nodes, r, from, err = Reassign(table, 300, "d")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0=a", "100=b", "200=c", "300=d"}, nodes)
+	assert.Equal(t, Range{From: 300, To: 0}, r)
+	assert.Equal(t, "c", from)
+
+	// before first (wrapped)
+	nodes, r, from, err = Reassign([]string{"100=b", "200=c"}, 50, "d")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"50=d", "100=b", "200=c"}, nodes)
+	assert.Equal(t, Range{From: 50, To: 100}, r)
+	assert.Equal(t, "c", from)
+
+	_, _, _, err = Reassign(table, 120, "b")
+	assert.EqualError(t, err, "range is already owned by b")
+
+	_, _, _, err = Reassign([]string{"a"}, 120, "b")
+	assert.EqualError(t, err, `bad node format: "a", expected '{shard}={host}'`)
+}
+
+type testNode struct {
+	addr   string
+	router *router.StaticRouter
+	guard  *Guard
+	cache  *testCache
+
+	// before is called before handling request
+	before func(path string)
+}
+
+func newTestNode(cache *testCache) (*testNode, func()) {
+	n := &testNode{router: router.NewStatic(""), guard: NewGuard(), cache: cache}
+
+	rh := router.Handler(n.router)
+	mux := http.NewServeMux()
+	mux.Handle("/cfg/router", rh)
+	mux.Handle("/cfg/handoff/", Handler(NewNode(n.guard, cache)))
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if n.before != nil {
+			n.before(req.URL.Path)
+		}
+		mux.ServeHTTP(w, req)
+	}))
+	n.addr = strings.TrimPrefix(s.URL, "http://")
+	n.router.SetSelf(n.addr)
+
+	return n, s.Close
+}
+
+func TestMigrateAcceptRollback(t *testing.T) {
+	from, closeFrom := newTestNode(&testCache{loaded: map[pt.AccID]struct{}{5: {}, 150: {}}})
+	defer closeFrom()
+	to, closeTo := newTestNode(&testCache{loaded: map[pt.AccID]struct{}{}, err: errors.New("db is down")})
+	defer closeTo()
+
+	table := []string{"0=" + from.addr}
+	for _, n := range []*testNode{from, to} {
+		assert.True(t, n.router.SetEpochNodes(1, table))
+	}
+
+	err := Migrate(context.TODO(), from.addr, 100, to.addr)
+	assert.EqualError(t, err, "accept: preload 150: db is down")
+
+	// range is back at the old owner and writable again
+	for _, n := range []*testNode{from, to} {
+		assert.Equal(t, table, n.router.Nodes())
+		assert.Equal(t, uint64(3), n.router.Epoch())
+		assert.False(t, n.guard.IsFrozen(150))
+	}
+	assert.True(t, from.guard.Enter(150))
+	from.guard.Leave(150)
+	assert.Equal(t, []pt.AccID{5}, from.cache.Loaded())
+}
+
+func TestMigrateIncomingPush(t *testing.T) {
+	db := map[pt.AccID]int{150: 1}
+
+	from, closeFrom := newTestNode(&testCache{loaded: map[pt.AccID]struct{}{150: {}}, db: db, incoming: map[pt.AccID]int{150: 1}})
+	defer closeFrom()
+	to, closeTo := newTestNode(&testCache{loaded: map[pt.AccID]struct{}{}, db: db, incoming: map[pt.AccID]int{}})
+	defer closeTo()
+
+	table := []string{"0=" + from.addr}
+	for _, n := range []*testNode{from, to} {
+		assert.True(t, n.router.SetEpochNodes(1, table))
+	}
+
+	// sender with the previous table stores txn to 150 and pushes it to the old owner until it refers requests away
+	from.before = func(path string) {
+		if path == "/cfg/handoff/finish" {
+			db[150]++
+		}
+	}
+
+	err := Migrate(context.TODO(), from.addr, 100, to.addr)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []pt.AccID{150}, to.cache.Loaded())
+	assert.Equal(t, 2, to.cache.incoming[150])
+	assert.False(t, to.guard.IsFrozen(150))
+	assert.False(t, from.guard.IsFrozen(150))
+}
@@ -0,0 +1,125 @@
+package handoff
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/pkg/errors"
+	"github.com/pressly/chi"
+
+	"github.com/qiwitech/qdp/pt"
+)
+
+// Cache is an local accounts cache which could be reloaded from BigChain.
+// preloader.Preloader implements it.
+type Cache interface {
+	pt.Preloader
+	// Loaded returns all accounts are in cache now
+	Loaded() []pt.AccID
+}
+
+// Node is a node side of handoff protocol
+type Node struct {
+	guard *Guard
+	cache Cache
+}
+
+func NewNode(guard *Guard, cache Cache) *Node {
+	return &Node{
+		guard: guard,
+		cache: cache,
+	}
+}
+
+// Prepare stops accepting requests for the range at the new owner until Accept
+func (n *Node) Prepare(ctx context.Context, r Range) error {
+	return n.guard.Freeze(ctx, r)
+}
+
+// Release stops accepting requests for the range at the old owner, waits for requests in progress
+// and drops range accounts from cache. It returns list of dropped accounts.
+func (n *Node) Release(ctx context.Context, r Range) ([]pt.AccID, error) {
+	err := n.guard.Freeze(ctx, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "freeze")
+	}
+
+	var accs []pt.AccID
+	for _, acc := range n.cache.Loaded() {
+		if !r.Contains(acc) {
+			continue
+		}
+
+		n.cache.Reset(ctx, acc)
+		accs = append(accs, acc)
+	}
+
+	return accs, nil
+}
+
+// Accept reloads given accounts from BigChain and starts accepting requests for the range
+func (n *Node) Accept(ctx context.Context, r Range, accs []pt.AccID) error {
+	for _, acc := range accs {
+		// drop everything we could have before
+		n.cache.Reset(ctx, acc)
+
+		err := n.cache.Preload(ctx, acc)
+		if err != nil {
+			return errors.Wrapf(err, "preload %v", acc)
+		}
+	}
+
+	n.guard.Unfreeze(r)
+
+	return nil
+}
+
+// Finish allows requests for the range again. Used at old owner after routing table was changed.
+func (n *Node) Finish(r Range) {
+	n.guard.Unfreeze(r)
+}
+
+type HTTPData struct {
+	Range    Range
+	Accounts []pt.AccID
+	Error    string `json:"error,omitempty"`
+}
+
+func Handler(n *Node) http.Handler {
+	e := chi.NewRouter()
+
+	handle := func(path string, f func(req *http.Request, d *HTTPData) error) {
+		e.Post(path, func(w http.ResponseWriter, req *http.Request) {
+			var d HTTPData
+
+			err := render.DecodeJSON(req.Body, &d)
+			if err == nil {
+				err = f(req, &d)
+			}
+			if err != nil {
+				render.JSON(w, req, map[string]string{"error": err.Error()})
+				return
+			}
+
+			render.JSON(w, req, d)
+		})
+	}
+
+	handle("/cfg/handoff/prepare", func(req *http.Request, d *HTTPData) error {
+		return n.Prepare(req.Context(), d.Range)
+	})
+	handle("/cfg/handoff/release", func(req *http.Request, d *HTTPData) (err error) {
+		d.Accounts, err = n.Release(req.Context(), d.Range)
+		return
+	})
+	handle("/cfg/handoff/accept", func(req *http.Request, d *HTTPData) error {
+		return n.Accept(req.Context(), d.Range, d.Accounts)
+	})
+	handle("/cfg/handoff/finish", func(req *http.Request, d *HTTPData) error {
+		n.Finish(d.Range)
+		return nil
+	})
+
+	return e
+}
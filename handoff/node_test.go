@@ -0,0 +1,89 @@
+package handoff
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/pt"
+)
+
+// testCache loads number of incoming txns stored to db if it's set
+type testCache struct {
+	loaded   map[pt.AccID]struct{}
+	err      error
+	db       map[pt.AccID]int
+	incoming map[pt.AccID]int
+}
+
+func (c *testCache) Preload(ctx context.Context, acc pt.AccID) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.loaded[acc] = struct{}{}
+	if c.db != nil {
+		c.incoming[acc] = c.db[acc]
+	}
+	return nil
+}
+
+func (c *testCache) Reset(ctx context.Context, acc pt.AccID) {
+	delete(c.loaded, acc)
+	delete(c.incoming, acc)
+}
+
+func (c *testCache) Loaded() []pt.AccID {
+	var res []pt.AccID
+	for acc := range c.loaded {
+		res = append(res, acc)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+func TestNodeHandoff(t *testing.T) {
+	r := Range{From: 10, To: 20}
+
+	oldCache := &testCache{loaded: map[pt.AccID]struct{}{5: {}, 12: {}, 15: {}, 30: {}}}
+	oldGuard := NewGuard()
+	old := NewNode(oldGuard, oldCache)
+
+	newCache := &testCache{loaded: map[pt.AccID]struct{}{}}
+	newGuard := NewGuard()
+	nw := NewNode(newGuard, newCache)
+
+	err := nw.Prepare(context.TODO(), r)
+	assert.NoError(t, err)
+	assert.False(t, newGuard.Enter(12))
+
+	accs, err := old.Release(context.TODO(), r)
+	assert.NoError(t, err)
+	assert.Equal(t, []pt.AccID{12, 15}, accs)
+	assert.Equal(t, []pt.AccID{5, 30}, oldCache.Loaded())
+	assert.False(t, oldGuard.Enter(12))
+
+	err = nw.Accept(context.TODO(), r, accs)
+	assert.NoError(t, err)
+	assert.Equal(t, []pt.AccID{12, 15}, newCache.Loaded())
+	assert.True(t, newGuard.Enter(12))
+
+	old.Finish(r)
+	assert.True(t, oldGuard.Enter(12))
+}
+
+func TestNodeAcceptError(t *testing.T) {
+	r := Range{From: 10, To: 20}
+
+	c := &testCache{loaded: map[pt.AccID]struct{}{}, err: errors.New("db is down")}
+	g := NewGuard()
+	n := NewNode(g, c)
+
+	assert.NoError(t, n.Prepare(context.TODO(), r))
+
+	err := n.Accept(context.TODO(), r, []pt.AccID{12})
+	assert.EqualError(t, err, "preload 12: db is down")
+	assert.True(t, g.IsFrozen(12))
+}
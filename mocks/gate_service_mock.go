@@ -30,6 +30,17 @@ func (_m *MockProcessorServiceInterface) EXPECT() *_MockProcessorServiceInterfac
 	return _m.recorder
 }
 
+func (_m *MockProcessorServiceInterface) CloseAccount(_param0 context.Context, _param1 *gatepb.CloseAccountRequest) (*gatepb.CloseAccountResponse, error) {
+	ret := _m.ctrl.Call(_m, "CloseAccount", _param0, _param1)
+	ret0, _ := ret[0].(*gatepb.CloseAccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockProcessorServiceInterfaceRecorder) CloseAccount(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CloseAccount", arg0, arg1)
+}
+
 func (_m *MockProcessorServiceInterface) GetBalance(_param0 context.Context, _param1 *gatepb.GetBalanceRequest) (*gatepb.GetBalanceResponse, error) {
 	ret := _m.ctrl.Call(_m, "GetBalance", _param0, _param1)
 	ret0, _ := ret[0].(*gatepb.GetBalanceResponse)
@@ -52,6 +63,17 @@ func (_mr *_MockProcessorServiceInterfaceRecorder) GetLastSettings(arg0, arg1 in
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetLastSettings", arg0, arg1)
 }
 
+func (_m *MockProcessorServiceInterface) GetPendingIncoming(_param0 context.Context, _param1 *gatepb.GetPendingIncomingRequest) (*gatepb.GetPendingIncomingResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetPendingIncoming", _param0, _param1)
+	ret0, _ := ret[0].(*gatepb.GetPendingIncomingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockProcessorServiceInterfaceRecorder) GetPendingIncoming(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetPendingIncoming", arg0, arg1)
+}
+
 func (_m *MockProcessorServiceInterface) GetPrevHash(_param0 context.Context, _param1 *gatepb.GetPrevHashRequest) (*gatepb.GetPrevHashResponse, error) {
 	ret := _m.ctrl.Call(_m, "GetPrevHash", _param0, _param1)
 	ret0, _ := ret[0].(*gatepb.GetPrevHashResponse)
@@ -41,6 +41,28 @@ func (_mr *_MockPlutoDBServiceInterfaceRecorder) Fetch(arg0, arg1 interface{}) *
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Fetch", arg0, arg1)
 }
 
+func (_m *MockPlutoDBServiceInterface) GetBalanceAt(_param0 context.Context, _param1 *plutodbpb.GetBalanceAtRequest) (*plutodbpb.GetBalanceAtResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetBalanceAt", _param0, _param1)
+	ret0, _ := ret[0].(*plutodbpb.GetBalanceAtResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockPlutoDBServiceInterfaceRecorder) GetBalanceAt(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBalanceAt", arg0, arg1)
+}
+
+func (_m *MockPlutoDBServiceInterface) GetData(_param0 context.Context, _param1 *plutodbpb.GetDataRequest) (*plutodbpb.GetDataResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetData", _param0, _param1)
+	ret0, _ := ret[0].(*plutodbpb.GetDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockPlutoDBServiceInterfaceRecorder) GetData(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetData", arg0, arg1)
+}
+
 func (_m *MockPlutoDBServiceInterface) GetHistory(_param0 context.Context, _param1 *plutodbpb.GetHistoryRequest) (*plutodbpb.GetHistoryResponse, error) {
 	ret := _m.ctrl.Call(_m, "GetHistory", _param0, _param1)
 	ret0, _ := ret[0].(*plutodbpb.GetHistoryResponse)
@@ -52,6 +74,50 @@ func (_mr *_MockPlutoDBServiceInterfaceRecorder) GetHistory(arg0, arg1 interface
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetHistory", arg0, arg1)
 }
 
+func (_m *MockPlutoDBServiceInterface) GetPendingIncoming(_param0 context.Context, _param1 *plutodbpb.GetPendingIncomingRequest) (*plutodbpb.GetPendingIncomingResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetPendingIncoming", _param0, _param1)
+	ret0, _ := ret[0].(*plutodbpb.GetPendingIncomingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockPlutoDBServiceInterfaceRecorder) GetPendingIncoming(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetPendingIncoming", arg0, arg1)
+}
+
+func (_m *MockPlutoDBServiceInterface) GetReplicaLag(_param0 context.Context, _param1 *plutodbpb.GetReplicaLagRequest) (*plutodbpb.GetReplicaLagResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetReplicaLag", _param0, _param1)
+	ret0, _ := ret[0].(*plutodbpb.GetReplicaLagResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockPlutoDBServiceInterfaceRecorder) GetReplicaLag(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetReplicaLag", arg0, arg1)
+}
+
+func (_m *MockPlutoDBServiceInterface) GetSettingsHistory(_param0 context.Context, _param1 *plutodbpb.GetSettingsHistoryRequest) (*plutodbpb.GetSettingsHistoryResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetSettingsHistory", _param0, _param1)
+	ret0, _ := ret[0].(*plutodbpb.GetSettingsHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockPlutoDBServiceInterfaceRecorder) GetSettingsHistory(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetSettingsHistory", arg0, arg1)
+}
+
+func (_m *MockPlutoDBServiceInterface) GetStatement(_param0 context.Context, _param1 *plutodbpb.GetStatementRequest) (*plutodbpb.GetStatementResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetStatement", _param0, _param1)
+	ret0, _ := ret[0].(*plutodbpb.GetStatementResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockPlutoDBServiceInterfaceRecorder) GetStatement(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStatement", arg0, arg1)
+}
+
 func (_m *MockPlutoDBServiceInterface) GetTxnMulti(_param0 context.Context, _param1 *plutodbpb.GetTxnMultiRequest) (*plutodbpb.GetTxnMultiResponse, error) {
 	ret := _m.ctrl.Call(_m, "GetTxnMulti", _param0, _param1)
 	ret0, _ := ret[0].(*plutodbpb.GetTxnMultiResponse)
@@ -62,3 +128,25 @@ func (_m *MockPlutoDBServiceInterface) GetTxnMulti(_param0 context.Context, _par
 func (_mr *_MockPlutoDBServiceInterfaceRecorder) GetTxnMulti(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetTxnMulti", arg0, arg1)
 }
+
+func (_m *MockPlutoDBServiceInterface) PutData(_param0 context.Context, _param1 *plutodbpb.PutDataRequest) (*plutodbpb.PutDataResponse, error) {
+	ret := _m.ctrl.Call(_m, "PutData", _param0, _param1)
+	ret0, _ := ret[0].(*plutodbpb.PutDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockPlutoDBServiceInterfaceRecorder) PutData(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "PutData", arg0, arg1)
+}
+
+func (_m *MockPlutoDBServiceInterface) StreamChanges(_param0 context.Context, _param1 *plutodbpb.StreamChangesRequest) (*plutodbpb.StreamChangesResponse, error) {
+	ret := _m.ctrl.Call(_m, "StreamChanges", _param0, _param1)
+	ret0, _ := ret[0].(*plutodbpb.StreamChangesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockPlutoDBServiceInterfaceRecorder) StreamChanges(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StreamChanges", arg0, arg1)
+}
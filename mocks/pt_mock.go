@@ -63,6 +63,28 @@ func (_mr *_MockTransferProcessorRecorder) GetBalance(arg0, arg1 interface{}) *g
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBalance", arg0, arg1)
 }
 
+func (_m *MockTransferProcessor) GetPendingIncoming(ctx context.Context, acc AccID) ([]Txn, error) {
+	ret := _m.ctrl.Call(_m, "GetPendingIncoming", ctx, acc)
+	ret0, _ := ret[0].([]Txn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransferProcessorRecorder) GetPendingIncoming(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetPendingIncoming", arg0, arg1)
+}
+
+func (_m *MockTransferProcessor) Sweep(ctx context.Context, acc AccID) (TransferResult, error) {
+	ret := _m.ctrl.Call(_m, "Sweep", ctx, acc)
+	ret0, _ := ret[0].(TransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransferProcessorRecorder) Sweep(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Sweep", arg0, arg1)
+}
+
 func (_m *MockTransferProcessor) SetPusher(_param0 Pusher) {
 	_m.ctrl.Call(_m, "SetPusher", _param0)
 }
@@ -87,6 +109,14 @@ func (_mr *_MockTransferProcessorRecorder) SetSettingsChain(arg0 interface{}) *g
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetSettingsChain", arg0)
 }
 
+func (_m *MockTransferProcessor) SetRegistry(_param0 Registry) {
+	_m.ctrl.Call(_m, "SetRegistry", _param0)
+}
+
+func (_mr *_MockTransferProcessorRecorder) SetRegistry(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetRegistry", arg0)
+}
+
 // Mock of SettingsChain interface
 type MockSettingsChain struct {
 	ctrl     *gomock.Controller
@@ -176,6 +206,17 @@ func (_mr *_MockSettingsProcessorRecorder) ProcessSettings(arg0, arg1 interface{
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ProcessSettings", arg0, arg1)
 }
 
+func (_m *MockSettingsProcessor) CloseAccount(ctx context.Context, s *Settings) (SettingsResult, error) {
+	ret := _m.ctrl.Call(_m, "CloseAccount", ctx, s)
+	ret0, _ := ret[0].(SettingsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockSettingsProcessorRecorder) CloseAccount(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CloseAccount", arg0, arg1)
+}
+
 func (_m *MockSettingsProcessor) GetLastSettings(ctx context.Context, acc AccID) (*Settings, error) {
 	ret := _m.ctrl.Call(_m, "GetLastSettings", ctx, acc)
 	ret0, _ := ret[0].(*Settings)
@@ -187,6 +228,46 @@ func (_mr *_MockSettingsProcessorRecorder) GetLastSettings(arg0, arg1 interface{
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetLastSettings", arg0, arg1)
 }
 
+// Mock of Registry interface
+type MockRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *_MockRegistryRecorder
+}
+
+// Recorder for MockRegistry (not exported)
+type _MockRegistryRecorder struct {
+	mock *MockRegistry
+}
+
+func NewMockRegistry(ctrl *gomock.Controller) *MockRegistry {
+	mock := &MockRegistry{ctrl: ctrl}
+	mock.recorder = &_MockRegistryRecorder{mock}
+	return mock
+}
+
+func (_m *MockRegistry) EXPECT() *_MockRegistryRecorder {
+	return _m.recorder
+}
+
+func (_m *MockRegistry) IsOpen(ctx context.Context, acc AccID) (bool, error) {
+	ret := _m.ctrl.Call(_m, "IsOpen", ctx, acc)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockRegistryRecorder) IsOpen(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsOpen", arg0, arg1)
+}
+
+func (_m *MockRegistry) Closed(acc AccID) {
+	_m.ctrl.Call(_m, "Closed", acc)
+}
+
+func (_mr *_MockRegistryRecorder) Closed(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Closed", arg0)
+}
+
 // Mock of Chain interface
 type MockChain struct {
 	ctrl     *gomock.Controller
@@ -358,12 +439,24 @@ func (_mr *_MockRouterRecorder) Nodes() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Nodes")
 }
 
-func (_m *MockRouter) SetNodes(nodes []string) {
-	_m.ctrl.Call(_m, "SetNodes", nodes)
+func (_m *MockRouter) Epoch() uint64 {
+	ret := _m.ctrl.Call(_m, "Epoch")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+func (_mr *_MockRouterRecorder) Epoch() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Epoch")
+}
+
+func (_m *MockRouter) SetEpochNodes(epoch uint64, nodes []string) bool {
+	ret := _m.ctrl.Call(_m, "SetEpochNodes", epoch, nodes)
+	ret0, _ := ret[0].(bool)
+	return ret0
 }
 
-func (_mr *_MockRouterRecorder) SetNodes(arg0 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetNodes", arg0)
+func (_mr *_MockRouterRecorder) SetEpochNodes(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetEpochNodes", arg0, arg1)
 }
 
 func (_m *MockRouter) IsSelf(node string) bool {
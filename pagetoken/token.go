@@ -0,0 +1,104 @@
+// Package pagetoken encodes positions in account history pages shared by PlutoDB implementations
+package pagetoken
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const version = 1
+
+const (
+	tokenOut = 1 << iota
+	tokenHasKey
+	tokenFiltered
+	tokenSettings
+)
+
+var ErrInvalid = errors.New("invalid token")
+
+// Token is a position in account history.
+// Unfiltered history walks account chain from Head outgoing txn down to the first one:
+// incoming txns spent by each outgoing txn follow it (unspent ones are the first).
+// Filtered history is ordered by (ProcessedAt desc, Sender, TxnID desc).
+// Settings history goes down from the ID settings.
+type Token struct {
+	Account  uint64
+	Filtered bool
+	Settings bool
+
+	// chain walk
+	Head uint64 // latest outgoing txn id at the first page
+	Out  bool   // next is outgoing txn with id less than ID
+	ID   uint64 // incoming txns spent_by being walked, last outgoing txn id or last settings id
+
+	// last returned txn key, if HasKey
+	HasKey      bool
+	Sender      uint64
+	TxnID       uint64
+	ProcessedAt int64
+}
+
+func (t *Token) String() string {
+	var flags byte
+	if t.Out {
+		flags |= tokenOut
+	}
+	if t.HasKey {
+		flags |= tokenHasKey
+	}
+	if t.Filtered {
+		flags |= tokenFiltered
+	}
+	if t.Settings {
+		flags |= tokenSettings
+	}
+
+	b := make([]byte, 2, 2+6*binary.MaxVarintLen64)
+	b[0] = version
+	b[1] = flags
+
+	var buf [binary.MaxVarintLen64]byte
+	for _, v := range []uint64{t.Account, t.Head, t.ID, t.Sender, t.TxnID} {
+		n := binary.PutUvarint(buf[:], v)
+		b = append(b, buf[:n]...)
+	}
+	n := binary.PutVarint(buf[:], t.ProcessedAt)
+	b = append(b, buf[:n]...)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Parse decodes token made by Token.String
+func Parse(s string) (*Token, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) < 2 || b[0] != version {
+		return nil, ErrInvalid
+	}
+
+	t := &Token{
+		Out:      b[1]&tokenOut != 0,
+		HasKey:   b[1]&tokenHasKey != 0,
+		Filtered: b[1]&tokenFiltered != 0,
+		Settings: b[1]&tokenSettings != 0,
+	}
+
+	b = b[2:]
+	for _, v := range []*uint64{&t.Account, &t.Head, &t.ID, &t.Sender, &t.TxnID} {
+		var n int
+		*v, n = binary.Uvarint(b)
+		if n <= 0 {
+			return nil, ErrInvalid
+		}
+		b = b[n:]
+	}
+	var n int
+	t.ProcessedAt, n = binary.Varint(b)
+	if n <= 0 || n != len(b) {
+		return nil, ErrInvalid
+	}
+
+	return t, nil
+}
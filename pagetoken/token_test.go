@@ -0,0 +1,29 @@
+package pagetoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	for _, tok := range []Token{
+		{Account: 1},
+		{Account: 1, Head: 100, Out: true, ID: 40},
+		{Account: 1<<64 - 1, Head: 100, ID: 40, HasKey: true, Sender: 5, TxnID: 7},
+		{Account: 1, Filtered: true, HasKey: true, Sender: 5, TxnID: 7, ProcessedAt: 1500000000000000000},
+		{Account: 1, Filtered: true, HasKey: true, ProcessedAt: -1},
+		{Account: 1, Settings: true, ID: 3},
+	} {
+		s := tok.String()
+		res, err := Parse(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, &tok, res, s)
+	}
+
+	good := (&Token{Account: 1, Head: 3}).String()
+	for _, s := range []string{"", "!!!", "AQ", good[:len(good)-1], good + "AA", "AgAAAAAAAA"} {
+		_, err := Parse(s)
+		assert.Equal(t, ErrInvalid, err, s)
+	}
+}
@@ -116,3 +116,16 @@ func (p *Preloader) Reset(ctx context.Context, accID pt.AccID) {
 	p.chain.Reset(accID)
 	p.settingsChain.Reset(accID)
 }
+
+// Loaded returns accounts preloaded at the moment
+func (p *Preloader) Loaded() []pt.AccID {
+	defer p.Unlock()
+	p.Lock()
+
+	accs := make([]pt.AccID, 0, len(p.preloaded))
+	for acc := range p.preloaded {
+		accs = append(accs, acc)
+	}
+
+	return accs
+}
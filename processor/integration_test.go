@@ -61,7 +61,7 @@ func TestPusherIntegration(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, pt.TransferResult{
 		TxnID: pt.TxnID{AccID: 10, ID: 3},
-		Hash:  pt.HashFromString("65145111132e2def3f240ebc8007e40b718a4b1087fa87a502605e6eb378a485"),
+		Hash:  pt.HashFromString("afa9ba751584465db599205b302df8e8bcd4f8a0d66a29fc03b2f317e0fcd600"),
 	}, resp)
 }
 
@@ -94,6 +94,6 @@ func TestPreloaderIntegration(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, pt.TransferResult{
 		TxnID: pt.TxnID{AccID: 10, ID: 3},
-		Hash:  pt.HashFromString("65145111132e2def3f240ebc8007e40b718a4b1087fa87a502605e6eb378a485"),
+		Hash:  pt.HashFromString("afa9ba751584465db599205b302df8e8bcd4f8a0d66a29fc03b2f317e0fcd600"),
 	}, resp)
 }
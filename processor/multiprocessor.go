@@ -40,6 +40,12 @@ func (p *Multiprocessor) SetSettingsChain(sc pt.SettingsChain) {
 	}
 }
 
+func (p *Multiprocessor) SetRegistry(r pt.Registry) {
+	for _, s := range p.sub {
+		s.SetRegistry(r)
+	}
+}
+
 func (p *Multiprocessor) ProcessTransfer(ctx context.Context, t pt.Transfer) (pt.TransferResult, error) {
 	sub := p.sub[t.Sender%pt.AccID(len(p.sub))]
 	return sub.ProcessTransfer(ctx, t)
@@ -54,3 +60,13 @@ func (p *Multiprocessor) GetBalance(ctx context.Context, acc pt.AccID) (int64, e
 	sub := p.sub[acc%pt.AccID(len(p.sub))]
 	return sub.GetBalance(ctx, acc)
 }
+
+func (p *Multiprocessor) GetPendingIncoming(ctx context.Context, acc pt.AccID) ([]pt.Txn, error) {
+	sub := p.sub[acc%pt.AccID(len(p.sub))]
+	return sub.GetPendingIncoming(ctx, acc)
+}
+
+func (p *Multiprocessor) Sweep(ctx context.Context, acc pt.AccID) (pt.TransferResult, error) {
+	sub := p.sub[acc%pt.AccID(len(p.sub))]
+	return sub.Sweep(ctx, acc)
+}
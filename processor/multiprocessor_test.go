@@ -45,6 +45,16 @@ func TestMultiCoverSetSettingsChain(t *testing.T) {
 	p.SetSettingsChain(nil)
 }
 
+func TestMultiSweep(t *testing.T) {
+	c := chain.NewChain()
+	p := NewMultiprocessor(c, 3)
+	p.SetSettingsChain(chain.NewSettingsChain())
+	p.SetRegistry(nil)
+
+	_, err := p.Sweep(context.TODO(), pt.AccID(10))
+	assert.Equal(t, ErrAccountNotClosed, err)
+}
+
 func BenchmarkMultiProcessTransfer32Receivers(b *testing.B) {
 	p := NewMultiprocessor(chain.NewChain(), 997)
 	transfer := pt.NewSingleTransfer(0, 20, 1000)
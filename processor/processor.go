@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/sha256"
 	"hash"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -18,10 +20,20 @@ var (
 	ErrNoBalance         = errors.New("processor: no balance")
 	ErrInvalidSettingsID = errors.New("processor: invalid settings id")
 	ErrInvalidSign       = errors.New("processor: invalid sign")
+	ErrAccountClosed     = errors.New("processor: account is closed")
+	ErrAccountNotClosed  = errors.New("processor: account is not closed")
+	ErrInvalidReceiver   = errors.New("processor: receiver is not registered or closed")
 
 	ErrInvalidSettingsPrevHash = errors.New("settings processor: invalid prev hash")
+	ErrSettingsClosed          = errors.New("settings processor: account is closed")
+	ErrCloseBySettings         = errors.New("settings processor: account could be closed by CloseAccount only")
+	ErrUnregister              = errors.New("settings processor: account can't be unregistered")
+	ErrInvalidSweepAccount     = errors.New("settings processor: invalid sweep account")
 )
 
+// timeNow is a default processors clock
+var timeNow = time.Now
+
 // Processor is an transaction processor.
 // It checks if request is correct, creates new transactions, pushes them to Pusher.
 type Processor struct {
@@ -31,12 +43,15 @@ type Processor struct {
 	settingsChain pt.SettingsChain
 	pusher        pt.Pusher
 	preloader     pt.Preloader
+	registry      pt.Registry
+	now           func() time.Time
 }
 
 func NewProcessor(chain pt.Chain) *Processor {
 	return &Processor{
 		hash:  sha256.New(),
 		chain: chain,
+		now:   timeNow,
 	}
 }
 
@@ -48,10 +63,20 @@ func (p *Processor) SetPreloader(pl pt.Preloader) {
 	p.preloader = pl
 }
 
+// SetClock sets time source for txns timestamps. It's time.Now by default.
+func (p *Processor) SetClock(now func() time.Time) {
+	p.now = now
+}
+
 func (p *Processor) SetPusher(pusher pt.Pusher) {
 	p.pusher = pusher
 }
 
+// SetRegistry enables receivers check. Transfers to unregistered or closed accounts are rejected then.
+func (p *Processor) SetRegistry(r pt.Registry) {
+	p.registry = r
+}
+
 func (p *Processor) GetPrevHash(ctx context.Context, acc pt.AccID) (pt.Hash, error) {
 	defer p.mu.Unlock()
 	p.mu.Lock()
@@ -74,6 +99,28 @@ func (p *Processor) GetBalance(ctx context.Context, acc pt.AccID) (int64, error)
 	return p.chain.GetBalance(acc), nil
 }
 
+func (p *Processor) GetPendingIncoming(ctx context.Context, acc pt.AccID) ([]pt.Txn, error) {
+	defer p.mu.Unlock()
+	p.mu.Lock()
+
+	if err := p.preloadAccount(ctx, acc); err != nil {
+		return nil, errors.Wrap(err, "account preloading")
+	}
+
+	txns := p.chain.ListUnspentTxns(acc)
+	sort.Slice(txns, func(i, j int) bool {
+		if txns[i].ProcessedAt != txns[j].ProcessedAt {
+			return txns[i].ProcessedAt < txns[j].ProcessedAt
+		}
+		if txns[i].Sender != txns[j].Sender {
+			return txns[i].Sender < txns[j].Sender
+		}
+		return txns[i].ID < txns[j].ID
+	})
+
+	return txns, nil
+}
+
 func (p *Processor) ProcessTransfer(ctx context.Context, t pt.Transfer) (pt.TransferResult, error) {
 	var res pt.TransferResult
 
@@ -82,6 +129,11 @@ func (p *Processor) ProcessTransfer(ctx context.Context, t pt.Transfer) (pt.Tran
 		return res, ErrNoReceivers
 	}
 
+	// registry could go to another nodes, so check it before lock
+	if err := p.checkReceivers(ctx, t.Batch); err != nil {
+		return res, err
+	}
+
 	defer p.mu.Unlock()
 	p.mu.Lock()
 
@@ -102,7 +154,19 @@ func (p *Processor) ProcessTransfer(ctx context.Context, t pt.Transfer) (pt.Tran
 		res.Hash = last.Hash
 	}
 
-	if last != nil { // idempotence check
+	var sett *pt.Settings
+	if p.settingsChain != nil {
+		sett = p.settingsChain.GetLastSettings(t.Sender)
+	}
+
+	// in server sequencing mode transfer is chained after the last one whatever PrevHash is
+	seq := sett != nil && sett.ServerSequencing
+	if seq {
+		t.PrevHash = pt.ZeroHash
+	}
+
+	// idempotence check. It's impossible to distinguish retry from the new request without PrevHash
+	if last != nil && !seq {
 		if len(t.Batch) == 1 {
 			if t.PrevHash == last.PrevHash &&
 				t.SettingsID == last.SettingsID && t.Batch[0].Receiver == last.Receiver && t.Batch[0].Amount == last.Amount {
@@ -134,8 +198,11 @@ func (p *Processor) ProcessTransfer(ctx context.Context, t pt.Transfer) (pt.Tran
 		}
 	}
 
+	if sett != nil && sett.Closed {
+		return res, ErrAccountClosed
+	}
+
 	if p.settingsChain != nil {
-		sett := p.settingsChain.GetLastSettings(t.Sender)
 		if sett != nil {
 			res.SettingsId = sett.ID
 
@@ -160,11 +227,62 @@ func (p *Processor) ProcessTransfer(ctx context.Context, t pt.Transfer) (pt.Tran
 		}
 	}
 
+	if seq {
+		t.PrevHash = lastHash
+	}
+
 	// check prev txn hash
 	if lastHash != t.PrevHash {
 		return res, ErrInvalidPrevHash
 	}
 
+	return p.commit(ctx, t, last, res)
+}
+
+// Sweep transfers the whole balance of closed account to its SweepTo account.
+// It's authorized by signed closing settings, so transfer has no sign.
+// It does nothing if there is no balance left.
+func (p *Processor) Sweep(ctx context.Context, acc pt.AccID) (pt.TransferResult, error) {
+	var res pt.TransferResult
+
+	defer p.mu.Unlock()
+	p.mu.Lock()
+
+	if err := p.preloadAccount(ctx, acc); err != nil {
+		return res, errors.Wrap(err, "account preloading")
+	}
+
+	var sett *pt.Settings
+	if p.settingsChain != nil {
+		sett = p.settingsChain.GetLastSettings(acc)
+	}
+	if sett == nil || !sett.Closed {
+		return res, ErrAccountNotClosed
+	}
+
+	res.SettingsId = sett.ID
+
+	last := p.chain.GetLastTxn(acc)
+	if last != nil {
+		res.Hash = last.Hash
+	}
+
+	balance := p.chain.GetBalance(acc)
+	if balance <= 0 {
+		return res, nil
+	}
+
+	t := pt.NewSingleTransfer(acc, sett.SweepTo, balance)
+	t.SettingsID = sett.ID
+	if last != nil {
+		t.PrevHash = last.Hash
+	}
+
+	return p.commit(ctx, t, last, res)
+}
+
+// commit creates transactions for checked transfer and pushes them
+func (p *Processor) commit(ctx context.Context, t pt.Transfer, last *pt.Txn, res pt.TransferResult) (pt.TransferResult, error) {
 	// fetch balance
 	balance := p.chain.GetBalance(t.Sender)
 
@@ -197,9 +315,12 @@ func (p *Processor) ProcessTransfer(ctx context.Context, t pt.Transfer) (pt.Tran
 	//}
 
 	var id pt.ID
+	var ts int64
 	if last != nil {
 		id = last.ID
+		ts = last.ProcessedAt
 	}
+	ts = timestamp(p.now(), ts)
 
 	// link output transaction with used input transactions
 	inputsTxns := p.chain.ListUnspentTxns(t.Sender)
@@ -218,6 +339,7 @@ func (p *Processor) ProcessTransfer(ctx context.Context, t pt.Transfer) (pt.Tran
 		}
 
 		txns[i].ID = id
+		txns[i].ProcessedAt = ts + int64(i)
 		txns[i].Hash = pt.GetHash(p.hash, &txns[i])
 	}
 	txns[0].Sign = t.Sign
@@ -246,6 +368,34 @@ func (p *Processor) ProcessTransfer(ctx context.Context, t pt.Transfer) (pt.Tran
 	return res, nil
 }
 
+// timestamp returns processing time in unix nanoseconds.
+// It's always greater than last one even if clock went backwards.
+func timestamp(now time.Time, last int64) int64 {
+	ts := now.UnixNano()
+	if ts <= last {
+		ts = last + 1
+	}
+	return ts
+}
+
+func (p *Processor) checkReceivers(ctx context.Context, batch []*pt.TransferItem) error {
+	if p.registry == nil {
+		return nil
+	}
+
+	for _, r := range batch {
+		ok, err := p.registry.IsOpen(ctx, r.Receiver)
+		if err != nil {
+			return errors.Wrap(err, "registry")
+		}
+		if !ok {
+			return ErrInvalidReceiver
+		}
+	}
+
+	return nil
+}
+
 func (p *Processor) preloadAccount(ctx context.Context, acc pt.AccID) error {
 	if p.preloader == nil {
 		// we can work without preloader
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/mock/gomock"
@@ -16,6 +17,11 @@ import (
 	"github.com/qiwitech/qdp/pusher"
 )
 
+func init() {
+	// fixed clock keeps txns hashes reproducible
+	timeNow = func() time.Time { return time.Unix(1500000000, 0) }
+}
+
 func TestInvalidPrevHash(t *testing.T) {
 	p := NewProcessor(chain.NewChain())
 
@@ -134,7 +140,7 @@ func TestProcessCheckSign(t *testing.T) {
 	hash := pt.GetTransferHashDefault(tr)
 	tr.Sign, err = pt.SignTransfer(hash, prv)
 	assert.NoError(t, err)
-	expect := pt.HashFromString("2d6e6f0639918056d40f97d29678392289bab5d4d0d2f626910f5f21be6a462c")
+	expect := pt.HashFromString("3fce9df56650623626bdcd960568840507e3c0c4ffb289f33bfe14ddea1dc155")
 
 	res, err = p.ProcessTransfer(context.TODO(), tr)
 	assert.NoError(t, err)
@@ -278,6 +284,219 @@ func BenchmarkProcessTransfer32Receivers(b *testing.B) {
 	b.ReportAllocs()
 }
 
+func TestProcessServerSequencing(t *testing.T) {
+	c := chain.NewChain()
+	p := NewProcessor(c)
+	sc := chain.NewSettingsChain()
+	p.SetSettingsChain(sc)
+
+	prv, err := btcec.NewPrivateKey(pt.SigningCurve)
+	assert.NoError(t, err)
+
+	sc.Put(&pt.Settings{
+		ID:               1,
+		Account:          5,
+		PublicKey:        prv.PubKey().SerializeHybrid(),
+		ServerSequencing: true,
+	})
+
+	// sign is made over request with zero prev hash
+	tr := pt.NewSingleTransfer(5, 4, 0)
+	tr.SettingsID = 1
+	tr.Sign, err = pt.SignTransfer(pt.GetTransferHashDefault(tr), prv)
+	assert.NoError(t, err)
+
+	// prev hash is ignored
+	tr.PrevHash = pt.HashFromString("123456")
+
+	res, err := p.ProcessTransfer(context.TODO(), tr)
+	assert.NoError(t, err)
+	assert.Equal(t, pt.NewTxnID(5, 1), res.TxnID)
+
+	// the same request is not deduplicated, it's chained after the previous one
+	res2, err := p.ProcessTransfer(context.TODO(), tr)
+	assert.NoError(t, err)
+	assert.Equal(t, pt.NewTxnID(5, 2), res2.TxnID)
+
+	last := c.GetLastTxn(5)
+	assert.Equal(t, res.Hash, last.PrevHash)
+	assert.Equal(t, res2.Hash, last.Hash)
+
+	// sign made over real prev hash is invalid
+	tr = pt.NewSingleTransfer(5, 4, 0)
+	tr.SettingsID = 1
+	tr.PrevHash = res2.Hash
+	tr.Sign, err = pt.SignTransfer(pt.GetTransferHashDefault(tr), prv)
+	assert.NoError(t, err)
+
+	_, err = p.ProcessTransfer(context.TODO(), tr)
+	assert.Equal(t, ErrInvalidSign, err)
+}
+
+func TestProcessRegisteredReceivers(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	reg := mocks.NewMockRegistry(mock)
+	reg.EXPECT().IsOpen(gomock.Any(), pt.AccID(20)).Return(true, nil).AnyTimes()
+	reg.EXPECT().IsOpen(gomock.Any(), pt.AccID(30)).Return(false, nil).AnyTimes()
+	reg.EXPECT().IsOpen(gomock.Any(), pt.AccID(40)).Return(false, errors.New("db")).AnyTimes()
+
+	p := NewProcessor(chain.NewChain())
+	p.SetRegistry(reg)
+
+	res, err := p.ProcessTransfer(context.TODO(), pt.NewSingleTransfer(0, 20, 1000))
+	assert.NoError(t, err)
+
+	tr := pt.NewSingleTransfer(0, 20, 1000)
+	tr.AddReceiver(30, 10)
+	tr.PrevHash = res.Hash
+	_, err = p.ProcessTransfer(context.TODO(), tr)
+	assert.Equal(t, ErrInvalidReceiver, err)
+
+	tr = pt.NewSingleTransfer(0, 40, 1000)
+	tr.PrevHash = res.Hash
+	_, err = p.ProcessTransfer(context.TODO(), tr)
+	assert.EqualError(t, err, "registry: db")
+}
+
+func TestProcessedAtMonotonic(t *testing.T) {
+	c := chain.NewChain()
+	p := NewProcessor(c)
+
+	now := time.Unix(1500000000, 0)
+	p.SetClock(func() time.Time { return now })
+
+	tr := pt.NewSingleTransfer(0, 10, 1)
+	tr.AddReceiver(20, 1)
+	res, err := p.ProcessTransfer(context.TODO(), tr)
+	assert.NoError(t, err)
+
+	txns := c.GetLastNTxns(0, 2)
+	if assert.Len(t, txns, 2) {
+		assert.Equal(t, now.UnixNano(), txns[1].ProcessedAt)
+		assert.Equal(t, now.UnixNano()+1, txns[0].ProcessedAt)
+	}
+
+	// clock went backwards
+	now = now.Add(-time.Hour)
+
+	tr = pt.NewSingleTransfer(0, 10, 1)
+	tr.PrevHash = res.Hash
+	_, err = p.ProcessTransfer(context.TODO(), tr)
+	assert.NoError(t, err)
+
+	last := c.GetLastTxn(0)
+	assert.Equal(t, time.Unix(1500000000, 2).UnixNano(), last.ProcessedAt)
+	assert.Equal(t, pt.GetHashDefault(last), last.Hash)
+
+	sp := NewSettingsProcessor(chain.NewSettingsChain())
+	sp.SetClock(func() time.Time { return now })
+
+	sres, err := sp.ProcessSettings(context.TODO(), &pt.Settings{Account: 10})
+	assert.NoError(t, err)
+
+	now = now.Add(-time.Hour)
+
+	_, err = sp.ProcessSettings(context.TODO(), &pt.Settings{Account: 10, PrevHash: sres.Hash})
+	assert.NoError(t, err)
+
+	s, err := sp.GetLastSettings(context.TODO(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour).UnixNano()+1, s.ProcessedAt)
+}
+
+func TestAccountClose(t *testing.T) {
+	c := chain.NewChain()
+	sc := chain.NewSettingsChain()
+
+	p := NewProcessor(c)
+	p.SetSettingsChain(sc)
+	p.SetPusher(pusher.NewChainReceiversPusher(c))
+
+	sp := NewSettingsProcessor(sc)
+
+	_, err := p.ProcessTransfer(context.TODO(), pt.NewSingleTransfer(0, 20, 1000))
+	assert.NoError(t, err)
+
+	// not closed
+	_, err = p.Sweep(context.TODO(), 20)
+	assert.Equal(t, ErrAccountNotClosed, err)
+
+	// register
+	sres, err := sp.ProcessSettings(context.TODO(), &pt.Settings{Account: 20, Registered: true})
+	assert.NoError(t, err)
+
+	_, err = sp.ProcessSettings(context.TODO(), &pt.Settings{Account: 20, PrevHash: sres.Hash})
+	assert.Equal(t, ErrUnregister, err)
+
+	_, err = sp.ProcessSettings(context.TODO(), &pt.Settings{Account: 20, PrevHash: sres.Hash, Registered: true, Closed: true})
+	assert.Equal(t, ErrCloseBySettings, err)
+
+	_, err = sp.CloseAccount(context.TODO(), &pt.Settings{Account: 20, SweepTo: 20, PrevHash: sres.Hash})
+	assert.Equal(t, ErrInvalidSweepAccount, err)
+
+	// close
+	cres, err := sp.CloseAccount(context.TODO(), &pt.Settings{Account: 20, SweepTo: 30, PrevHash: sres.Hash})
+	assert.NoError(t, err)
+	assert.Equal(t, pt.NewSettingsID(20, 2), cres.SettingsID)
+
+	last := sc.GetLastSettings(20)
+	assert.True(t, last.Registered)
+	assert.True(t, last.Closed)
+	assert.Equal(t, pt.AccID(30), last.SweepTo)
+
+	// retry
+	res, err := sp.CloseAccount(context.TODO(), &pt.Settings{Account: 20, SweepTo: 30, PrevHash: sres.Hash})
+	assert.NoError(t, err)
+	assert.Equal(t, cres, res)
+
+	_, err = sp.CloseAccount(context.TODO(), &pt.Settings{Account: 20, SweepTo: 40, PrevHash: cres.Hash})
+	assert.Equal(t, ErrSettingsClosed, err)
+
+	_, err = sp.ProcessSettings(context.TODO(), &pt.Settings{Account: 20, PrevHash: cres.Hash, Registered: true})
+	assert.Equal(t, ErrSettingsClosed, err)
+
+	// no activity
+	tr := pt.NewSingleTransfer(20, 40, 1)
+	tr.SettingsID = 2
+	_, err = p.ProcessTransfer(context.TODO(), tr)
+	assert.Equal(t, ErrAccountClosed, err)
+
+	// sweep
+	tres, err := p.Sweep(context.TODO(), 20)
+	assert.NoError(t, err)
+	assert.Equal(t, pt.NewTxnID(20, 1), tres.TxnID)
+	assert.Equal(t, pt.ID(2), tres.SettingsId)
+
+	assert.Equal(t, int64(0), c.GetBalance(20))
+	assert.Equal(t, int64(1000), c.GetBalance(30))
+
+	// nothing left
+	res2, err := p.Sweep(context.TODO(), 20)
+	assert.NoError(t, err)
+	assert.Equal(t, pt.TxnID{}, res2.TxnID)
+	assert.Equal(t, tres.Hash, res2.Hash)
+}
+
+func TestAccountCloseRegistry(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	reg := mocks.NewMockRegistry(mock)
+	reg.EXPECT().IsOpen(gomock.Any(), pt.AccID(30)).Return(true, nil).Times(2)
+	reg.EXPECT().Closed(pt.AccID(20)).Times(1)
+
+	sp := NewSettingsProcessor(chain.NewSettingsChain())
+	sp.SetRegistry(reg)
+
+	_, err := sp.CloseAccount(context.TODO(), &pt.Settings{Account: 20, SweepTo: 30, PrevHash: pt.Hash{1}})
+	assert.Equal(t, ErrInvalidSettingsPrevHash, err)
+
+	_, err = sp.CloseAccount(context.TODO(), &pt.Settings{Account: 20, SweepTo: 30})
+	assert.NoError(t, err)
+}
+
 func TestProcessTransfer(t *testing.T) {
 	p := NewProcessor(chain.NewChain())
 
@@ -285,7 +504,7 @@ func TestProcessTransfer(t *testing.T) {
 	if assert.NoError(t, err) {
 		assert.Equal(t, pt.TransferResult{
 			TxnID: pt.TxnID{AccID: 0, ID: 1},
-			Hash:  pt.HashFromString("2d2be2c4259955feff587e567aee7ae3e99b5022289813cbacbfaaeb48df4d16"),
+			Hash:  pt.HashFromString("d697e88fecad8ae55d2c9d86ebee1b67cce4130b3907dc1ffc65c3bdd0396b46"),
 		}, res)
 	}
 
@@ -296,7 +515,7 @@ func TestProcessTransfer(t *testing.T) {
 	if assert.NoError(t, err) {
 		assert.Equal(t, pt.TransferResult{
 			TxnID: pt.TxnID{AccID: 0, ID: 2},
-			Hash:  pt.HashFromString("660d2d296c5d8b18261a84a2e3b77cb9cf1b3c700733ea3fe7b1931dc61d4ace"),
+			Hash:  pt.HashFromString("42b1e124042fefd5c7be98f0da57daaf72df9a5fa100589870aecba0f174d9b7"),
 		}, res)
 	}
 }
@@ -308,22 +527,54 @@ func TestProcessTransferSetLastHash(t *testing.T) {
 	if assert.NoError(t, err) {
 		assert.Equal(t, pt.TransferResult{
 			TxnID: pt.TxnID{AccID: 0, ID: 1},
-			Hash:  pt.HashFromString("2d2be2c4259955feff587e567aee7ae3e99b5022289813cbacbfaaeb48df4d16"),
+			Hash:  pt.HashFromString("d697e88fecad8ae55d2c9d86ebee1b67cce4130b3907dc1ffc65c3bdd0396b46"),
 		}, res)
 	}
 
 	transfer := pt.NewSingleTransfer(0, 30, 1000)
-	transfer.PrevHash = pt.HashFromString("2d2be2c4259955feff587e567aee7ae3e99b5022289813cbacbfaaeb48df4d16")
+	transfer.PrevHash = pt.HashFromString("d697e88fecad8ae55d2c9d86ebee1b67cce4130b3907dc1ffc65c3bdd0396b46")
 
 	res, err = p.ProcessTransfer(context.TODO(), transfer)
 	if assert.NoError(t, err) {
 		assert.Equal(t, pt.TransferResult{
 			TxnID: pt.TxnID{AccID: 0, ID: 2},
-			Hash:  pt.HashFromString("660d2d296c5d8b18261a84a2e3b77cb9cf1b3c700733ea3fe7b1931dc61d4ace"),
+			Hash:  pt.HashFromString("42b1e124042fefd5c7be98f0da57daaf72df9a5fa100589870aecba0f174d9b7"),
 		}, res)
 	}
 }
 
+func TestGetPendingIncoming(t *testing.T) {
+	c := chain.NewChain()
+	p := NewProcessor(c)
+	p.SetPusher(pusher.NewChainReceiversPusher(c))
+
+	res, err := p.ProcessTransfer(context.TODO(), pt.NewSingleTransfer(0, 20, 1000))
+	assert.NoError(t, err)
+
+	transfer := pt.NewSingleTransfer(0, 20, 500)
+	transfer.PrevHash = res.Hash
+	_, err = p.ProcessTransfer(context.TODO(), transfer)
+	assert.NoError(t, err)
+
+	txns, err := p.GetPendingIncoming(context.TODO(), 20)
+	assert.NoError(t, err)
+	if assert.Len(t, txns, 2) {
+		assert.Equal(t, int64(1000), txns[0].Amount)
+		assert.Equal(t, int64(500), txns[1].Amount)
+	}
+
+	_, err = p.ProcessTransfer(context.TODO(), pt.NewSingleTransfer(20, 30, 100))
+	assert.NoError(t, err)
+
+	txns, err = p.GetPendingIncoming(context.TODO(), 20)
+	assert.NoError(t, err)
+	assert.Len(t, txns, 0)
+
+	txns, err = p.GetPendingIncoming(context.TODO(), 30)
+	assert.NoError(t, err)
+	assert.Len(t, txns, 1)
+}
+
 func TestGetPrevHashPreloaderError(t *testing.T) {
 	mock := gomock.NewController(t)
 	defer mock.Finish()
@@ -472,7 +723,7 @@ func TestProcessSettings(t *testing.T) {
 
 	assert.Equal(t, pt.SettingsResult{
 		SettingsID: pt.NewSettingsID(10, 1),
-		Hash:       pt.HashFromString("74280ac6f6059268431efe2a9b8903957a08f7685e651c6cbbedcd83c101bcc5"),
+		Hash:       pt.HashFromString("9a9c833cb5ac074707b4fde3dcab56cb4e210ab590f85cd3b0fa09981b7489af"),
 	}, res)
 
 	s = &pt.Settings{Account: 10, PrevHash: s.Hash}
@@ -481,7 +732,7 @@ func TestProcessSettings(t *testing.T) {
 
 	assert.Equal(t, pt.SettingsResult{
 		SettingsID: pt.NewSettingsID(10, 2),
-		Hash:       pt.HashFromString("0d4e1907ce02854ea140eaf16f09d97d218f5d79e710867777fb152cfc3f1362"),
+		Hash:       pt.HashFromString("99794f9e103c7ab859b68dd045c012e65fc950c200f1a42df7ba630b6437c974"),
 	}, res)
 }
 
@@ -513,7 +764,7 @@ func TestProcessSettingsSign(t *testing.T) {
 	assert.NoError(t, err)
 	res, err := p.ProcessSettings(context.TODO(), s)
 	assert.NoError(t, err)
-	assert.Equal(t, pt.SettingsResult{SettingsID: pt.NewSettingsID(10, 2), Hash: pt.HashFromString("2b7742f16fc17283936ee227d0808ad3496edcd3a8d51ebb058d0d1fa3145583")}, res)
+	assert.Equal(t, pt.SettingsResult{SettingsID: pt.NewSettingsID(10, 2), Hash: pt.HashFromString("8be938e1379981162d3af855edb5f6896b28044f86168289961fbe3006346d75")}, res)
 
 	// no public key, but signed
 	s = &pt.Settings{ID: 3, Account: 10}
@@ -542,7 +793,7 @@ func TestProcessSettingsWithLast(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, pt.SettingsResult{
 		SettingsID: pt.NewSettingsID(10, 2),
-		Hash:       pt.HashFromString("0d4e1907ce02854ea140eaf16f09d97d218f5d79e710867777fb152cfc3f1362"),
+		Hash:       pt.HashFromString("99794f9e103c7ab859b68dd045c012e65fc950c200f1a42df7ba630b6437c974"),
 	}, res)
 }
 
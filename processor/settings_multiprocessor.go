@@ -27,6 +27,11 @@ func (p *SettingsMultiprocessor) ProcessSettings(ctx context.Context, s *pt.Sett
 	return sub.ProcessSettings(ctx, s)
 }
 
+func (p *SettingsMultiprocessor) CloseAccount(ctx context.Context, s *pt.Settings) (pt.SettingsResult, error) {
+	sub := p.sub[s.Account%pt.AccID(len(p.sub))]
+	return sub.CloseAccount(ctx, s)
+}
+
 func (p *SettingsMultiprocessor) GetLastSettings(ctx context.Context, acc pt.AccID) (*pt.Settings, error) {
 	sub := p.sub[acc%pt.AccID(len(p.sub))]
 	return sub.GetLastSettings(ctx, acc)
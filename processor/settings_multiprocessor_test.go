@@ -19,7 +19,7 @@ func TestMultiProcessSettings(t *testing.T) {
 
 	assert.Equal(t, pt.SettingsResult{
 		SettingsID: pt.NewSettingsID(10, 1),
-		Hash:       pt.HashFromString("74280ac6f6059268431efe2a9b8903957a08f7685e651c6cbbedcd83c101bcc5"),
+		Hash:       pt.HashFromString("9a9c833cb5ac074707b4fde3dcab56cb4e210ab590f85cd3b0fa09981b7489af"),
 	}, res)
 }
 
@@ -31,3 +31,13 @@ func TestMultiGetLastSettings(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, (*pt.Settings)(nil), b)
 }
+
+func TestMultiCloseAccount(t *testing.T) {
+	c := chain.NewSettingsChain()
+	p := NewSettingsMultiprocessor(c, 3)
+
+	res, err := p.CloseAccount(context.TODO(), &pt.Settings{Account: 10, SweepTo: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, pt.NewSettingsID(10, 1), res.SettingsID)
+	assert.True(t, c.GetLastSettings(10).Closed)
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"hash"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -18,12 +19,15 @@ type SettingsProcessor struct {
 	chain     pt.SettingsChain
 	pusher    pt.SettingsPusher
 	preloader pt.Preloader
+	registry  pt.Registry
+	now       func() time.Time
 }
 
 func NewSettingsProcessor(chain pt.SettingsChain) *SettingsProcessor {
 	return &SettingsProcessor{
 		hash:  sha256.New(),
 		chain: chain,
+		now:   timeNow,
 	}
 }
 
@@ -31,13 +35,27 @@ func (p *SettingsProcessor) SetPreloader(pl pt.Preloader) {
 	p.preloader = pl
 }
 
+// SetClock sets time source for settings timestamps. It's time.Now by default.
+func (p *SettingsProcessor) SetClock(now func() time.Time) {
+	p.now = now
+}
+
 func (p *SettingsProcessor) SetPusher(pusher pt.SettingsPusher) {
 	p.pusher = pusher
 }
 
+// SetRegistry enables sweep account check on CloseAccount.
+func (p *SettingsProcessor) SetRegistry(r pt.Registry) {
+	p.registry = r
+}
+
 func (p *SettingsProcessor) ProcessSettings(ctx context.Context, s *pt.Settings) (pt.SettingsResult, error) {
 	var res pt.SettingsResult
 
+	if s.Closed {
+		return res, ErrCloseBySettings
+	}
+
 	defer p.mu.Unlock()
 	p.mu.Lock()
 
@@ -47,6 +65,90 @@ func (p *SettingsProcessor) ProcessSettings(ctx context.Context, s *pt.Settings)
 
 	// fetch last settings
 	last := p.chain.GetLastSettings(s.Account)
+	if last != nil {
+		if last.Closed {
+			return res, ErrSettingsClosed
+		}
+		if last.Registered && !s.Registered {
+			return res, ErrUnregister
+		}
+	}
+
+	return p.commit(ctx, s, last)
+}
+
+// CloseAccount writes terminal settings with Closed flag. Balance must be swept by TransferProcessor then.
+// Retry with the same SweepTo returns the same result.
+func (p *SettingsProcessor) CloseAccount(ctx context.Context, req *pt.Settings) (pt.SettingsResult, error) {
+	var res pt.SettingsResult
+
+	// zero account is an emission account, it can have negative balance
+	if req.Account == 0 || req.SweepTo == req.Account {
+		return res, ErrInvalidSweepAccount
+	}
+
+	// registry could go to another nodes, so check it before lock
+	if p.registry != nil {
+		ok, err := p.registry.IsOpen(ctx, req.SweepTo)
+		if err != nil {
+			return res, errors.Wrap(err, "registry")
+		}
+		if !ok {
+			return res, ErrInvalidSweepAccount
+		}
+	}
+
+	defer p.mu.Unlock()
+	p.mu.Lock()
+
+	if err := p.preloadAccount(ctx, req.Account); err != nil {
+		return res, errors.Wrap(err, "account preloading")
+	}
+
+	last := p.chain.GetLastSettings(req.Account)
+
+	s := &pt.Settings{
+		Account:  req.Account,
+		PrevHash: req.PrevHash,
+		Sign:     req.Sign,
+		Closed:   true,
+		SweepTo:  req.SweepTo,
+	}
+
+	if last != nil {
+		if last.Closed {
+			if last.SweepTo != req.SweepTo {
+				return res, ErrSettingsClosed
+			}
+			res.SettingsID = pt.NewSettingsID(last.Account, last.ID)
+			res.Hash = last.Hash
+			return res, nil
+		}
+
+		s.PublicKey = last.PublicKey
+		s.VerifyTransferSign = last.VerifyTransferSign
+		s.DataHash = last.DataHash
+		s.ServerSequencing = last.ServerSequencing
+		s.Registered = last.Registered
+	}
+
+	res, err := p.commit(ctx, s, last)
+	if err != nil {
+		return res, err
+	}
+
+	// don't wait registry TTL to reject transfers to the account
+	if p.registry != nil {
+		p.registry.Closed(req.Account)
+	}
+
+	return res, nil
+}
+
+// commit checks settings sign and prev hash, assigns id and pushes it
+func (p *SettingsProcessor) commit(ctx context.Context, s, last *pt.Settings) (pt.SettingsResult, error) {
+	var res pt.SettingsResult
+
 	lastHash := pt.Hash{}
 	if last != nil {
 		lastHash = last.Hash
@@ -72,11 +174,14 @@ func (p *SettingsProcessor) ProcessSettings(ctx context.Context, s *pt.Settings)
 
 	// increment last id
 	var id pt.ID
+	var ts int64
 	if last != nil {
 		id = last.ID
+		ts = last.ProcessedAt
 	}
 
 	s.ID = id + 1
+	s.ProcessedAt = timestamp(p.now(), ts)
 
 	// TODO(outself): rename
 	pt.GetSettingsHash(p.hash, s)
@@ -30,6 +30,13 @@ func AddAPIServiceHandlers(mux graceful.Handlerer, srv APIServiceInterface, c gr
 			return srv.ProcessTransfer(ctx, args.(*TransferRequest))
 		}))
 
+	mux.Handle("/BulkProcessTransfer", graceful.NewHandler(
+		c,
+		func() interface{} { return &BulkTransferRequest{} },
+		func(ctx context.Context, args interface{}) (interface{}, error) {
+			return srv.BulkProcessTransfer(ctx, args.(*BulkTransferRequest))
+		}))
+
 	mux.Handle("/GetPrevHash", graceful.NewHandler(
 		c,
 		func() interface{} { return &GetPrevHashRequest{} },
@@ -58,6 +65,27 @@ func AddAPIServiceHandlers(mux graceful.Handlerer, srv APIServiceInterface, c gr
 			return srv.GetLastSettings(ctx, args.(*GetLastSettingsRequest))
 		}))
 
+	mux.Handle("/CloseAccount", graceful.NewHandler(
+		c,
+		func() interface{} { return &CloseAccountRequest{} },
+		func(ctx context.Context, args interface{}) (interface{}, error) {
+			return srv.CloseAccount(ctx, args.(*CloseAccountRequest))
+		}))
+
+	mux.Handle("/RegisterAlias", graceful.NewHandler(
+		c,
+		func() interface{} { return &RegisterAliasRequest{} },
+		func(ctx context.Context, args interface{}) (interface{}, error) {
+			return srv.RegisterAlias(ctx, args.(*RegisterAliasRequest))
+		}))
+
+	mux.Handle("/ResolveAlias", graceful.NewHandler(
+		c,
+		func() interface{} { return &ResolveAliasRequest{} },
+		func(ctx context.Context, args interface{}) (interface{}, error) {
+			return srv.ResolveAlias(ctx, args.(*ResolveAliasRequest))
+		}))
+
 	mux.Handle("/GetHistory", graceful.NewHandler(
 		c,
 		func() interface{} { return &GetHistoryRequest{} },
@@ -65,6 +93,41 @@ func AddAPIServiceHandlers(mux graceful.Handlerer, srv APIServiceInterface, c gr
 			return srv.GetHistory(ctx, args.(*GetHistoryRequest))
 		}))
 
+	mux.Handle("/GetStatement", graceful.NewHandler(
+		c,
+		func() interface{} { return &GetStatementRequest{} },
+		func(ctx context.Context, args interface{}) (interface{}, error) {
+			return srv.GetStatement(ctx, args.(*GetStatementRequest))
+		}))
+
+	mux.Handle("/GetBalanceAt", graceful.NewHandler(
+		c,
+		func() interface{} { return &GetBalanceAtRequest{} },
+		func(ctx context.Context, args interface{}) (interface{}, error) {
+			return srv.GetBalanceAt(ctx, args.(*GetBalanceAtRequest))
+		}))
+
+	mux.Handle("/GetPendingIncoming", graceful.NewHandler(
+		c,
+		func() interface{} { return &GetPendingIncomingRequest{} },
+		func(ctx context.Context, args interface{}) (interface{}, error) {
+			return srv.GetPendingIncoming(ctx, args.(*GetPendingIncomingRequest))
+		}))
+
+	mux.Handle("/GetSettingsHistory", graceful.NewHandler(
+		c,
+		func() interface{} { return &GetSettingsHistoryRequest{} },
+		func(ctx context.Context, args interface{}) (interface{}, error) {
+			return srv.GetSettingsHistory(ctx, args.(*GetSettingsHistoryRequest))
+		}))
+
+	mux.Handle("/StreamChanges", graceful.NewHandler(
+		c,
+		func() interface{} { return &StreamChangesRequest{} },
+		func(ctx context.Context, args interface{}) (interface{}, error) {
+			return srv.StreamChanges(ctx, args.(*StreamChangesRequest))
+		}))
+
 	mux.Handle("/GetByMetaKey", graceful.NewHandler(
 		c,
 		func() interface{} { return &GetByMetaKeyRequest{} },
@@ -105,6 +168,12 @@ func (cl APIServiceHTTPClient) ProcessTransfer(ctx context.Context, args *Transf
 	return &resp, err
 }
 
+func (cl APIServiceHTTPClient) BulkProcessTransfer(ctx context.Context, args *BulkTransferRequest) (*BulkTransferResponse, error) {
+	var resp BulkTransferResponse
+	err := cl.Client.Call(ctx, "BulkProcessTransfer", args, &resp)
+	return &resp, err
+}
+
 func (cl APIServiceHTTPClient) GetPrevHash(ctx context.Context, args *GetPrevHashRequest) (*GetPrevHashResponse, error) {
 	var resp GetPrevHashResponse
 	err := cl.Client.Call(ctx, "GetPrevHash", args, &resp)
@@ -129,12 +198,60 @@ func (cl APIServiceHTTPClient) GetLastSettings(ctx context.Context, args *GetLas
 	return &resp, err
 }
 
+func (cl APIServiceHTTPClient) CloseAccount(ctx context.Context, args *CloseAccountRequest) (*CloseAccountResponse, error) {
+	var resp CloseAccountResponse
+	err := cl.Client.Call(ctx, "CloseAccount", args, &resp)
+	return &resp, err
+}
+
+func (cl APIServiceHTTPClient) RegisterAlias(ctx context.Context, args *RegisterAliasRequest) (*RegisterAliasResponse, error) {
+	var resp RegisterAliasResponse
+	err := cl.Client.Call(ctx, "RegisterAlias", args, &resp)
+	return &resp, err
+}
+
+func (cl APIServiceHTTPClient) ResolveAlias(ctx context.Context, args *ResolveAliasRequest) (*ResolveAliasResponse, error) {
+	var resp ResolveAliasResponse
+	err := cl.Client.Call(ctx, "ResolveAlias", args, &resp)
+	return &resp, err
+}
+
 func (cl APIServiceHTTPClient) GetHistory(ctx context.Context, args *GetHistoryRequest) (*GetHistoryResponse, error) {
 	var resp GetHistoryResponse
 	err := cl.Client.Call(ctx, "GetHistory", args, &resp)
 	return &resp, err
 }
 
+func (cl APIServiceHTTPClient) GetStatement(ctx context.Context, args *GetStatementRequest) (*GetStatementResponse, error) {
+	var resp GetStatementResponse
+	err := cl.Client.Call(ctx, "GetStatement", args, &resp)
+	return &resp, err
+}
+
+func (cl APIServiceHTTPClient) GetBalanceAt(ctx context.Context, args *GetBalanceAtRequest) (*GetBalanceAtResponse, error) {
+	var resp GetBalanceAtResponse
+	err := cl.Client.Call(ctx, "GetBalanceAt", args, &resp)
+	return &resp, err
+}
+
+func (cl APIServiceHTTPClient) GetPendingIncoming(ctx context.Context, args *GetPendingIncomingRequest) (*GetPendingIncomingResponse, error) {
+	var resp GetPendingIncomingResponse
+	err := cl.Client.Call(ctx, "GetPendingIncoming", args, &resp)
+	return &resp, err
+}
+
+func (cl APIServiceHTTPClient) GetSettingsHistory(ctx context.Context, args *GetSettingsHistoryRequest) (*GetSettingsHistoryResponse, error) {
+	var resp GetSettingsHistoryResponse
+	err := cl.Client.Call(ctx, "GetSettingsHistory", args, &resp)
+	return &resp, err
+}
+
+func (cl APIServiceHTTPClient) StreamChanges(ctx context.Context, args *StreamChangesRequest) (*StreamChangesResponse, error) {
+	var resp StreamChangesResponse
+	err := cl.Client.Call(ctx, "StreamChanges", args, &resp)
+	return &resp, err
+}
+
 func (cl APIServiceHTTPClient) GetByMetaKey(ctx context.Context, args *GetByMetaKeyRequest) (*GetByMetaKeyResponse, error) {
 	var resp GetByMetaKeyResponse
 	err := cl.Client.Call(ctx, "GetByMetaKey", args, &resp)
@@ -156,6 +273,8 @@ func (cl APIServiceHTTPClient) PutMeta(ctx context.Context, args *PutMetaRequest
 type APIServiceInterface interface {
 	ProcessTransfer(context.Context, *TransferRequest) (*TransferResponse, error)
 
+	BulkProcessTransfer(context.Context, *BulkTransferRequest) (*BulkTransferResponse, error)
+
 	GetPrevHash(context.Context, *GetPrevHashRequest) (*GetPrevHashResponse, error)
 
 	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
@@ -164,8 +283,24 @@ type APIServiceInterface interface {
 
 	GetLastSettings(context.Context, *GetLastSettingsRequest) (*GetLastSettingsResponse, error)
 
+	CloseAccount(context.Context, *CloseAccountRequest) (*CloseAccountResponse, error)
+
+	RegisterAlias(context.Context, *RegisterAliasRequest) (*RegisterAliasResponse, error)
+
+	ResolveAlias(context.Context, *ResolveAliasRequest) (*ResolveAliasResponse, error)
+
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 
+	GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error)
+
+	GetBalanceAt(context.Context, *GetBalanceAtRequest) (*GetBalanceAtResponse, error)
+
+	GetPendingIncoming(context.Context, *GetPendingIncomingRequest) (*GetPendingIncomingResponse, error)
+
+	GetSettingsHistory(context.Context, *GetSettingsHistoryRequest) (*GetSettingsHistoryResponse, error)
+
+	StreamChanges(context.Context, *StreamChangesRequest) (*StreamChangesResponse, error)
+
 	GetByMetaKey(context.Context, *GetByMetaKeyRequest) (*GetByMetaKeyResponse, error)
 
 	SearchMeta(context.Context, *SearchMetaRequest) (*SearchMetaResponse, error)
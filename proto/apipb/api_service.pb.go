@@ -34,6 +34,26 @@ It has these top-level messages:
 	SearchMetaResponse
 	PutMetaRequest
 	PutMetaResponse
+	BulkTransferRequest
+	BulkTransferResponse
+	CloseAccountRequest
+	CloseAccountResponse
+	RegisterAliasRequest
+	RegisterAliasResponse
+	ResolveAliasRequest
+	ResolveAliasResponse
+	GetStatementRequest
+	GetStatementResponse
+	GetBalanceAtRequest
+	GetBalanceAtResponse
+	GetPendingIncomingRequest
+	GetPendingIncomingResponse
+	GetSettingsHistoryRequest
+	SettingsHistoryEntry
+	GetSettingsHistoryResponse
+	StreamChangesRequest
+	Change
+	StreamChangesResponse
 */
 package apipb
 
@@ -67,17 +87,25 @@ const (
 	TransferCode_INTERNAL_ERROR    TransferCode = 5
 	TransferCode_RETRY             TransferCode = 7
 	TransferCode_METADATA_ERROR    TransferCode = 8
+	TransferCode_ACCOUNT_CLOSED    TransferCode = 9
+	TransferCode_INVALID_RECEIVER  TransferCode = 10
+	TransferCode_ALIAS_TAKEN       TransferCode = 11
+	TransferCode_ALIAS_NOT_FOUND   TransferCode = 12
 )
 
 var TransferCode_name = map[int32]string{
-	0: "OK",
-	1: "INVALID_PREV_HASH",
-	2: "INVALID_SIGN",
-	3: "BAD_REQUEST",
-	4: "NO_BALANCE",
-	5: "INTERNAL_ERROR",
-	7: "RETRY",
-	8: "METADATA_ERROR",
+	0:  "OK",
+	1:  "INVALID_PREV_HASH",
+	2:  "INVALID_SIGN",
+	3:  "BAD_REQUEST",
+	4:  "NO_BALANCE",
+	5:  "INTERNAL_ERROR",
+	7:  "RETRY",
+	8:  "METADATA_ERROR",
+	9:  "ACCOUNT_CLOSED",
+	10: "INVALID_RECEIVER",
+	11: "ALIAS_TAKEN",
+	12: "ALIAS_NOT_FOUND",
 }
 var TransferCode_value = map[string]int32{
 	"OK":                0,
@@ -88,6 +116,10 @@ var TransferCode_value = map[string]int32{
 	"INTERNAL_ERROR":    5,
 	"RETRY":             7,
 	"METADATA_ERROR":    8,
+	"ACCOUNT_CLOSED":    9,
+	"INVALID_RECEIVER":  10,
+	"ALIAS_TAKEN":       11,
+	"ALIAS_NOT_FOUND":   12,
 }
 
 func (x TransferCode) String() string {
@@ -95,6 +127,31 @@ func (x TransferCode) String() string {
 }
 func (TransferCode) EnumDescriptor() ([]byte, []int) { return fileDescriptorApiService, []int{0} }
 
+// History transactions direction relative to requested account
+type HistoryDirection int32
+
+const (
+	HistoryDirection_ALL      HistoryDirection = 0
+	HistoryDirection_INCOMING HistoryDirection = 1
+	HistoryDirection_OUTGOING HistoryDirection = 2
+)
+
+var HistoryDirection_name = map[int32]string{
+	0: "ALL",
+	1: "INCOMING",
+	2: "OUTGOING",
+}
+var HistoryDirection_value = map[string]int32{
+	"ALL":      0,
+	"INCOMING": 1,
+	"OUTGOING": 2,
+}
+
+func (x HistoryDirection) String() string {
+	return proto.EnumName(HistoryDirection_name, int32(x))
+}
+func (HistoryDirection) EnumDescriptor() ([]byte, []int) { return fileDescriptorApiService, []int{1} }
+
 // Status field.
 // It's a part of every response.
 type Status struct {
@@ -140,6 +197,8 @@ type TransferItem struct {
 	Receiver uint64 `protobuf:"varint,1,opt,name=receiver,proto3" json:"receiver,omitempty"`
 	// Amount to send to that Receiver
 	Amount int64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Receiver alias. Resolved by plutoapi if receiver is not set
+	Alias string `protobuf:"bytes,3,opt,name=alias,proto3" json:"alias,omitempty"`
 }
 
 func (m *TransferItem) Reset()                    { *m = TransferItem{} }
@@ -161,6 +220,13 @@ func (m *TransferItem) GetAmount() int64 {
 	return 0
 }
 
+func (m *TransferItem) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
 // Request to transfer value to one or more receivers
 type TransferRequest struct {
 	// Value Sender
@@ -373,6 +439,14 @@ type SettingsRequest struct {
 	Sign string `protobuf:"bytes,5,opt,name=sign,proto3" json:"sign,omitempty"`
 	// Enables sign checking for following requests
 	VerifyTransferSign bool `protobuf:"varint,6,opt,name=verify_transfer_sign,json=verifyTransferSign,proto3" json:"verify_transfer_sign,omitempty"`
+	// Enables server sequencing for following transfers: they are chained in order they come
+	// and prev_hash is ignored. Transfer sign is made with empty prev_hash then,
+	// so signed request could be replayed. Deduplicate transfers by metadata keys
+	ServerSequencing bool `protobuf:"varint,7,opt,name=server_sequencing,json=serverSequencing,proto3" json:"server_sequencing,omitempty"`
+	// Account is explicitly registered. Once set it can't be reset
+	Registered bool `protobuf:"varint,8,opt,name=registered,proto3" json:"registered,omitempty"`
+	// User data which data_hash is made of. Stored by the database once settings are accepted, optional. If storing fails, the same request could be retried to store it
+	Data []byte `protobuf:"bytes,9,opt,name=data,proto3" json:"data,omitempty"`
 }
 
 func (m *SettingsRequest) Reset()                    { *m = SettingsRequest{} }
@@ -422,6 +496,27 @@ func (m *SettingsRequest) GetVerifyTransferSign() bool {
 	return false
 }
 
+func (m *SettingsRequest) GetServerSequencing() bool {
+	if m != nil {
+		return m.ServerSequencing
+	}
+	return false
+}
+
+func (m *SettingsRequest) GetRegistered() bool {
+	if m != nil {
+		return m.Registered
+	}
+	return false
+}
+
+func (m *SettingsRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 // Response on SettingsRequest
 type SettingsResponse struct {
 	// Operation Status
@@ -462,6 +557,8 @@ func (m *SettingsResponse) GetHash() string {
 type GetLastSettingsRequest struct {
 	// Account ID
 	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Load user data stored with settings
+	WithData bool `protobuf:"varint,2,opt,name=with_data,json=withData,proto3" json:"with_data,omitempty"`
 }
 
 func (m *GetLastSettingsRequest) Reset()         { *m = GetLastSettingsRequest{} }
@@ -478,6 +575,13 @@ func (m *GetLastSettingsRequest) GetAccount() uint64 {
 	return 0
 }
 
+func (m *GetLastSettingsRequest) GetWithData() bool {
+	if m != nil {
+		return m.WithData
+	}
+	return false
+}
+
 // Response on GetLastSettingsRequest
 type GetLastSettingsResponse struct {
 	// Operation Status
@@ -499,6 +603,16 @@ type GetLastSettingsResponse struct {
 	Sign string `protobuf:"bytes,10,opt,name=sign,proto3" json:"sign,omitempty"`
 	// True if sign checking for requests is enabled
 	VerifyTransferSign bool `protobuf:"varint,11,opt,name=verify_transfer_sign,json=verifyTransferSign,proto3" json:"verify_transfer_sign,omitempty"`
+	// True if server sequencing is enabled
+	ServerSequencing bool `protobuf:"varint,12,opt,name=server_sequencing,json=serverSequencing,proto3" json:"server_sequencing,omitempty"`
+	// Account is explicitly registered
+	Registered bool `protobuf:"varint,13,opt,name=registered,proto3" json:"registered,omitempty"`
+	// Account is closed, no more activity allowed
+	Closed bool `protobuf:"varint,14,opt,name=closed,proto3" json:"closed,omitempty"`
+	// Account balance was swept to on close
+	SweepTo uint64 `protobuf:"varint,15,opt,name=sweep_to,json=sweepTo,proto3" json:"sweep_to,omitempty"`
+	// User data if requested and stored
+	Data []byte `protobuf:"bytes,16,opt,name=data,proto3" json:"data,omitempty"`
 }
 
 func (m *GetLastSettingsResponse) Reset()         { *m = GetLastSettingsResponse{} }
@@ -571,6 +685,41 @@ func (m *GetLastSettingsResponse) GetVerifyTransferSign() bool {
 	return false
 }
 
+func (m *GetLastSettingsResponse) GetServerSequencing() bool {
+	if m != nil {
+		return m.ServerSequencing
+	}
+	return false
+}
+
+func (m *GetLastSettingsResponse) GetRegistered() bool {
+	if m != nil {
+		return m.Registered
+	}
+	return false
+}
+
+func (m *GetLastSettingsResponse) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+func (m *GetLastSettingsResponse) GetSweepTo() uint64 {
+	if m != nil {
+		return m.SweepTo
+	}
+	return 0
+}
+
+func (m *GetLastSettingsResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 // Request for account transactions History
 type GetHistoryRequest struct {
 	// Account ID
@@ -579,6 +728,18 @@ type GetHistoryRequest struct {
 	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	// Next page token
 	Token string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	// Only transactions processed at or after that time (RFC3339)
+	FromTime string `protobuf:"bytes,5,opt,name=from_time,json=fromTime,proto3" json:"from_time,omitempty"`
+	// Only transactions processed before that time (RFC3339)
+	ToTime string `protobuf:"bytes,6,opt,name=to_time,json=toTime,proto3" json:"to_time,omitempty"`
+	// Only incoming or outgoing transactions
+	Direction HistoryDirection `protobuf:"varint,7,opt,name=direction,proto3,enum=api.HistoryDirection" json:"direction,omitempty"`
+	// Only transactions with that account on the other side
+	Counterparty uint64 `protobuf:"varint,8,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	// Min transaction amount, inclusive
+	MinAmount int64 `protobuf:"varint,9,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	// Max transaction amount, inclusive. Zero means no limit
+	MaxAmount int64 `protobuf:"varint,10,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
 }
 
 func (m *GetHistoryRequest) Reset()                    { *m = GetHistoryRequest{} }
@@ -607,6 +768,48 @@ func (m *GetHistoryRequest) GetToken() string {
 	return ""
 }
 
+func (m *GetHistoryRequest) GetFromTime() string {
+	if m != nil {
+		return m.FromTime
+	}
+	return ""
+}
+
+func (m *GetHistoryRequest) GetToTime() string {
+	if m != nil {
+		return m.ToTime
+	}
+	return ""
+}
+
+func (m *GetHistoryRequest) GetDirection() HistoryDirection {
+	if m != nil {
+		return m.Direction
+	}
+	return HistoryDirection_ALL
+}
+
+func (m *GetHistoryRequest) GetCounterparty() uint64 {
+	if m != nil {
+		return m.Counterparty
+	}
+	return 0
+}
+
+func (m *GetHistoryRequest) GetMinAmount() int64 {
+	if m != nil {
+		return m.MinAmount
+	}
+	return 0
+}
+
+func (m *GetHistoryRequest) GetMaxAmount() int64 {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return 0
+}
+
 // Response of GetHistoryRequest
 type GetHistoryResponse struct {
 	// Operation Status
@@ -668,6 +871,8 @@ type Txn struct {
 	Hash string `protobuf:"bytes,21,opt,name=hash,proto3" json:"hash,omitempty"`
 	// Metadata attached
 	Meta *Meta `protobuf:"bytes,14,opt,name=meta" json:"meta,omitempty"`
+	// Processing time in RFC3339 format
+	ProcessedAt string `protobuf:"bytes,15,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
 }
 
 func (m *Txn) Reset()                    { *m = Txn{} }
@@ -752,6 +957,13 @@ func (m *Txn) GetMeta() *Meta {
 	return nil
 }
 
+func (m *Txn) GetProcessedAt() string {
+	if m != nil {
+		return m.ProcessedAt
+	}
+	return ""
+}
+
 // Metadata that could be attached to transactions
 type Meta struct {
 	// Unique key
@@ -942,6 +1154,833 @@ func (m *PutMetaResponse) GetStatus() *Status {
 	return nil
 }
 
+// Request to process many independent transfers at once
+type BulkTransferRequest struct {
+	// Transfers to process. They are not atomic with each other,
+	// transfers of the same sender are processed in the given order.
+	// prev_hash of a transfer could be omitted if the previous transfer of the same sender
+	// is in the same request, it's taken from the previous result then
+	Transfers []*TransferRequest `protobuf:"bytes,1,rep,name=transfers" json:"transfers,omitempty"`
+}
+
+func (m *BulkTransferRequest) Reset()                    { *m = BulkTransferRequest{} }
+func (m *BulkTransferRequest) String() string            { return proto.CompactTextString(m) }
+func (*BulkTransferRequest) ProtoMessage()               {}
+func (*BulkTransferRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{22} }
+
+func (m *BulkTransferRequest) GetTransfers() []*TransferRequest {
+	if m != nil {
+		return m.Transfers
+	}
+	return nil
+}
+
+// Response on BulkTransferRequest
+type BulkTransferResponse struct {
+	// Operation Status. It's OK even if some transfers failed
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// Per transfer results in the same order as transfers in request
+	Results []*TransferResponse `protobuf:"bytes,2,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *BulkTransferResponse) Reset()                    { *m = BulkTransferResponse{} }
+func (m *BulkTransferResponse) String() string            { return proto.CompactTextString(m) }
+func (*BulkTransferResponse) ProtoMessage()               {}
+func (*BulkTransferResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{23} }
+
+func (m *BulkTransferResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *BulkTransferResponse) GetResults() []*TransferResponse {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// Request to close account. Remaining balance is swept to sweep_to account
+// Sign is made over settings request hash with closed flag and sweep_to
+type CloseAccountRequest struct {
+	// Account to close
+	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Account to sweep remaining balance to
+	SweepTo uint64 `protobuf:"varint,2,opt,name=sweep_to,json=sweepTo,proto3" json:"sweep_to,omitempty"`
+	// Last account Settings Hash
+	PrevHash string `protobuf:"bytes,3,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	// Request Sign
+	Sign string `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *CloseAccountRequest) Reset()                    { *m = CloseAccountRequest{} }
+func (m *CloseAccountRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloseAccountRequest) ProtoMessage()               {}
+func (*CloseAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{24} }
+
+func (m *CloseAccountRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *CloseAccountRequest) GetSweepTo() uint64 {
+	if m != nil {
+		return m.SweepTo
+	}
+	return 0
+}
+
+func (m *CloseAccountRequest) GetPrevHash() string {
+	if m != nil {
+		return m.PrevHash
+	}
+	return ""
+}
+
+func (m *CloseAccountRequest) GetSign() string {
+	if m != nil {
+		return m.Sign
+	}
+	return ""
+}
+
+// Response on CloseAccountRequest
+type CloseAccountResponse struct {
+	// Operation Status
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// Closing Settings ID
+	SettingsId string `protobuf:"bytes,2,opt,name=settings_id,json=settingsId,proto3" json:"settings_id,omitempty"`
+	// Closing Settings Hash
+	Hash string `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Sweep transaction ID. Empty if there was no balance
+	TxnId string `protobuf:"bytes,4,opt,name=txn_id,json=txnId,proto3" json:"txn_id,omitempty"`
+	// Last transaction Hash
+	TxnHash string `protobuf:"bytes,5,opt,name=txn_hash,json=txnHash,proto3" json:"txn_hash,omitempty"`
+}
+
+func (m *CloseAccountResponse) Reset()                    { *m = CloseAccountResponse{} }
+func (m *CloseAccountResponse) String() string            { return proto.CompactTextString(m) }
+func (*CloseAccountResponse) ProtoMessage()               {}
+func (*CloseAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{25} }
+
+func (m *CloseAccountResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *CloseAccountResponse) GetSettingsId() string {
+	if m != nil {
+		return m.SettingsId
+	}
+	return ""
+}
+
+func (m *CloseAccountResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *CloseAccountResponse) GetTxnId() string {
+	if m != nil {
+		return m.TxnId
+	}
+	return ""
+}
+
+func (m *CloseAccountResponse) GetTxnHash() string {
+	if m != nil {
+		return m.TxnHash
+	}
+	return ""
+}
+
+// Request to bind alias to account
+// Sign is made over alias hash with the account signing key
+type RegisterAliasRequest struct {
+	// Alias: phone, email, nickname and so on
+	Alias string `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	// Account to bind alias to
+	Account uint64 `protobuf:"varint,2,opt,name=account,proto3" json:"account,omitempty"`
+	// Request Sign made over the normalized (trimmed and lower cased) alias
+	Sign string `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *RegisterAliasRequest) Reset()                    { *m = RegisterAliasRequest{} }
+func (m *RegisterAliasRequest) String() string            { return proto.CompactTextString(m) }
+func (*RegisterAliasRequest) ProtoMessage()               {}
+func (*RegisterAliasRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{26} }
+
+func (m *RegisterAliasRequest) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *RegisterAliasRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *RegisterAliasRequest) GetSign() string {
+	if m != nil {
+		return m.Sign
+	}
+	return ""
+}
+
+// Response on RegisterAliasRequest
+type RegisterAliasResponse struct {
+	// Operation Status
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *RegisterAliasResponse) Reset()         { *m = RegisterAliasResponse{} }
+func (m *RegisterAliasResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterAliasResponse) ProtoMessage()    {}
+func (*RegisterAliasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiService, []int{27}
+}
+
+func (m *RegisterAliasResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+// Request to resolve alias to account
+type ResolveAliasRequest struct {
+	// Alias to resolve
+	Alias string `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+}
+
+func (m *ResolveAliasRequest) Reset()                    { *m = ResolveAliasRequest{} }
+func (m *ResolveAliasRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResolveAliasRequest) ProtoMessage()               {}
+func (*ResolveAliasRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{28} }
+
+func (m *ResolveAliasRequest) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+// Response on ResolveAliasRequest
+type ResolveAliasResponse struct {
+	// Operation Status
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// Account alias is bound to
+	Account uint64 `protobuf:"varint,2,opt,name=account,proto3" json:"account,omitempty"`
+}
+
+func (m *ResolveAliasResponse) Reset()                    { *m = ResolveAliasResponse{} }
+func (m *ResolveAliasResponse) String() string            { return proto.CompactTextString(m) }
+func (*ResolveAliasResponse) ProtoMessage()               {}
+func (*ResolveAliasResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{29} }
+
+func (m *ResolveAliasResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ResolveAliasResponse) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+// Request for account statement for the period
+type GetStatementRequest struct {
+	// Account ID
+	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Period start (RFC3339), inclusive. Empty means from the beginning
+	FromTime string `protobuf:"bytes,2,opt,name=from_time,json=fromTime,proto3" json:"from_time,omitempty"`
+	// Period end (RFC3339), exclusive. Empty means till now
+	ToTime string `protobuf:"bytes,3,opt,name=to_time,json=toTime,proto3" json:"to_time,omitempty"`
+	// Don't return period transactions
+	TotalsOnly bool `protobuf:"varint,4,opt,name=totals_only,json=totalsOnly,proto3" json:"totals_only,omitempty"`
+}
+
+func (m *GetStatementRequest) Reset()                    { *m = GetStatementRequest{} }
+func (m *GetStatementRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetStatementRequest) ProtoMessage()               {}
+func (*GetStatementRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{30} }
+
+func (m *GetStatementRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *GetStatementRequest) GetFromTime() string {
+	if m != nil {
+		return m.FromTime
+	}
+	return ""
+}
+
+func (m *GetStatementRequest) GetToTime() string {
+	if m != nil {
+		return m.ToTime
+	}
+	return ""
+}
+
+func (m *GetStatementRequest) GetTotalsOnly() bool {
+	if m != nil {
+		return m.TotalsOnly
+	}
+	return false
+}
+
+// Response of GetStatementRequest
+type GetStatementResponse struct {
+	// Operation Status
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// Balance at the period start
+	OpeningBalance int64 `protobuf:"varint,2,opt,name=opening_balance,json=openingBalance,proto3" json:"opening_balance,omitempty"`
+	// Balance at the period end
+	ClosingBalance int64 `protobuf:"varint,3,opt,name=closing_balance,json=closingBalance,proto3" json:"closing_balance,omitempty"`
+	// Total amount sent during the period
+	Debits int64 `protobuf:"varint,4,opt,name=debits,proto3" json:"debits,omitempty"`
+	// Total amount received during the period
+	Credits int64 `protobuf:"varint,5,opt,name=credits,proto3" json:"credits,omitempty"`
+	// Number of transactions during the period
+	TxnCount uint64 `protobuf:"varint,6,opt,name=txn_count,json=txnCount,proto3" json:"txn_count,omitempty"`
+	// Period transactions, oldest first
+	Txns []*Txn `protobuf:"bytes,7,rep,name=txns" json:"txns,omitempty"`
+}
+
+func (m *GetStatementResponse) Reset()                    { *m = GetStatementResponse{} }
+func (m *GetStatementResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetStatementResponse) ProtoMessage()               {}
+func (*GetStatementResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{31} }
+
+func (m *GetStatementResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetStatementResponse) GetOpeningBalance() int64 {
+	if m != nil {
+		return m.OpeningBalance
+	}
+	return 0
+}
+
+func (m *GetStatementResponse) GetClosingBalance() int64 {
+	if m != nil {
+		return m.ClosingBalance
+	}
+	return 0
+}
+
+func (m *GetStatementResponse) GetDebits() int64 {
+	if m != nil {
+		return m.Debits
+	}
+	return 0
+}
+
+func (m *GetStatementResponse) GetCredits() int64 {
+	if m != nil {
+		return m.Credits
+	}
+	return 0
+}
+
+func (m *GetStatementResponse) GetTxnCount() uint64 {
+	if m != nil {
+		return m.TxnCount
+	}
+	return 0
+}
+
+func (m *GetStatementResponse) GetTxns() []*Txn {
+	if m != nil {
+		return m.Txns
+	}
+	return nil
+}
+
+// Request for account balance at some point in the past
+type GetBalanceAtRequest struct {
+	// Account ID
+	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Balance right after that account outgoing transaction
+	TxnId uint64 `protobuf:"varint,2,opt,name=txn_id,json=txnId,proto3" json:"txn_id,omitempty"`
+	// Or balance before that time (RFC3339). Current balance if none is set
+	Time string `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (m *GetBalanceAtRequest) Reset()                    { *m = GetBalanceAtRequest{} }
+func (m *GetBalanceAtRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetBalanceAtRequest) ProtoMessage()               {}
+func (*GetBalanceAtRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{32} }
+
+func (m *GetBalanceAtRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *GetBalanceAtRequest) GetTxnId() uint64 {
+	if m != nil {
+		return m.TxnId
+	}
+	return 0
+}
+
+func (m *GetBalanceAtRequest) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+// Response of GetBalanceAtRequest
+type GetBalanceAtResponse struct {
+	// Operation Status
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// Balance of the last outgoing transaction including incoming transactions spent by it
+	Balance int64 `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	// Incoming transactions received but not spent yet at that point
+	PendingIncoming int64 `protobuf:"varint,3,opt,name=pending_incoming,json=pendingIncoming,proto3" json:"pending_incoming,omitempty"`
+	// Last outgoing transaction ID at that point
+	TxnId uint64 `protobuf:"varint,4,opt,name=txn_id,json=txnId,proto3" json:"txn_id,omitempty"`
+}
+
+func (m *GetBalanceAtResponse) Reset()                    { *m = GetBalanceAtResponse{} }
+func (m *GetBalanceAtResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetBalanceAtResponse) ProtoMessage()               {}
+func (*GetBalanceAtResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{33} }
+
+func (m *GetBalanceAtResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetBalanceAtResponse) GetBalance() int64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *GetBalanceAtResponse) GetPendingIncoming() int64 {
+	if m != nil {
+		return m.PendingIncoming
+	}
+	return 0
+}
+
+func (m *GetBalanceAtResponse) GetTxnId() uint64 {
+	if m != nil {
+		return m.TxnId
+	}
+	return 0
+}
+
+// Request for account incoming transactions not spent yet
+type GetPendingIncomingRequest struct {
+	// Account ID
+	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Read from the database, account isn't loaded to the processing node then
+	FromDb bool `protobuf:"varint,2,opt,name=from_db,json=fromDb,proto3" json:"from_db,omitempty"`
+}
+
+func (m *GetPendingIncomingRequest) Reset()         { *m = GetPendingIncomingRequest{} }
+func (m *GetPendingIncomingRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPendingIncomingRequest) ProtoMessage()    {}
+func (*GetPendingIncomingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiService, []int{34}
+}
+
+func (m *GetPendingIncomingRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *GetPendingIncomingRequest) GetFromDb() bool {
+	if m != nil {
+		return m.FromDb
+	}
+	return false
+}
+
+// Response of GetPendingIncomingRequest
+type GetPendingIncomingResponse struct {
+	// Operation Status
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// Incoming transactions not spent by any outgoing transaction yet
+	Txns []*Txn `protobuf:"bytes,2,rep,name=txns" json:"txns,omitempty"`
+	// Sum of transactions amounts
+	Total int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (m *GetPendingIncomingResponse) Reset()         { *m = GetPendingIncomingResponse{} }
+func (m *GetPendingIncomingResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPendingIncomingResponse) ProtoMessage()    {}
+func (*GetPendingIncomingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiService, []int{35}
+}
+
+func (m *GetPendingIncomingResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetPendingIncomingResponse) GetTxns() []*Txn {
+	if m != nil {
+		return m.Txns
+	}
+	return nil
+}
+
+func (m *GetPendingIncomingResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+// Request for account settings history
+type GetSettingsHistoryRequest struct {
+	// Account ID
+	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Max number of settings to return at page
+	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Next page token
+	Token string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (m *GetSettingsHistoryRequest) Reset()         { *m = GetSettingsHistoryRequest{} }
+func (m *GetSettingsHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSettingsHistoryRequest) ProtoMessage()    {}
+func (*GetSettingsHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiService, []int{36}
+}
+
+func (m *GetSettingsHistoryRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *GetSettingsHistoryRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetSettingsHistoryRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+// Account settings record
+type SettingsHistoryEntry struct {
+	// Settings ID
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Settings Hash
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Hash of Previous settings
+	PrevHash string `protobuf:"bytes,3,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	// User Public Key
+	PublicKey string `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// True if public key differs from the previous settings one
+	KeyChanged bool `protobuf:"varint,5,opt,name=key_changed,json=keyChanged,proto3" json:"key_changed,omitempty"`
+	// User defined Data Hash
+	DataHash string `protobuf:"bytes,6,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
+	// Request Sign
+	Sign string `protobuf:"bytes,7,opt,name=sign,proto3" json:"sign,omitempty"`
+	// True if sign checking for requests is enabled
+	VerifyTransferSign bool `protobuf:"varint,8,opt,name=verify_transfer_sign,json=verifyTransferSign,proto3" json:"verify_transfer_sign,omitempty"`
+	// True if server sequencing is enabled
+	ServerSequencing bool `protobuf:"varint,9,opt,name=server_sequencing,json=serverSequencing,proto3" json:"server_sequencing,omitempty"`
+	// Account is explicitly registered
+	Registered bool `protobuf:"varint,10,opt,name=registered,proto3" json:"registered,omitempty"`
+	// Account is closed, no more activity allowed
+	Closed bool `protobuf:"varint,11,opt,name=closed,proto3" json:"closed,omitempty"`
+	// Account balance was swept to on close
+	SweepTo uint64 `protobuf:"varint,12,opt,name=sweep_to,json=sweepTo,proto3" json:"sweep_to,omitempty"`
+	// Processing time in RFC3339 format
+	ProcessedAt string `protobuf:"bytes,13,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
+}
+
+func (m *SettingsHistoryEntry) Reset()                    { *m = SettingsHistoryEntry{} }
+func (m *SettingsHistoryEntry) String() string            { return proto.CompactTextString(m) }
+func (*SettingsHistoryEntry) ProtoMessage()               {}
+func (*SettingsHistoryEntry) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{37} }
+
+func (m *SettingsHistoryEntry) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SettingsHistoryEntry) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *SettingsHistoryEntry) GetPrevHash() string {
+	if m != nil {
+		return m.PrevHash
+	}
+	return ""
+}
+
+func (m *SettingsHistoryEntry) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *SettingsHistoryEntry) GetKeyChanged() bool {
+	if m != nil {
+		return m.KeyChanged
+	}
+	return false
+}
+
+func (m *SettingsHistoryEntry) GetDataHash() string {
+	if m != nil {
+		return m.DataHash
+	}
+	return ""
+}
+
+func (m *SettingsHistoryEntry) GetSign() string {
+	if m != nil {
+		return m.Sign
+	}
+	return ""
+}
+
+func (m *SettingsHistoryEntry) GetVerifyTransferSign() bool {
+	if m != nil {
+		return m.VerifyTransferSign
+	}
+	return false
+}
+
+func (m *SettingsHistoryEntry) GetServerSequencing() bool {
+	if m != nil {
+		return m.ServerSequencing
+	}
+	return false
+}
+
+func (m *SettingsHistoryEntry) GetRegistered() bool {
+	if m != nil {
+		return m.Registered
+	}
+	return false
+}
+
+func (m *SettingsHistoryEntry) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+func (m *SettingsHistoryEntry) GetSweepTo() uint64 {
+	if m != nil {
+		return m.SweepTo
+	}
+	return 0
+}
+
+func (m *SettingsHistoryEntry) GetProcessedAt() string {
+	if m != nil {
+		return m.ProcessedAt
+	}
+	return ""
+}
+
+// Response of GetSettingsHistoryRequest
+type GetSettingsHistoryResponse struct {
+	// Operation Status
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// Settings list, latest first
+	Settings []*SettingsHistoryEntry `protobuf:"bytes,2,rep,name=settings" json:"settings,omitempty"`
+	// Next page token, empty if there are no more settings
+	Token string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (m *GetSettingsHistoryResponse) Reset()         { *m = GetSettingsHistoryResponse{} }
+func (m *GetSettingsHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSettingsHistoryResponse) ProtoMessage()    {}
+func (*GetSettingsHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiService, []int{38}
+}
+
+func (m *GetSettingsHistoryResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetSettingsHistoryResponse) GetSettings() []*SettingsHistoryEntry {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *GetSettingsHistoryResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+// Request to follow ledger changes
+type StreamChangesRequest struct {
+	// Changes after the cursor are returned, zero to start from the beginning
+	Cursor uint64 `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Max number of changes to return
+	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Seconds to wait for changes if there are none yet, zero to return at once
+	Wait uint32 `protobuf:"varint,3,opt,name=wait,proto3" json:"wait,omitempty"`
+}
+
+func (m *StreamChangesRequest) Reset()                    { *m = StreamChangesRequest{} }
+func (m *StreamChangesRequest) String() string            { return proto.CompactTextString(m) }
+func (*StreamChangesRequest) ProtoMessage()               {}
+func (*StreamChangesRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{39} }
+
+func (m *StreamChangesRequest) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+func (m *StreamChangesRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *StreamChangesRequest) GetWait() uint32 {
+	if m != nil {
+		return m.Wait
+	}
+	return 0
+}
+
+// Ledger change, either transaction or account settings
+type Change struct {
+	// Change sequence number
+	Seq uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Stored transaction
+	Txn *Txn `protobuf:"bytes,2,opt,name=txn" json:"txn,omitempty"`
+	// Stored account settings, key_changed is not set
+	Settings *SettingsHistoryEntry `protobuf:"bytes,3,opt,name=settings" json:"settings,omitempty"`
+}
+
+func (m *Change) Reset()                    { *m = Change{} }
+func (m *Change) String() string            { return proto.CompactTextString(m) }
+func (*Change) ProtoMessage()               {}
+func (*Change) Descriptor() ([]byte, []int) { return fileDescriptorApiService, []int{40} }
+
+func (m *Change) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Change) GetTxn() *Txn {
+	if m != nil {
+		return m.Txn
+	}
+	return nil
+}
+
+func (m *Change) GetSettings() *SettingsHistoryEntry {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+// Response of StreamChangesRequest
+type StreamChangesResponse struct {
+	// Response status
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// Changes in commit order
+	Changes []*Change `protobuf:"bytes,2,rep,name=changes" json:"changes,omitempty"`
+	// Cursor to pass to the next request
+	Cursor uint64 `protobuf:"varint,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (m *StreamChangesResponse) Reset()         { *m = StreamChangesResponse{} }
+func (m *StreamChangesResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamChangesResponse) ProtoMessage()    {}
+func (*StreamChangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiService, []int{41}
+}
+
+func (m *StreamChangesResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *StreamChangesResponse) GetChanges() []*Change {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *StreamChangesResponse) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Status)(nil), "api.Status")
 	proto.RegisterType((*TransferItem)(nil), "api.TransferItem")
@@ -965,99 +2004,189 @@ func init() {
 	proto.RegisterType((*SearchMetaResponse)(nil), "api.SearchMetaResponse")
 	proto.RegisterType((*PutMetaRequest)(nil), "api.PutMetaRequest")
 	proto.RegisterType((*PutMetaResponse)(nil), "api.PutMetaResponse")
+	proto.RegisterType((*BulkTransferRequest)(nil), "api.BulkTransferRequest")
+	proto.RegisterType((*BulkTransferResponse)(nil), "api.BulkTransferResponse")
+	proto.RegisterType((*CloseAccountRequest)(nil), "api.CloseAccountRequest")
+	proto.RegisterType((*CloseAccountResponse)(nil), "api.CloseAccountResponse")
+	proto.RegisterType((*RegisterAliasRequest)(nil), "api.RegisterAliasRequest")
+	proto.RegisterType((*RegisterAliasResponse)(nil), "api.RegisterAliasResponse")
+	proto.RegisterType((*ResolveAliasRequest)(nil), "api.ResolveAliasRequest")
+	proto.RegisterType((*ResolveAliasResponse)(nil), "api.ResolveAliasResponse")
+	proto.RegisterType((*GetStatementRequest)(nil), "api.GetStatementRequest")
+	proto.RegisterType((*GetStatementResponse)(nil), "api.GetStatementResponse")
+	proto.RegisterType((*GetBalanceAtRequest)(nil), "api.GetBalanceAtRequest")
+	proto.RegisterType((*GetBalanceAtResponse)(nil), "api.GetBalanceAtResponse")
+	proto.RegisterType((*GetPendingIncomingRequest)(nil), "api.GetPendingIncomingRequest")
+	proto.RegisterType((*GetPendingIncomingResponse)(nil), "api.GetPendingIncomingResponse")
+	proto.RegisterType((*GetSettingsHistoryRequest)(nil), "api.GetSettingsHistoryRequest")
+	proto.RegisterType((*SettingsHistoryEntry)(nil), "api.SettingsHistoryEntry")
+	proto.RegisterType((*GetSettingsHistoryResponse)(nil), "api.GetSettingsHistoryResponse")
+	proto.RegisterType((*StreamChangesRequest)(nil), "api.StreamChangesRequest")
+	proto.RegisterType((*Change)(nil), "api.Change")
+	proto.RegisterType((*StreamChangesResponse)(nil), "api.StreamChangesResponse")
 	proto.RegisterEnum("api.TransferCode", TransferCode_name, TransferCode_value)
+	proto.RegisterEnum("api.HistoryDirection", HistoryDirection_name, HistoryDirection_value)
 }
 
 func init() { proto.RegisterFile("api_service.proto", fileDescriptorApiService) }
 
 var fileDescriptorApiService = []byte{
-	// 1394 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdb, 0x72, 0x1a, 0x47,
-	0x13, 0xfe, 0x97, 0x33, 0x0d, 0x06, 0x34, 0x46, 0x12, 0x5e, 0xd9, 0xf5, 0x2b, 0x9b, 0x72, 0x59,
-	0x51, 0xc5, 0xe0, 0x90, 0x54, 0xec, 0x72, 0x25, 0x55, 0x01, 0x8b, 0x48, 0x94, 0x65, 0xac, 0x2c,
-	0xd8, 0x55, 0x4a, 0x2e, 0xa8, 0x01, 0xc6, 0x68, 0xcb, 0xb0, 0xbb, 0xd9, 0x1d, 0x64, 0xb8, 0x4d,
-	0xde, 0x20, 0xbe, 0xf4, 0x5b, 0xe4, 0x31, 0x92, 0xcb, 0xbc, 0x40, 0x2e, 0xf2, 0x04, 0x79, 0x82,
-	0xd4, 0x1c, 0x96, 0x3d, 0x80, 0x2c, 0x93, 0xca, 0x15, 0xdb, 0xdd, 0x33, 0x3d, 0x3d, 0x5f, 0x7f,
-	0xdd, 0x3d, 0xc0, 0x16, 0xb6, 0x8d, 0xbe, 0x4b, 0x9c, 0x4b, 0x63, 0x48, 0xaa, 0xb6, 0x63, 0x51,
-	0x0b, 0xc5, 0xb1, 0x6d, 0xa8, 0xb7, 0xc6, 0x96, 0x35, 0x9e, 0x90, 0x1a, 0x57, 0x0d, 0x66, 0xaf,
-	0x6a, 0xd8, 0x5c, 0x08, 0xbb, 0xfa, 0x29, 0xff, 0x19, 0xde, 0x1f, 0x13, 0xf3, 0xbe, 0xfb, 0x06,
-	0x8f, 0xc7, 0xc4, 0xa9, 0x59, 0x36, 0x35, 0x2c, 0xd3, 0xad, 0x61, 0xd3, 0xb4, 0x28, 0xe6, 0xdf,
-	0x72, 0xf5, 0x6d, 0xe9, 0x08, 0xdb, 0xc6, 0xaa, 0x55, 0x5b, 0x40, 0xaa, 0x4b, 0x31, 0x9d, 0xb9,
-	0xe8, 0x2e, 0x24, 0x86, 0xd6, 0x88, 0x54, 0x94, 0x7d, 0xe5, 0xa0, 0x50, 0xdf, 0xaa, 0x62, 0xdb,
-	0xa8, 0xf6, 0x1c, 0x6c, 0xba, 0xaf, 0x88, 0xf3, 0xc4, 0x1a, 0x11, 0x9d, 0x9b, 0x51, 0x05, 0xd2,
-	0x53, 0xe2, 0xba, 0x78, 0x4c, 0x2a, 0xb1, 0x7d, 0xe5, 0x20, 0xab, 0x7b, 0x22, 0xaa, 0x42, 0x7a,
-	0x44, 0x28, 0x36, 0x26, 0x6e, 0x25, 0xbe, 0x1f, 0x3f, 0xc8, 0xd5, 0xcb, 0x55, 0x71, 0x74, 0xd5,
-	0xbb, 0x43, 0xb5, 0x61, 0x2e, 0x74, 0x6f, 0x91, 0xd6, 0x84, 0xbc, 0xe7, 0xbf, 0x4d, 0xc9, 0x14,
-	0xa9, 0x90, 0x71, 0xc8, 0x90, 0x18, 0x97, 0xc4, 0xe1, 0x41, 0x24, 0xf4, 0xa5, 0x8c, 0x76, 0x20,
-	0x85, 0xa7, 0xd6, 0xcc, 0xa4, 0xfc, 0xd0, 0xb8, 0x2e, 0x25, 0xed, 0x77, 0x05, 0x8a, 0x9e, 0x13,
-	0x9d, 0xfc, 0x38, 0x23, 0x2e, 0x65, 0x6b, 0x5d, 0x62, 0x8e, 0x96, 0x5e, 0xa4, 0x84, 0xee, 0x41,
-	0x72, 0x80, 0xe9, 0xf0, 0xa2, 0x12, 0xe3, 0xd1, 0x85, 0x6f, 0xc8, 0x22, 0xd0, 0x85, 0x1d, 0xfd,
-	0x1f, 0x72, 0x2e, 0xa1, 0xd4, 0x30, 0xc7, 0x6e, 0xdf, 0x18, 0x55, 0xe2, 0xdc, 0x0b, 0x78, 0xaa,
-	0xf6, 0x08, 0xed, 0x41, 0xd6, 0x76, 0xc8, 0x65, 0xff, 0x02, 0xbb, 0x17, 0x95, 0x04, 0x47, 0x21,
-	0xc3, 0x14, 0x27, 0xd8, 0xbd, 0x40, 0x08, 0x12, 0xae, 0x31, 0x36, 0x2b, 0x49, 0xae, 0xe7, 0xdf,
-	0xe8, 0x2e, 0x64, 0xa6, 0x84, 0xe2, 0x11, 0xa6, 0xb8, 0x92, 0xda, 0x57, 0x0e, 0x72, 0xf5, 0x2c,
-	0x3f, 0xfd, 0x19, 0xa1, 0x58, 0x5f, 0x9a, 0xb4, 0x9f, 0x15, 0x28, 0xf9, 0xb7, 0x71, 0x6d, 0xcb,
-	0x74, 0x09, 0xfa, 0x18, 0x52, 0x2e, 0xcf, 0x10, 0xbf, 0x4e, 0xae, 0x9e, 0xe3, 0x3b, 0x45, 0xd2,
-	0x74, 0x69, 0x42, 0xdb, 0x90, 0xa2, 0x73, 0x93, 0x45, 0x2b, 0x92, 0x92, 0xa4, 0x73, 0xb3, 0x3d,
-	0x62, 0xb1, 0xf0, 0x18, 0xe3, 0x22, 0x16, 0xf6, 0x1d, 0xbd, 0x5d, 0x22, 0x7a, 0x3b, 0xad, 0x0a,
-	0xe8, 0x98, 0xd0, 0x33, 0x79, 0x1f, 0x0f, 0xd5, 0x0a, 0xa4, 0xf1, 0x70, 0xc8, 0x53, 0x20, 0x60,
-	0xf5, 0x44, 0xad, 0x03, 0x37, 0x43, 0xeb, 0x37, 0x89, 0xdb, 0x0b, 0x30, 0xe6, 0x07, 0xa8, 0xdd,
-	0x87, 0xad, 0x63, 0x42, 0x9b, 0x78, 0x82, 0xcd, 0x21, 0xb9, 0xfe, 0xf8, 0x2e, 0x0f, 0x77, 0xb9,
-	0x7c, 0x93, 0xd3, 0x2b, 0x90, 0x1e, 0x88, 0x7d, 0x92, 0x56, 0x9e, 0xa8, 0xfd, 0xa6, 0x40, 0xb1,
-	0x2b, 0x21, 0xb9, 0x36, 0x04, 0x74, 0x07, 0xc0, 0x9e, 0x0d, 0x26, 0xc6, 0xb0, 0xff, 0x9a, 0x2c,
-	0xe4, 0x5d, 0xb2, 0x42, 0xf3, 0x94, 0x2c, 0xc2, 0x74, 0x89, 0x47, 0xe8, 0xb2, 0x07, 0x59, 0x96,
-	0xfb, 0x10, 0x97, 0x98, 0xe2, 0x4a, 0x2e, 0x3d, 0x80, 0xf2, 0x25, 0x71, 0x8c, 0x57, 0x8b, 0x3e,
-	0x95, 0x54, 0xe9, 0xf3, 0x35, 0x8c, 0x57, 0x19, 0x1d, 0x09, 0x9b, 0xc7, 0xa2, 0xae, 0x31, 0x36,
-	0xb5, 0x09, 0x94, 0xfc, 0xbb, 0x6c, 0x82, 0x4f, 0x84, 0x2a, 0xe2, 0x62, 0xc1, 0x42, 0x58, 0xc3,
-	0x2f, 0xad, 0x0e, 0x3b, 0xc7, 0x84, 0x9e, 0x62, 0x97, 0x7e, 0x30, 0x80, 0xda, 0xbb, 0x18, 0xec,
-	0xae, 0x6c, 0xda, 0x24, 0xd2, 0x02, 0xc4, 0x96, 0x5c, 0x8e, 0x19, 0x7e, 0x60, 0xc9, 0x00, 0xf1,
-	0x03, 0xc7, 0xa7, 0xde, 0x97, 0xbf, 0xf4, 0x7b, 0xf3, 0x97, 0x79, 0x5f, 0xfe, 0xb2, 0x57, 0xe4,
-	0x0f, 0x3e, 0x20, 0x7f, 0xb9, 0x2b, 0xf3, 0x77, 0xce, 0x0b, 0xe2, 0xc4, 0x70, 0xa9, 0xe5, 0x2c,
-	0xae, 0x67, 0x63, 0x19, 0x92, 0x13, 0x63, 0x6a, 0x88, 0x56, 0x79, 0x43, 0x17, 0x02, 0xd3, 0x52,
-	0xeb, 0x35, 0x31, 0x65, 0xae, 0x84, 0xa0, 0x4d, 0x79, 0xf1, 0x2c, 0x5d, 0x6f, 0x02, 0xf9, 0x6d,
-	0x48, 0xd0, 0xb9, 0xe9, 0xca, 0x6e, 0x9a, 0x11, 0xdd, 0x74, 0x6e, 0xea, 0x5c, 0x7b, 0xc5, 0x71,
-	0xef, 0x62, 0x10, 0xef, 0xcd, 0x4d, 0x99, 0x2e, 0x85, 0x9b, 0x58, 0xba, 0xfc, 0x96, 0x2d, 0x2a,
-	0xc0, 0x6b, 0xd9, 0xc1, 0x91, 0x20, 0x52, 0xb9, 0x6e, 0x24, 0xa4, 0xc4, 0x1e, 0x21, 0x05, 0x8b,
-	0x5a, 0x64, 0xd2, 0x13, 0xd1, 0x2d, 0xc8, 0xb8, 0x36, 0x31, 0x69, 0x7f, 0xb0, 0x90, 0x99, 0x4a,
-	0x73, 0xb9, 0x19, 0x49, 0x31, 0x44, 0x52, 0x1c, 0x29, 0x83, 0xfc, 0xba, 0x32, 0xe0, 0x29, 0xbc,
-	0x11, 0x48, 0xb3, 0xc7, 0xc0, 0xed, 0x00, 0x03, 0xef, 0x40, 0x82, 0xf5, 0xfa, 0x4a, 0x21, 0x3a,
-	0x02, 0xb8, 0x5a, 0xfb, 0x53, 0x81, 0x04, 0x13, 0x51, 0x09, 0xe2, 0x8c, 0x88, 0x0c, 0x9f, 0xbc,
-	0xce, 0x3e, 0xd1, 0x21, 0x24, 0x0d, 0x73, 0x44, 0xe6, 0x12, 0xed, 0xf2, 0x72, 0x6b, 0xb5, 0xcd,
-	0xd4, 0x2d, 0x93, 0x3a, 0x0b, 0x5d, 0x2c, 0x41, 0xf7, 0x20, 0xc1, 0x07, 0x8d, 0x18, 0xc2, 0x37,
-	0xfd, 0xa5, 0x47, 0x98, 0x62, 0xb1, 0x92, 0x2f, 0x50, 0x1f, 0x01, 0xf8, 0xbb, 0x83, 0x87, 0x66,
-	0xc5, 0xa1, 0x65, 0x48, 0x5e, 0xe2, 0xc9, 0x4c, 0x34, 0xc7, 0xbc, 0x2e, 0x84, 0xc7, 0xb1, 0x47,
-	0x8a, 0xfa, 0x10, 0xb2, 0x4b, 0x67, 0x9b, 0x6c, 0xd4, 0x3e, 0xe1, 0xb3, 0xa2, 0xb9, 0x60, 0xf1,
-	0x3c, 0x25, 0x4b, 0x32, 0x23, 0x48, 0xbc, 0x26, 0x0b, 0x46, 0xb7, 0xf8, 0x41, 0x5e, 0xe7, 0xdf,
-	0xda, 0x39, 0x94, 0xc3, 0x4b, 0xff, 0x33, 0x72, 0x6a, 0xbf, 0x2a, 0xb0, 0xd5, 0x25, 0xd8, 0x19,
-	0x5e, 0x70, 0xf4, 0x65, 0x10, 0x0f, 0xc3, 0x18, 0x7f, 0x24, 0xfc, 0x46, 0x97, 0xad, 0x01, 0x3c,
-	0xc4, 0xf5, 0xbc, 0xe4, 0xba, 0x5f, 0x86, 0x8c, 0xd2, 0x49, 0x59, 0x86, 0xff, 0x1e, 0x73, 0x6d,
-	0x01, 0x28, 0x18, 0xcc, 0x66, 0x7d, 0x3c, 0x69, 0x50, 0x32, 0xf5, 0xe0, 0x08, 0x10, 0x4f, 0xe8,
-	0x59, 0x03, 0x34, 0xc9, 0x9c, 0xf6, 0x83, 0xd7, 0xc8, 0x32, 0x4d, 0x8f, 0x97, 0x6d, 0x0d, 0x0a,
-	0x67, 0x33, 0x1a, 0xc4, 0xca, 0x63, 0xb2, 0xb2, 0x9e, 0xc9, 0x5f, 0x42, 0x71, 0xb9, 0x61, 0x83,
-	0x40, 0x0f, 0xdf, 0x2a, 0xfe, 0x9b, 0x90, 0xbd, 0x39, 0x51, 0x0a, 0x62, 0xcf, 0x9f, 0x96, 0xfe,
-	0x87, 0xb6, 0x61, 0xab, 0xdd, 0x79, 0xd9, 0x38, 0x6d, 0x1f, 0xf5, 0xcf, 0xf4, 0xd6, 0xcb, 0xfe,
-	0x49, 0xa3, 0x7b, 0x52, 0x52, 0x50, 0x09, 0xf2, 0x9e, 0xba, 0xdb, 0x3e, 0xee, 0x94, 0x62, 0xa8,
-	0x08, 0xb9, 0x66, 0xe3, 0xa8, 0xaf, 0xb7, 0xbe, 0x7b, 0xd1, 0xea, 0xf6, 0x4a, 0x71, 0x54, 0x00,
-	0xe8, 0x3c, 0xef, 0x37, 0x1b, 0xa7, 0x8d, 0xce, 0x93, 0x56, 0x29, 0x81, 0x10, 0x14, 0xda, 0x9d,
-	0x5e, 0x4b, 0xef, 0x34, 0x4e, 0xfb, 0x2d, 0x5d, 0x7f, 0xae, 0x97, 0x92, 0x28, 0x0b, 0x49, 0xbd,
-	0xd5, 0xd3, 0xcf, 0x4b, 0x69, 0x66, 0x7e, 0xd6, 0xea, 0x35, 0x8e, 0x1a, 0xbd, 0x86, 0x34, 0x67,
-	0xea, 0x7f, 0x27, 0x01, 0x1a, 0x67, 0xed, 0xae, 0x78, 0xa4, 0xa3, 0x1f, 0xa0, 0x78, 0xe6, 0x58,
-	0x43, 0xe2, 0xba, 0x5e, 0xa8, 0xa8, 0x1c, 0x7a, 0x4b, 0x4a, 0x90, 0xd4, 0xed, 0x88, 0x56, 0x20,
-	0xa1, 0xed, 0xfd, 0xf4, 0xc7, 0x5f, 0x6f, 0x63, 0xdb, 0x5a, 0x89, 0xbd, 0xed, 0x83, 0x6e, 0x1e,
-	0x2b, 0x87, 0xe8, 0x1c, 0x72, 0x81, 0xc7, 0x14, 0xda, 0xe5, 0x2e, 0x56, 0x9f, 0x63, 0x6a, 0x65,
-	0xd5, 0x20, 0xdd, 0xef, 0x72, 0xf7, 0x5b, 0x5a, 0xbe, 0x36, 0xf6, 0xad, 0xcc, 0xf5, 0x0b, 0x00,
-	0xff, 0xa1, 0x84, 0x76, 0x3c, 0x07, 0xe1, 0x87, 0x96, 0xba, 0xbb, 0xa2, 0x97, 0x7e, 0x77, 0xb8,
-	0xdf, 0x92, 0x96, 0x63, 0x7e, 0xa5, 0x51, 0x44, 0x5c, 0x78, 0x61, 0x8f, 0x30, 0x25, 0xde, 0xe4,
-	0x96, 0x68, 0x44, 0xa6, 0xbf, 0x44, 0x23, 0x3a, 0xde, 0x35, 0x95, 0xbb, 0x2d, 0x6b, 0xc5, 0xda,
-	0x2c, 0xe4, 0x85, 0xb9, 0x36, 0xa0, 0x18, 0x79, 0x15, 0xa0, 0x3d, 0x2f, 0xbc, 0x35, 0x0f, 0x0c,
-	0xf5, 0xf6, 0x7a, 0xe3, 0x0a, 0xee, 0xe3, 0xf0, 0x0a, 0x1f, 0x1c, 0x39, 0x08, 0x7d, 0x70, 0xc2,
-	0x43, 0xd7, 0x07, 0x27, 0x32, 0x31, 0xc3, 0xe0, 0x48, 0x23, 0x73, 0xfb, 0x04, 0xf2, 0xc1, 0x26,
-	0x86, 0x96, 0x69, 0x8b, 0xb6, 0x40, 0xf5, 0xd6, 0x1a, 0x8b, 0x2c, 0x9d, 0xaf, 0x01, 0xfc, 0xca,
-	0x97, 0xb1, 0xad, 0xf4, 0x25, 0x19, 0xdb, 0x9a, 0x16, 0xf1, 0x05, 0xa4, 0x65, 0x31, 0x22, 0x31,
-	0x0c, 0xc2, 0xb5, 0xac, 0x96, 0xc3, 0x4a, 0xb1, 0xab, 0xf9, 0xed, 0x2f, 0x8d, 0xaf, 0xd0, 0x43,
-	0x4d, 0x05, 0x70, 0xcc, 0x51, 0x75, 0x48, 0x4c, 0x4a, 0x1c, 0x35, 0x8f, 0xbf, 0xf1, 0xa5, 0xc3,
-	0x32, 0xa0, 0x37, 0xe4, 0xde, 0x64, 0xb2, 0x3f, 0xbc, 0xb0, 0x2c, 0x97, 0xec, 0x4f, 0x30, 0x25,
-	0x4e, 0x3d, 0xfe, 0x59, 0xf5, 0xc1, 0xa1, 0xa2, 0x7c, 0x9f, 0xc4, 0xb6, 0x61, 0x0f, 0x06, 0x29,
-	0xfe, 0xe7, 0xef, 0xf3, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x41, 0x3c, 0xbf, 0xaf, 0xe8, 0x0e,
-	0x00, 0x00,
+	// 2504 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xcd, 0x6e, 0x1b, 0xc9,
+	0xf1, 0xdf, 0xe1, 0x37, 0x8b, 0x94, 0x34, 0x6a, 0x51, 0x12, 0x39, 0xde, 0xb5, 0xb4, 0xf3, 0x87,
+	0x61, 0xaf, 0xf6, 0x6f, 0x71, 0xa3, 0x4d, 0xe2, 0xc5, 0x62, 0x03, 0x84, 0x92, 0xb8, 0x32, 0x61,
+	0x99, 0x74, 0x86, 0x94, 0xb1, 0x76, 0x60, 0x0c, 0x5a, 0x64, 0x9b, 0x1a, 0x88, 0x9c, 0xe1, 0xce,
+	0x34, 0x65, 0x11, 0x7b, 0x4b, 0x80, 0x1c, 0x13, 0x20, 0x39, 0xe4, 0x94, 0x4b, 0x0e, 0xb9, 0xe4,
+	0x10, 0x20, 0x0f, 0x90, 0x17, 0xc8, 0x31, 0x2f, 0x10, 0x20, 0x79, 0x8a, 0x9c, 0x82, 0xfe, 0x18,
+	0x72, 0x66, 0x38, 0x94, 0xc4, 0xc0, 0x7b, 0x12, 0xbb, 0xaa, 0xa6, 0xaa, 0xba, 0xfa, 0x57, 0x5d,
+	0x55, 0x2d, 0x58, 0xc7, 0x23, 0xcb, 0xf4, 0x88, 0x7b, 0x65, 0x75, 0xc9, 0xfe, 0xc8, 0x75, 0xa8,
+	0x83, 0x92, 0x78, 0x64, 0x69, 0x95, 0xbe, 0xe3, 0xf4, 0x07, 0xa4, 0xca, 0x49, 0xe7, 0xe3, 0xb7,
+	0x55, 0x6c, 0x4f, 0x04, 0x5f, 0xfb, 0x7f, 0xfe, 0xa7, 0xfb, 0xb8, 0x4f, 0xec, 0xc7, 0xde, 0x3b,
+	0xdc, 0xef, 0x13, 0xb7, 0xea, 0x8c, 0xa8, 0xe5, 0xd8, 0x5e, 0x15, 0xdb, 0xb6, 0x43, 0x31, 0xff,
+	0x2d, 0xa5, 0x3f, 0x94, 0x8a, 0xf0, 0xc8, 0x9a, 0xe7, 0xea, 0x13, 0xc8, 0xb4, 0x29, 0xa6, 0x63,
+	0x0f, 0x3d, 0x80, 0x54, 0xd7, 0xe9, 0x91, 0xb2, 0xb2, 0xab, 0x3c, 0x5a, 0x3d, 0x58, 0xdf, 0xc7,
+	0x23, 0x6b, 0xbf, 0xe3, 0x62, 0xdb, 0x7b, 0x4b, 0xdc, 0x23, 0xa7, 0x47, 0x0c, 0xce, 0x46, 0x65,
+	0xc8, 0x0e, 0x89, 0xe7, 0xe1, 0x3e, 0x29, 0x27, 0x76, 0x95, 0x47, 0x79, 0xc3, 0x5f, 0xa2, 0x7d,
+	0xc8, 0xf6, 0x08, 0xc5, 0xd6, 0xc0, 0x2b, 0x27, 0x77, 0x93, 0x8f, 0x0a, 0x07, 0xa5, 0x7d, 0x61,
+	0x7a, 0xdf, 0xdf, 0xc3, 0x7e, 0xcd, 0x9e, 0x18, 0xbe, 0x90, 0xfe, 0x0d, 0x14, 0x7d, 0xfd, 0x0d,
+	0x4a, 0x86, 0x48, 0x83, 0x9c, 0x4b, 0xba, 0xc4, 0xba, 0x22, 0x2e, 0x77, 0x22, 0x65, 0x4c, 0xd7,
+	0x68, 0x0b, 0x32, 0x78, 0xe8, 0x8c, 0x6d, 0xca, 0x8d, 0x26, 0x0d, 0xb9, 0x42, 0x25, 0x48, 0xe3,
+	0x81, 0x85, 0x99, 0x45, 0xe6, 0x8b, 0x58, 0xe8, 0x7f, 0x57, 0x60, 0xcd, 0x57, 0x6d, 0x90, 0x6f,
+	0xc7, 0xc4, 0xa3, 0x4c, 0x83, 0x47, 0xec, 0xde, 0x54, 0xb7, 0x5c, 0xa1, 0x87, 0x90, 0x3e, 0xc7,
+	0xb4, 0x7b, 0x51, 0x4e, 0x70, 0x9f, 0xc3, 0xfb, 0x66, 0x7e, 0x19, 0x82, 0x8f, 0x76, 0xa0, 0xe0,
+	0x11, 0x4a, 0x2d, 0xbb, 0xef, 0x99, 0x56, 0x8f, 0x1b, 0x4c, 0x19, 0xe0, 0x93, 0x1a, 0x3d, 0x74,
+	0x0f, 0xf2, 0x23, 0x97, 0x5c, 0x99, 0x17, 0xd8, 0xbb, 0x28, 0xa7, 0xb8, 0x3f, 0x39, 0x46, 0x78,
+	0x8a, 0xbd, 0x0b, 0x84, 0x20, 0xe5, 0x59, 0x7d, 0xbb, 0x9c, 0xe6, 0x74, 0xfe, 0x1b, 0x3d, 0x80,
+	0xdc, 0x90, 0x50, 0xdc, 0xc3, 0x14, 0x97, 0x33, 0xbb, 0xca, 0xa3, 0xc2, 0x41, 0x9e, 0x5b, 0x7f,
+	0x4e, 0x28, 0x36, 0xa6, 0x2c, 0xfd, 0x97, 0x0a, 0xa8, 0xb3, 0xdd, 0x78, 0x23, 0xc7, 0xf6, 0x08,
+	0xfa, 0x3f, 0xc8, 0x78, 0xfc, 0xdc, 0xf8, 0x76, 0x0a, 0x07, 0x05, 0xfe, 0xa5, 0x38, 0x4a, 0x43,
+	0xb2, 0xd0, 0x26, 0x64, 0xe8, 0xb5, 0xcd, 0xbc, 0x15, 0x47, 0x95, 0xa6, 0xd7, 0x76, 0xa3, 0xc7,
+	0x7c, 0xe1, 0x3e, 0x8a, 0x98, 0xf1, 0xdf, 0xd1, 0xdd, 0xa5, 0xa2, 0xbb, 0xd3, 0xf7, 0x01, 0x9d,
+	0x10, 0xfa, 0x42, 0xee, 0xc7, 0x8f, 0x6a, 0x19, 0xb2, 0xb8, 0xdb, 0xe5, 0x07, 0x23, 0xc2, 0xea,
+	0x2f, 0xf5, 0x26, 0x6c, 0x84, 0xe4, 0x97, 0xf1, 0xdb, 0x77, 0x30, 0x31, 0x73, 0x50, 0x7f, 0x0c,
+	0xeb, 0x27, 0x84, 0x1e, 0xe2, 0x01, 0xb6, 0xbb, 0xe4, 0x76, 0xf3, 0x6d, 0x40, 0x41, 0xf1, 0x65,
+	0xac, 0x97, 0x21, 0x7b, 0x2e, 0xbe, 0x93, 0x60, 0xf3, 0x97, 0xfa, 0x9f, 0x13, 0xb0, 0xd6, 0x96,
+	0x21, 0xb9, 0xd5, 0x05, 0xf4, 0x11, 0xc0, 0x68, 0x7c, 0x3e, 0xb0, 0xba, 0xe6, 0x25, 0x99, 0xc8,
+	0xbd, 0xe4, 0x05, 0xe5, 0x19, 0x99, 0x84, 0xe1, 0x92, 0x8c, 0xc0, 0xe5, 0x1e, 0xe4, 0xd9, 0xd9,
+	0x87, 0xb0, 0xc4, 0x08, 0x0b, 0xb1, 0xf4, 0x19, 0x94, 0xae, 0x88, 0x6b, 0xbd, 0x9d, 0x98, 0x54,
+	0x42, 0xc5, 0xe4, 0x32, 0x0c, 0x57, 0x39, 0x03, 0x09, 0x9e, 0x8f, 0xa2, 0x36, 0xfb, 0xe2, 0x53,
+	0x58, 0x67, 0xd7, 0x0e, 0x13, 0x64, 0x5b, 0xb1, 0xbb, 0x96, 0xdd, 0x2f, 0x67, 0xb9, 0xb8, 0x2a,
+	0x18, 0xed, 0x29, 0x1d, 0xdd, 0x07, 0x70, 0x49, 0xdf, 0xf2, 0x28, 0x71, 0x49, 0xaf, 0x9c, 0xe3,
+	0x52, 0x01, 0x0a, 0x73, 0x89, 0xc3, 0x38, 0xbf, 0xab, 0x3c, 0x2a, 0x1a, 0xfc, 0xb7, 0x3e, 0x00,
+	0x75, 0x16, 0xac, 0x65, 0x0e, 0x20, 0x82, 0x45, 0x11, 0xb9, 0x60, 0xa6, 0xc5, 0x00, 0x58, 0x6f,
+	0xc1, 0xd6, 0x09, 0xa1, 0xa7, 0xd8, 0xa3, 0x77, 0x3f, 0xa1, 0x7b, 0x90, 0x7f, 0x67, 0xd1, 0x0b,
+	0x93, 0xbb, 0x9e, 0xe0, 0x9b, 0xca, 0x31, 0xc2, 0x31, 0x73, 0xff, 0x2f, 0x49, 0xd8, 0x9e, 0xd3,
+	0xb8, 0xcc, 0x36, 0x56, 0x21, 0x31, 0xcd, 0xa4, 0x84, 0x35, 0xf3, 0x3a, 0x1d, 0x48, 0xbb, 0x80,
+	0x6f, 0x99, 0x9b, 0xd0, 0x93, 0xbd, 0x11, 0x3d, 0xb9, 0x9b, 0xd0, 0x93, 0x5f, 0x80, 0x1e, 0xb8,
+	0x03, 0x7a, 0x0a, 0xcb, 0xa1, 0xa7, 0x78, 0x27, 0xf4, 0xac, 0xcc, 0xa1, 0x67, 0x0b, 0x32, 0xdd,
+	0x81, 0xe3, 0x91, 0x5e, 0x79, 0x95, 0xf3, 0xe4, 0x0a, 0x55, 0x20, 0xe7, 0xbd, 0x23, 0x64, 0x64,
+	0x52, 0xa7, 0xbc, 0x26, 0xc2, 0xc3, 0xd7, 0x1d, 0x67, 0x0a, 0x38, 0x35, 0x00, 0xb8, 0x3f, 0x25,
+	0xf8, 0x1d, 0xf1, 0xd4, 0xf2, 0xa8, 0xe3, 0x4e, 0x6e, 0x3f, 0xfe, 0x12, 0xa4, 0x07, 0xd6, 0xd0,
+	0x12, 0x35, 0x65, 0xc5, 0x10, 0x0b, 0x46, 0xa5, 0xce, 0x25, 0xb1, 0xfd, 0x92, 0xc2, 0x17, 0x2c,
+	0xa4, 0x6f, 0x5d, 0x67, 0x68, 0x52, 0x6b, 0x48, 0xe4, 0x09, 0xe6, 0x18, 0xa1, 0x63, 0x0d, 0x09,
+	0xda, 0x86, 0x2c, 0x75, 0x04, 0x2b, 0xc3, 0x59, 0x19, 0xea, 0x70, 0xc6, 0xe7, 0x90, 0xef, 0x59,
+	0x2e, 0xe9, 0xb2, 0x8a, 0xcb, 0xcf, 0x70, 0xf5, 0x60, 0x93, 0x43, 0x45, 0xfa, 0x78, 0xec, 0x33,
+	0x8d, 0x99, 0x1c, 0xd2, 0xa1, 0xc8, 0xfd, 0x23, 0xee, 0x08, 0xbb, 0x74, 0xc2, 0x4f, 0x37, 0x65,
+	0x84, 0x68, 0x0c, 0x1d, 0x43, 0xcb, 0x36, 0x65, 0x4d, 0xcc, 0xf3, 0x6b, 0x2a, 0x3f, 0xb4, 0xec,
+	0xda, 0xd0, 0x07, 0xcf, 0x10, 0x5f, 0xfb, 0x6c, 0x90, 0x6c, 0x7c, 0x2d, 0xd8, 0xfa, 0x90, 0x5f,
+	0x8e, 0xd3, 0x38, 0x2d, 0x03, 0xea, 0x0f, 0x21, 0x45, 0xaf, 0x6d, 0x4f, 0x56, 0xcb, 0x9c, 0xa8,
+	0x96, 0xd7, 0xb6, 0xc1, 0xa9, 0xf1, 0xb1, 0xd3, 0xff, 0x96, 0x80, 0x64, 0xe7, 0xda, 0x96, 0x09,
+	0xa1, 0x70, 0x16, 0x4b, 0x88, 0x59, 0x49, 0x16, 0x37, 0x9c, 0x5c, 0x85, 0x1a, 0x01, 0x19, 0xea,
+	0x98, 0x46, 0x40, 0x46, 0x5a, 0xac, 0x82, 0x97, 0xb6, 0xc8, 0x15, 0x7f, 0xc9, 0x41, 0x34, 0x22,
+	0x36, 0x35, 0xcf, 0x27, 0x32, 0x17, 0xb2, 0x7c, 0x7d, 0x18, 0x49, 0x22, 0x88, 0x24, 0x51, 0xe4,
+	0x16, 0x2a, 0xc6, 0xdd, 0x42, 0x3c, 0x49, 0x56, 0x02, 0x89, 0xe4, 0xe7, 0xf8, 0x66, 0x20, 0xc7,
+	0x3f, 0x82, 0x14, 0xab, 0xe5, 0xe5, 0xd5, 0x68, 0x89, 0xe7, 0x64, 0xf4, 0x31, 0x14, 0x47, 0xae,
+	0xd3, 0x25, 0x9e, 0x47, 0x7a, 0x26, 0xa6, 0x1c, 0xe8, 0x79, 0xa3, 0x30, 0xa5, 0xd5, 0xa8, 0xfe,
+	0x4f, 0x05, 0x52, 0xec, 0x0b, 0xa4, 0x42, 0x92, 0xdd, 0x06, 0x0a, 0x07, 0x3d, 0xfb, 0x89, 0xf6,
+	0x20, 0x6d, 0xd9, 0x3d, 0x72, 0x2d, 0x0f, 0xa4, 0x34, 0xd5, 0xbe, 0xdf, 0x60, 0xe4, 0xba, 0x4d,
+	0xdd, 0x89, 0x21, 0x44, 0xd0, 0x43, 0x99, 0x33, 0xa2, 0x3b, 0xdb, 0x98, 0x89, 0xb2, 0xfb, 0x4e,
+	0x48, 0x72, 0x01, 0xed, 0x0b, 0x80, 0xd9, 0xd7, 0x41, 0xa3, 0x79, 0x61, 0xb4, 0x04, 0xe9, 0x2b,
+	0x3c, 0x18, 0x8b, 0xfa, 0x58, 0x34, 0xc4, 0xe2, 0xcb, 0xc4, 0x17, 0x8a, 0xf6, 0x04, 0xf2, 0x53,
+	0x65, 0xcb, 0x7c, 0xa8, 0x7f, 0xc2, 0xdb, 0x85, 0xc3, 0x09, 0xf3, 0xe7, 0x19, 0x99, 0x26, 0x2f,
+	0x82, 0xd4, 0x25, 0x99, 0x30, 0x44, 0x26, 0x59, 0x9a, 0xb3, 0xdf, 0xfa, 0x2b, 0x28, 0x85, 0x45,
+	0xdf, 0x1b, 0x7e, 0xf5, 0xbf, 0x2a, 0xb0, 0xde, 0x26, 0xd8, 0xed, 0x5e, 0xf0, 0x03, 0x92, 0x4e,
+	0x3c, 0x09, 0xc7, 0xf8, 0x63, 0xa1, 0x37, 0x2a, 0x16, 0x13, 0xf0, 0x50, 0x3a, 0x14, 0xfd, 0xab,
+	0x64, 0x7a, 0xed, 0x30, 0xd4, 0xa7, 0xe5, 0xb5, 0xf3, 0xbf, 0xc7, 0x5c, 0x9f, 0x00, 0x0a, 0x3a,
+	0xb3, 0x5c, 0xa5, 0x4d, 0x5b, 0x94, 0x0c, 0xfd, 0x70, 0x04, 0xb0, 0x29, 0xe8, 0xec, 0x22, 0xb1,
+	0xc9, 0x35, 0x35, 0x83, 0xdb, 0xc8, 0x33, 0x4a, 0x87, 0x67, 0x76, 0x15, 0x56, 0x5f, 0x8c, 0x69,
+	0x30, 0x56, 0x3e, 0xd8, 0x95, 0x58, 0xb0, 0xeb, 0x3f, 0x86, 0xb5, 0xe9, 0x07, 0x4b, 0x38, 0xaa,
+	0x37, 0x60, 0xe3, 0x70, 0x3c, 0xb8, 0x8c, 0x36, 0xf5, 0x07, 0x90, 0xf7, 0x0b, 0x96, 0xc0, 0x88,
+	0x9f, 0x01, 0x11, 0x41, 0x63, 0x26, 0xa6, 0x0f, 0xa0, 0x14, 0x56, 0xb5, 0x4c, 0xc0, 0xaa, 0x90,
+	0x75, 0x89, 0x37, 0x1e, 0x50, 0x3f, 0x64, 0x9b, 0x11, 0x73, 0x42, 0x99, 0xe1, 0x4b, 0xe9, 0xdf,
+	0xc1, 0xc6, 0x11, 0x2b, 0x66, 0x35, 0x51, 0x73, 0x6e, 0x2f, 0x4a, 0xc1, 0x9a, 0x97, 0x08, 0xd7,
+	0xbc, 0x1b, 0x3b, 0x46, 0xff, 0x36, 0x4a, 0xcd, 0x6e, 0x23, 0xfd, 0x8f, 0x0a, 0x94, 0xc2, 0xd6,
+	0xbf, 0xef, 0x36, 0x2c, 0x30, 0x72, 0xa4, 0x82, 0x23, 0x47, 0x05, 0x72, 0x8c, 0x1c, 0xe8, 0x7f,
+	0xb2, 0xf4, 0xda, 0x66, 0x8e, 0xeb, 0xaf, 0xa1, 0x64, 0xc8, 0x56, 0xa0, 0xc6, 0xa6, 0x37, 0x3f,
+	0x44, 0xd3, 0xd1, 0x4e, 0x09, 0x8c, 0x76, 0xc1, 0xc0, 0x25, 0xc2, 0x81, 0xf3, 0x03, 0x90, 0x0c,
+	0x04, 0xe0, 0x2b, 0xd8, 0x8c, 0xe8, 0x5e, 0x06, 0x74, 0x9f, 0xc2, 0x86, 0x41, 0x3c, 0x67, 0x70,
+	0x45, 0x6e, 0x77, 0x4c, 0x3f, 0x83, 0x52, 0x58, 0x78, 0xc9, 0x91, 0x23, 0x7e, 0x57, 0xfa, 0xaf,
+	0x14, 0x7e, 0x31, 0x32, 0x79, 0x32, 0x24, 0x77, 0x01, 0x50, 0xa8, 0x53, 0x49, 0x2c, 0xee, 0x54,
+	0x92, 0xa1, 0x4e, 0x65, 0x07, 0x0a, 0xd4, 0xa1, 0x78, 0xe0, 0x99, 0x8e, 0x3d, 0x98, 0xf0, 0xc3,
+	0xcb, 0x19, 0x20, 0x48, 0x2d, 0x7b, 0x30, 0xd1, 0xff, 0xa3, 0x40, 0x29, 0xec, 0xc8, 0x32, 0x1b,
+	0x7c, 0x08, 0x6b, 0xce, 0x88, 0xd8, 0x96, 0xdd, 0x37, 0xc3, 0xb3, 0xd5, 0xaa, 0x24, 0xcb, 0x49,
+	0x8d, 0x09, 0xb2, 0xe6, 0x2f, 0x28, 0x98, 0x14, 0x82, 0x92, 0xec, 0x0b, 0x6e, 0x41, 0xa6, 0x47,
+	0xce, 0x2d, 0xea, 0x71, 0x5f, 0x93, 0x86, 0x5c, 0xb1, 0xc0, 0x74, 0x5d, 0xd2, 0x63, 0x8c, 0x34,
+	0x67, 0xf8, 0x4b, 0x16, 0x18, 0x86, 0xc1, 0x60, 0xb7, 0xcd, 0x40, 0x79, 0xc4, 0xa3, 0xe6, 0xd7,
+	0x85, 0x6c, 0x6c, 0x5d, 0x78, 0x2d, 0xaa, 0x93, 0x30, 0x5d, 0xbb, 0xc3, 0x21, 0x84, 0x27, 0xef,
+	0x54, 0x60, 0xf2, 0x0e, 0xc4, 0x9e, 0xff, 0xd6, 0x7f, 0x2f, 0x02, 0x1b, 0x50, 0xfe, 0x5e, 0x86,
+	0x55, 0xf4, 0x09, 0xa8, 0x23, 0x62, 0xf7, 0x58, 0x24, 0x2d, 0xbb, 0xeb, 0x0c, 0x59, 0x83, 0x2e,
+	0x42, 0xb9, 0x26, 0xe9, 0x0d, 0x49, 0x8e, 0x24, 0xad, 0xef, 0xad, 0xde, 0x84, 0x0a, 0x1b, 0xe1,
+	0xc3, 0xc2, 0xb7, 0xef, 0x7d, 0x1b, 0xb2, 0x1c, 0x80, 0xbd, 0x73, 0x39, 0x53, 0x65, 0xd8, 0xf2,
+	0xf8, 0x5c, 0x1f, 0x83, 0x16, 0xa7, 0xef, 0x3d, 0xb7, 0x9f, 0x14, 0x0f, 0xe4, 0x3e, 0xc5, 0x42,
+	0xc7, 0x7c, 0x1b, 0xfe, 0x0c, 0xf7, 0x7d, 0x4c, 0x07, 0xfa, 0x1f, 0x92, 0x50, 0x8a, 0x18, 0x10,
+	0x75, 0x7c, 0xd6, 0xf2, 0x86, 0x67, 0xc0, 0xc0, 0xcb, 0xc6, 0xcd, 0xd7, 0x7a, 0x78, 0x0c, 0x4c,
+	0x45, 0xc7, 0xc0, 0x1d, 0x28, 0x5c, 0x92, 0x89, 0xd9, 0xbd, 0xc0, 0x76, 0x9f, 0xf4, 0x38, 0xe2,
+	0x73, 0x06, 0x5c, 0x92, 0xc9, 0x91, 0xa0, 0x84, 0x47, 0xc1, 0xcc, 0x82, 0x51, 0x30, 0x7b, 0x87,
+	0x51, 0x30, 0xb7, 0xdc, 0x28, 0x98, 0xbf, 0xd3, 0x28, 0x08, 0x37, 0x8c, 0x82, 0x85, 0x85, 0xa3,
+	0x60, 0x31, 0x5c, 0x16, 0xa3, 0x0d, 0xf4, 0xca, 0x7c, 0x03, 0xfd, 0x6b, 0x85, 0x43, 0x6f, 0x0e,
+	0x03, 0xcb, 0x40, 0xef, 0x47, 0x90, 0xf3, 0x6b, 0x9f, 0x84, 0x5f, 0x45, 0x88, 0xc5, 0x9c, 0xbb,
+	0x31, 0x15, 0x5d, 0x00, 0x98, 0x6f, 0xa0, 0xd4, 0xa6, 0x2e, 0xc1, 0x43, 0x71, 0x4e, 0x5e, 0xe0,
+	0x95, 0xb2, 0x3b, 0x76, 0x3d, 0x67, 0xfa, 0x4a, 0x29, 0x56, 0x0b, 0xc0, 0x88, 0x20, 0xf5, 0x0e,
+	0x5b, 0x94, 0xab, 0x5e, 0x31, 0xf8, 0x6f, 0x7d, 0x08, 0x19, 0xa1, 0x93, 0xf5, 0x90, 0x1e, 0xf9,
+	0x56, 0x2a, 0x62, 0x3f, 0x91, 0x06, 0x49, 0x7a, 0x6d, 0x73, 0x1d, 0xc1, 0xe4, 0x61, 0xc4, 0xd0,
+	0xf6, 0x92, 0xbb, 0xca, 0x1d, 0xb7, 0xa7, 0x7f, 0x07, 0x9b, 0x91, 0x8d, 0x2c, 0x13, 0xd3, 0x07,
+	0x90, 0x15, 0xd0, 0xf5, 0x43, 0x2a, 0xa4, 0x84, 0x2e, 0xc3, 0xe7, 0x05, 0xa2, 0x92, 0x0c, 0x46,
+	0x65, 0xef, 0x5f, 0x0a, 0x14, 0x83, 0x4f, 0xd4, 0x28, 0x03, 0x89, 0xd6, 0x33, 0xf5, 0x03, 0xb4,
+	0x09, 0xeb, 0x8d, 0xe6, 0xcb, 0xda, 0x69, 0xe3, 0xd8, 0x7c, 0x61, 0xd4, 0x5f, 0x9a, 0x4f, 0x6b,
+	0xed, 0xa7, 0xaa, 0x82, 0x54, 0x28, 0xfa, 0xe4, 0x76, 0xe3, 0xa4, 0xa9, 0x26, 0xd0, 0x1a, 0x14,
+	0x0e, 0x6b, 0xc7, 0xa6, 0x51, 0xff, 0xd9, 0x59, 0xbd, 0xdd, 0x51, 0x93, 0x68, 0x15, 0xa0, 0xd9,
+	0x32, 0x0f, 0x6b, 0xa7, 0xb5, 0xe6, 0x51, 0x5d, 0x4d, 0x21, 0x04, 0xab, 0x8d, 0x66, 0xa7, 0x6e,
+	0x34, 0x6b, 0xa7, 0x66, 0xdd, 0x30, 0x5a, 0x86, 0x9a, 0x46, 0x79, 0x48, 0x1b, 0xf5, 0x8e, 0xf1,
+	0x4a, 0xcd, 0x32, 0xf6, 0xf3, 0x7a, 0xa7, 0x76, 0x5c, 0xeb, 0xd4, 0x24, 0x3b, 0xc7, 0x68, 0xb5,
+	0xa3, 0xa3, 0xd6, 0x59, 0xb3, 0x63, 0x1e, 0x9d, 0xb6, 0xda, 0xf5, 0x63, 0x35, 0x8f, 0x4a, 0xa0,
+	0xfa, 0x96, 0x8d, 0xfa, 0x51, 0xbd, 0xf1, 0xb2, 0x6e, 0xa8, 0xc0, 0xac, 0xd7, 0x4e, 0x1b, 0xb5,
+	0xb6, 0xd9, 0xa9, 0x3d, 0xab, 0x37, 0xd5, 0x02, 0xda, 0x80, 0x35, 0x41, 0x68, 0xb6, 0x3a, 0xe6,
+	0xd7, 0xad, 0xb3, 0xe6, 0xb1, 0x5a, 0xdc, 0x7b, 0x02, 0x6a, 0xf4, 0xb9, 0x00, 0x65, 0x21, 0x59,
+	0x3b, 0x3d, 0x55, 0x3f, 0x40, 0x45, 0xc8, 0x35, 0x9a, 0x47, 0xad, 0xe7, 0x8d, 0xe6, 0x89, 0xaa,
+	0xb0, 0x55, 0xeb, 0xac, 0x73, 0xd2, 0x62, 0xab, 0xc4, 0xc1, 0x6f, 0x56, 0x00, 0x6a, 0x2f, 0x1a,
+	0x6d, 0xf1, 0xcf, 0x05, 0xf4, 0x73, 0x58, 0x7b, 0x21, 0x72, 0xc2, 0x8f, 0x19, 0x8a, 0x6d, 0x96,
+	0xb5, 0xf8, 0x9e, 0x56, 0xbf, 0xf7, 0x8b, 0x7f, 0xfc, 0xfb, 0x77, 0x89, 0xcd, 0x2f, 0x95, 0x3d,
+	0x5d, 0xad, 0x8e, 0x22, 0x9a, 0x2e, 0x45, 0x83, 0x1e, 0x35, 0x50, 0xe6, 0xaa, 0x62, 0x5a, 0x77,
+	0xad, 0x12, 0xc3, 0x91, 0x86, 0x76, 0xb8, 0xa1, 0x0a, 0x33, 0x54, 0xaa, 0x9e, 0xc7, 0x68, 0x7d,
+	0x05, 0x85, 0xc0, 0xdb, 0x32, 0xda, 0xe6, 0xaa, 0xe6, 0x5f, 0xa7, 0xb5, 0xf2, 0x3c, 0x43, 0x9a,
+	0xd8, 0xe6, 0x26, 0xd6, 0x99, 0x89, 0x62, 0xb5, 0x1f, 0xd0, 0x75, 0x06, 0x30, 0x2b, 0xc6, 0x68,
+	0xcb, 0x57, 0x10, 0x7e, 0x77, 0xd6, 0xb6, 0xe7, 0xe8, 0x52, 0xef, 0x16, 0xd7, 0xab, 0x32, 0xbd,
+	0x85, 0x6a, 0x7f, 0xca, 0x47, 0xaf, 0x60, 0xf5, 0x6c, 0xd4, 0xc3, 0x94, 0xb4, 0xa7, 0xf7, 0x42,
+	0x28, 0xbb, 0xc2, 0xa1, 0x8f, 0xbe, 0x37, 0xea, 0x1a, 0x57, 0x5b, 0x62, 0x6a, 0xd7, 0xaa, 0xe3,
+	0xb0, 0x22, 0x0b, 0xd6, 0x22, 0xcf, 0x94, 0xe8, 0x9e, 0xef, 0x5e, 0xcc, 0x73, 0xa8, 0xf6, 0x61,
+	0x3c, 0x33, 0xee, 0x90, 0xfb, 0x11, 0xbd, 0x6f, 0xa0, 0x18, 0x1c, 0x27, 0xe4, 0xe9, 0xc6, 0xcc,
+	0x37, 0x5a, 0x25, 0x86, 0x23, 0x2d, 0x94, 0xb9, 0x05, 0xc4, 0x2c, 0xac, 0x54, 0xbb, 0x41, 0x75,
+	0x18, 0x56, 0x42, 0xdd, 0x3a, 0x12, 0x5a, 0xe2, 0xa6, 0x03, 0x4d, 0x8b, 0x63, 0x49, 0x0b, 0x15,
+	0x6e, 0x61, 0x83, 0x59, 0x58, 0xad, 0xba, 0x21, 0x8d, 0x6f, 0xa0, 0x18, 0xec, 0xd2, 0xe5, 0x0e,
+	0x62, 0xba, 0x7c, 0xad, 0x12, 0xc3, 0x89, 0xdb, 0x81, 0x1b, 0x54, 0x27, 0xd0, 0x23, 0xb3, 0x75,
+	0x86, 0x9e, 0x70, 0xcf, 0xa1, 0x6d, 0xcf, 0xd1, 0x17, 0xa0, 0xc7, 0x57, 0xf4, 0x06, 0x8a, 0xc1,
+	0xd6, 0x1b, 0x4d, 0x71, 0x1d, 0x1d, 0x0b, 0xb4, 0x4a, 0x0c, 0x27, 0xce, 0xeb, 0x7e, 0x50, 0x9d,
+	0x50, 0x3f, 0x6d, 0x40, 0x67, 0xea, 0xa3, 0x0d, 0xaf, 0x56, 0x89, 0xe1, 0x2c, 0x50, 0x3f, 0x53,
+	0x37, 0x06, 0x34, 0xdf, 0xf6, 0xa1, 0xfb, 0xd3, 0xdc, 0x8c, 0xed, 0x2f, 0xb5, 0x9d, 0x85, 0x7c,
+	0x69, 0xf0, 0x3e, 0x37, 0x58, 0x66, 0x06, 0x37, 0xaa, 0xfd, 0x39, 0x39, 0x69, 0x36, 0x52, 0xbe,
+	0x66, 0x66, 0xe3, 0xfb, 0x41, 0x6d, 0x67, 0x21, 0x7f, 0x81, 0xd9, 0xa8, 0x01, 0x0c, 0x2b, 0xa1,
+	0x82, 0x28, 0x41, 0x1c, 0x57, 0xed, 0x35, 0x2d, 0x8e, 0x15, 0x07, 0x62, 0x2f, 0xa4, 0xf1, 0x08,
+	0x8a, 0xc1, 0x07, 0xb0, 0xc0, 0x79, 0x45, 0x9e, 0xcf, 0xb4, 0x4a, 0x0c, 0x47, 0xd6, 0xe7, 0x9f,
+	0x00, 0xcc, 0x5e, 0x8d, 0x24, 0x54, 0xe7, 0xde, 0xb4, 0xb4, 0xed, 0x39, 0xba, 0xfc, 0xfc, 0x87,
+	0x90, 0x95, 0x0f, 0x39, 0x48, 0x3c, 0x24, 0x86, 0xdf, 0x81, 0xb4, 0x52, 0x98, 0x28, 0xbe, 0x3a,
+	0xfc, 0xfa, 0xb7, 0xb5, 0xaf, 0xd0, 0x13, 0x5d, 0x03, 0x70, 0xed, 0xde, 0x7e, 0x97, 0xd8, 0x94,
+	0xb8, 0x5a, 0x11, 0xff, 0x74, 0xb6, 0xda, 0x2b, 0x01, 0x7a, 0x47, 0x1e, 0x0e, 0x06, 0xbb, 0xdd,
+	0x0b, 0xc7, 0xf1, 0xc8, 0xee, 0x00, 0x53, 0xe2, 0x1e, 0x24, 0x7f, 0xb0, 0xff, 0xd9, 0x9e, 0xa2,
+	0xbc, 0x4e, 0xe3, 0x91, 0x35, 0x3a, 0x3f, 0xcf, 0xf0, 0xff, 0x28, 0x7f, 0xfe, 0xdf, 0x01, 0x00,
+	0x42, 0xd9, 0x5b, 0xcc, 0x3d, 0x1f, 0x00, 0x00,
 }
@@ -24,6 +24,13 @@ func RegisterAPIServiceHandlers(s *tcprpc.Server, prefix string, srv APIServiceI
 			return srv.ProcessTransfer(ctx, args)
 		}))
 
+	s.Handle(prefix+"BulkProcessTransfer", tcprpc.NewHandler(
+		func() proto.Message { return new(BulkTransferRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*BulkTransferRequest)
+			return srv.BulkProcessTransfer(ctx, args)
+		}))
+
 	s.Handle(prefix+"GetPrevHash", tcprpc.NewHandler(
 		func() proto.Message { return new(GetPrevHashRequest) },
 		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
@@ -52,6 +59,27 @@ func RegisterAPIServiceHandlers(s *tcprpc.Server, prefix string, srv APIServiceI
 			return srv.GetLastSettings(ctx, args)
 		}))
 
+	s.Handle(prefix+"CloseAccount", tcprpc.NewHandler(
+		func() proto.Message { return new(CloseAccountRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*CloseAccountRequest)
+			return srv.CloseAccount(ctx, args)
+		}))
+
+	s.Handle(prefix+"RegisterAlias", tcprpc.NewHandler(
+		func() proto.Message { return new(RegisterAliasRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*RegisterAliasRequest)
+			return srv.RegisterAlias(ctx, args)
+		}))
+
+	s.Handle(prefix+"ResolveAlias", tcprpc.NewHandler(
+		func() proto.Message { return new(ResolveAliasRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*ResolveAliasRequest)
+			return srv.ResolveAlias(ctx, args)
+		}))
+
 	s.Handle(prefix+"GetHistory", tcprpc.NewHandler(
 		func() proto.Message { return new(GetHistoryRequest) },
 		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
@@ -59,6 +87,41 @@ func RegisterAPIServiceHandlers(s *tcprpc.Server, prefix string, srv APIServiceI
 			return srv.GetHistory(ctx, args)
 		}))
 
+	s.Handle(prefix+"GetStatement", tcprpc.NewHandler(
+		func() proto.Message { return new(GetStatementRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetStatementRequest)
+			return srv.GetStatement(ctx, args)
+		}))
+
+	s.Handle(prefix+"GetBalanceAt", tcprpc.NewHandler(
+		func() proto.Message { return new(GetBalanceAtRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetBalanceAtRequest)
+			return srv.GetBalanceAt(ctx, args)
+		}))
+
+	s.Handle(prefix+"GetPendingIncoming", tcprpc.NewHandler(
+		func() proto.Message { return new(GetPendingIncomingRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetPendingIncomingRequest)
+			return srv.GetPendingIncoming(ctx, args)
+		}))
+
+	s.Handle(prefix+"GetSettingsHistory", tcprpc.NewHandler(
+		func() proto.Message { return new(GetSettingsHistoryRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetSettingsHistoryRequest)
+			return srv.GetSettingsHistory(ctx, args)
+		}))
+
+	s.Handle(prefix+"StreamChanges", tcprpc.NewHandler(
+		func() proto.Message { return new(StreamChangesRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*StreamChangesRequest)
+			return srv.StreamChanges(ctx, args)
+		}))
+
 	s.Handle(prefix+"GetByMetaKey", tcprpc.NewHandler(
 		func() proto.Message { return new(GetByMetaKeyRequest) },
 		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
@@ -103,6 +166,15 @@ func (cl TCPRPCAPIServiceClient) ProcessTransfer(ctx context.Context, args *Tran
 	return &resp, nil
 }
 
+func (cl TCPRPCAPIServiceClient) BulkProcessTransfer(ctx context.Context, args *BulkTransferRequest) (*BulkTransferResponse, error) {
+	var resp BulkTransferResponse
+	err := cl.cl.Call(ctx, cl.pref+"BulkProcessTransfer", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (cl TCPRPCAPIServiceClient) GetPrevHash(ctx context.Context, args *GetPrevHashRequest) (*GetPrevHashResponse, error) {
 	var resp GetPrevHashResponse
 	err := cl.cl.Call(ctx, cl.pref+"GetPrevHash", args, &resp)
@@ -139,6 +211,33 @@ func (cl TCPRPCAPIServiceClient) GetLastSettings(ctx context.Context, args *GetL
 	return &resp, nil
 }
 
+func (cl TCPRPCAPIServiceClient) CloseAccount(ctx context.Context, args *CloseAccountRequest) (*CloseAccountResponse, error) {
+	var resp CloseAccountResponse
+	err := cl.cl.Call(ctx, cl.pref+"CloseAccount", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCAPIServiceClient) RegisterAlias(ctx context.Context, args *RegisterAliasRequest) (*RegisterAliasResponse, error) {
+	var resp RegisterAliasResponse
+	err := cl.cl.Call(ctx, cl.pref+"RegisterAlias", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCAPIServiceClient) ResolveAlias(ctx context.Context, args *ResolveAliasRequest) (*ResolveAliasResponse, error) {
+	var resp ResolveAliasResponse
+	err := cl.cl.Call(ctx, cl.pref+"ResolveAlias", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (cl TCPRPCAPIServiceClient) GetHistory(ctx context.Context, args *GetHistoryRequest) (*GetHistoryResponse, error) {
 	var resp GetHistoryResponse
 	err := cl.cl.Call(ctx, cl.pref+"GetHistory", args, &resp)
@@ -148,6 +247,51 @@ func (cl TCPRPCAPIServiceClient) GetHistory(ctx context.Context, args *GetHistor
 	return &resp, nil
 }
 
+func (cl TCPRPCAPIServiceClient) GetStatement(ctx context.Context, args *GetStatementRequest) (*GetStatementResponse, error) {
+	var resp GetStatementResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetStatement", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCAPIServiceClient) GetBalanceAt(ctx context.Context, args *GetBalanceAtRequest) (*GetBalanceAtResponse, error) {
+	var resp GetBalanceAtResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetBalanceAt", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCAPIServiceClient) GetPendingIncoming(ctx context.Context, args *GetPendingIncomingRequest) (*GetPendingIncomingResponse, error) {
+	var resp GetPendingIncomingResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetPendingIncoming", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCAPIServiceClient) GetSettingsHistory(ctx context.Context, args *GetSettingsHistoryRequest) (*GetSettingsHistoryResponse, error) {
+	var resp GetSettingsHistoryResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetSettingsHistory", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCAPIServiceClient) StreamChanges(ctx context.Context, args *StreamChangesRequest) (*StreamChangesResponse, error) {
+	var resp StreamChangesResponse
+	err := cl.cl.Call(ctx, cl.pref+"StreamChanges", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (cl TCPRPCAPIServiceClient) GetByMetaKey(ctx context.Context, args *GetByMetaKeyRequest) (*GetByMetaKeyResponse, error) {
 	var resp GetByMetaKeyResponse
 	err := cl.cl.Call(ctx, cl.pref+"GetByMetaKey", args, &resp)
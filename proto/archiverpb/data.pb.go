@@ -51,16 +51,17 @@ func (x Direction) String() string {
 func (Direction) EnumDescriptor() ([]byte, []int) { return fileDescriptorData, []int{0} }
 
 type Txn struct {
-	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Sender     string `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
-	Receiver   string `protobuf:"bytes,3,opt,name=receiver,proto3" json:"receiver,omitempty"`
-	Amount     string `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Balance    string `protobuf:"bytes,5,opt,name=balance,proto3" json:"balance,omitempty"`
-	SpentBy    string `protobuf:"bytes,6,opt,name=spent_by,json=spentBy,proto3" json:"spent_by,omitempty"`
-	SettingsId string `protobuf:"bytes,7,opt,name=settings_id,json=settingsId,proto3" json:"settings_id,omitempty"`
-	PrevHash   string `protobuf:"bytes,8,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
-	Hash       string `protobuf:"bytes,9,opt,name=hash,proto3" json:"hash,omitempty"`
-	Sign       string `protobuf:"bytes,10,opt,name=sign,proto3" json:"sign,omitempty"`
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sender      string `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Receiver    string `protobuf:"bytes,3,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Amount      string `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Balance     string `protobuf:"bytes,5,opt,name=balance,proto3" json:"balance,omitempty"`
+	SpentBy     string `protobuf:"bytes,6,opt,name=spent_by,json=spentBy,proto3" json:"spent_by,omitempty"`
+	SettingsId  string `protobuf:"bytes,7,opt,name=settings_id,json=settingsId,proto3" json:"settings_id,omitempty"`
+	PrevHash    string `protobuf:"bytes,8,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Hash        string `protobuf:"bytes,9,opt,name=hash,proto3" json:"hash,omitempty"`
+	Sign        string `protobuf:"bytes,10,opt,name=sign,proto3" json:"sign,omitempty"`
+	ProcessedAt string `protobuf:"bytes,11,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
 }
 
 func (m *Txn) Reset()                    { *m = Txn{} }
@@ -138,6 +139,13 @@ func (m *Txn) GetSign() string {
 	return ""
 }
 
+func (m *Txn) GetProcessedAt() string {
+	if m != nil {
+		return m.ProcessedAt
+	}
+	return ""
+}
+
 type Settings struct {
 	Id                 string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Account            string `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
@@ -147,6 +155,11 @@ type Settings struct {
 	DataHash           string `protobuf:"bytes,6,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
 	Sign               string `protobuf:"bytes,7,opt,name=sign,proto3" json:"sign,omitempty"`
 	VerifyTransferSign bool   `protobuf:"varint,8,opt,name=verify_transfer_sign,json=verifyTransferSign,proto3" json:"verify_transfer_sign,omitempty"`
+	ServerSequencing   bool   `protobuf:"varint,9,opt,name=server_sequencing,json=serverSequencing,proto3" json:"server_sequencing,omitempty"`
+	Registered         bool   `protobuf:"varint,10,opt,name=registered,proto3" json:"registered,omitempty"`
+	Closed             bool   `protobuf:"varint,11,opt,name=closed,proto3" json:"closed,omitempty"`
+	SweepTo            string `protobuf:"bytes,12,opt,name=sweep_to,json=sweepTo,proto3" json:"sweep_to,omitempty"`
+	ProcessedAt        string `protobuf:"bytes,13,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
 }
 
 func (m *Settings) Reset()                    { *m = Settings{} }
@@ -210,6 +223,41 @@ func (m *Settings) GetVerifyTransferSign() bool {
 	return false
 }
 
+func (m *Settings) GetServerSequencing() bool {
+	if m != nil {
+		return m.ServerSequencing
+	}
+	return false
+}
+
+func (m *Settings) GetRegistered() bool {
+	if m != nil {
+		return m.Registered
+	}
+	return false
+}
+
+func (m *Settings) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+func (m *Settings) GetSweepTo() string {
+	if m != nil {
+		return m.SweepTo
+	}
+	return ""
+}
+
+func (m *Settings) GetProcessedAt() string {
+	if m != nil {
+		return m.ProcessedAt
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Txn)(nil), "archiverpb.Txn")
 	proto.RegisterType((*Settings)(nil), "archiverpb.Settings")
@@ -219,27 +267,32 @@ func init() {
 func init() { proto.RegisterFile("data.proto", fileDescriptorData) }
 
 var fileDescriptorData = []byte{
-	// 337 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcb, 0x4a, 0xc3, 0x40,
-	0x14, 0x86, 0x4d, 0x2f, 0xb9, 0x1c, 0x41, 0xe4, 0x20, 0x32, 0x2a, 0xa2, 0x74, 0x25, 0x2e, 0x44,
-	0xf0, 0x0d, 0xc4, 0x45, 0x8b, 0xa0, 0xd2, 0xa6, 0xeb, 0x30, 0x49, 0x4e, 0x9b, 0xc1, 0x3a, 0x09,
-	0x33, 0xd3, 0x62, 0x9e, 0xd8, 0x97, 0x70, 0x21, 0x33, 0x93, 0x6a, 0x45, 0xdc, 0x9d, 0xff, 0xfb,
-	0x73, 0x2e, 0xf9, 0x13, 0x80, 0x92, 0x1b, 0x7e, 0xd3, 0xa8, 0xda, 0xd4, 0x08, 0x5c, 0x15, 0x95,
-	0xd8, 0x90, 0x6a, 0xf2, 0xd1, 0x67, 0x00, 0xfd, 0xf4, 0x5d, 0xe2, 0x01, 0xf4, 0x44, 0xc9, 0x82,
-	0xcb, 0xe0, 0x2a, 0x99, 0xf6, 0x44, 0x89, 0xc7, 0x10, 0x6a, 0x92, 0x25, 0x29, 0xd6, 0x73, 0xac,
-	0x53, 0x78, 0x0a, 0xb1, 0xa2, 0x82, 0x6c, 0x37, 0xeb, 0x3b, 0xe7, 0x5b, 0xdb, 0x1e, 0xfe, 0x56,
-	0xaf, 0xa5, 0x61, 0x03, 0xdf, 0xe3, 0x15, 0x32, 0x88, 0x72, 0xbe, 0xe2, 0xb2, 0x20, 0x36, 0x74,
-	0xc6, 0x56, 0xe2, 0x09, 0xc4, 0xba, 0x21, 0x69, 0xb2, 0xbc, 0x65, 0xa1, 0xb7, 0x9c, 0xbe, 0x6f,
-	0xf1, 0x02, 0xf6, 0x35, 0x19, 0x23, 0xe4, 0x52, 0x67, 0xa2, 0x64, 0x91, 0x73, 0x61, 0x8b, 0x26,
-	0x25, 0x9e, 0x41, 0xd2, 0x28, 0xda, 0x64, 0x15, 0xd7, 0x15, 0x8b, 0xfd, 0x29, 0x16, 0x8c, 0xb9,
-	0xae, 0x10, 0x61, 0xe0, 0x78, 0xe2, 0xb8, 0xab, 0x2d, 0xd3, 0x62, 0x29, 0x19, 0x78, 0x66, 0xeb,
-	0xd1, 0x47, 0x00, 0xf1, 0xac, 0x9b, 0xf9, 0x27, 0x03, 0x06, 0x11, 0x2f, 0x0a, 0xf7, 0x42, 0x3e,
-	0x84, 0xad, 0xfc, 0xbd, 0xbb, 0xff, 0xcf, 0xee, 0xc1, 0xce, 0xee, 0x73, 0x80, 0x66, 0x9d, 0xaf,
-	0x44, 0x91, 0xbd, 0x52, 0xdb, 0xa5, 0x90, 0x78, 0xf2, 0x48, 0xad, 0x9d, 0x67, 0xbf, 0x8f, 0x9f,
-	0xe7, 0x83, 0x88, 0x2d, 0x18, 0xef, 0xde, 0x1d, 0xfd, 0xdc, 0x8d, 0xb7, 0x70, 0xb4, 0x21, 0x25,
-	0x16, 0x6d, 0x66, 0x14, 0x97, 0x7a, 0x41, 0x2a, 0x73, 0xcf, 0xd8, 0x1c, 0xe2, 0x29, 0x7a, 0x2f,
-	0xed, 0xac, 0x99, 0x58, 0xca, 0xeb, 0x11, 0x24, 0x0f, 0x42, 0x51, 0x61, 0x44, 0x2d, 0x31, 0x81,
-	0xe1, 0xe4, 0xe9, 0x65, 0x9e, 0x1e, 0xee, 0x21, 0x40, 0xf8, 0x3c, 0x4f, 0x6d, 0x1d, 0xe4, 0xa1,
-	0xfb, 0x3f, 0xee, 0xbe, 0x02, 0x00, 0x00, 0xff, 0xff, 0x01, 0x4e, 0x98, 0xfd, 0x2d, 0x02, 0x00,
-	0x00,
+	// 423 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xdd, 0x8e, 0xd3, 0x30,
+	0x10, 0x85, 0xe9, 0xcf, 0xb6, 0xce, 0x74, 0x41, 0x8b, 0x85, 0x90, 0x01, 0xf1, 0xd7, 0x2b, 0x04,
+	0x12, 0x42, 0xe2, 0x09, 0x40, 0x5c, 0xec, 0x0a, 0x09, 0x50, 0x9a, 0xbd, 0xb6, 0x1c, 0x7b, 0xb6,
+	0xb1, 0x28, 0x76, 0xb0, 0xdd, 0x40, 0x5e, 0x81, 0x97, 0xe0, 0x55, 0x91, 0xed, 0xb4, 0x0a, 0xaa,
+	0xf6, 0x2e, 0xe7, 0x3b, 0x1e, 0x67, 0xe6, 0x8c, 0x01, 0x94, 0x08, 0xe2, 0x6d, 0xeb, 0x6c, 0xb0,
+	0x14, 0x84, 0x93, 0x8d, 0xee, 0xd0, 0xb5, 0xf5, 0xfa, 0xef, 0x14, 0x66, 0xd5, 0x6f, 0x43, 0xef,
+	0xc1, 0x54, 0x2b, 0x36, 0x79, 0x31, 0x79, 0x55, 0x94, 0x53, 0xad, 0xe8, 0x43, 0x58, 0x78, 0x34,
+	0x0a, 0x1d, 0x9b, 0x26, 0x36, 0x28, 0xfa, 0x18, 0x88, 0x43, 0x89, 0xb1, 0x9a, 0xcd, 0x92, 0x73,
+	0xd4, 0xb1, 0x46, 0xfc, 0xb0, 0x7b, 0x13, 0xd8, 0x3c, 0xd7, 0x64, 0x45, 0x19, 0x2c, 0x6b, 0xb1,
+	0x13, 0x46, 0x22, 0x3b, 0x4b, 0xc6, 0x41, 0xd2, 0x47, 0x40, 0x7c, 0x8b, 0x26, 0xf0, 0xba, 0x67,
+	0x8b, 0x6c, 0x25, 0xfd, 0xb1, 0xa7, 0xcf, 0x61, 0xe5, 0x31, 0x04, 0x6d, 0xb6, 0x9e, 0x6b, 0xc5,
+	0x96, 0xc9, 0x85, 0x03, 0xba, 0x52, 0xf4, 0x09, 0x14, 0xad, 0xc3, 0x8e, 0x37, 0xc2, 0x37, 0x8c,
+	0xe4, 0x56, 0x22, 0xb8, 0x14, 0xbe, 0xa1, 0x14, 0xe6, 0x89, 0x17, 0x89, 0xcf, 0x9b, 0x81, 0x79,
+	0xbd, 0x35, 0x0c, 0x32, 0x8b, 0xdf, 0xf4, 0x25, 0x9c, 0xb7, 0xce, 0x4a, 0xf4, 0x1e, 0x15, 0x17,
+	0x81, 0xad, 0x92, 0xb7, 0x3a, 0xb2, 0x0f, 0x61, 0xfd, 0x67, 0x06, 0x64, 0x33, 0xfc, 0xf6, 0x24,
+	0x26, 0x06, 0x4b, 0x21, 0x65, 0x9a, 0x39, 0xe7, 0x74, 0x90, 0xff, 0xb7, 0x37, 0xbb, 0xa5, 0xbd,
+	0xf9, 0xa8, 0xbd, 0xa7, 0x00, 0xed, 0xbe, 0xde, 0x69, 0xc9, 0xbf, 0x63, 0x3f, 0x04, 0x55, 0x64,
+	0xf2, 0x19, 0xfb, 0x78, 0x5f, 0x5c, 0x61, 0xbe, 0x2f, 0x67, 0x45, 0x22, 0xb8, 0x1c, 0x8f, 0xb6,
+	0x1c, 0x8d, 0xf6, 0x0e, 0x1e, 0x74, 0xe8, 0xf4, 0x4d, 0xcf, 0x83, 0x13, 0xc6, 0xdf, 0xa0, 0xe3,
+	0xe9, 0x4c, 0x8c, 0x8a, 0x94, 0x34, 0x7b, 0xd5, 0x60, 0x6d, 0x62, 0xc5, 0x1b, 0xb8, 0xef, 0xd1,
+	0x75, 0xf1, 0x20, 0xfe, 0xdc, 0xa3, 0x91, 0xda, 0x6c, 0x53, 0x82, 0xa4, 0xbc, 0xc8, 0xc6, 0xe6,
+	0xc8, 0xe9, 0x33, 0x00, 0x87, 0x5b, 0xed, 0x03, 0x3a, 0x54, 0x29, 0x53, 0x52, 0x8e, 0x48, 0x7c,
+	0x0c, 0x72, 0x67, 0x3d, 0xaa, 0x94, 0x29, 0x29, 0x07, 0x95, 0x56, 0xfe, 0x0b, 0xb1, 0xe5, 0xc1,
+	0xb2, 0xf3, 0x61, 0xe5, 0x51, 0x57, 0xf6, 0x64, 0x19, 0x77, 0x4f, 0x96, 0xf1, 0x7a, 0x0d, 0xc5,
+	0x27, 0xed, 0x50, 0x06, 0x6d, 0x0d, 0x2d, 0xe0, 0xec, 0xea, 0xcb, 0xb7, 0xeb, 0xea, 0xe2, 0x0e,
+	0x05, 0x58, 0x7c, 0xbd, 0xae, 0xe2, 0xf7, 0xa4, 0x5e, 0xa4, 0x57, 0xfe, 0xfe, 0xdf, 0x00, 0x19,
+	0xb5, 0xfa, 0xe4, 0xf3, 0x02, 0x00, 0x00,
 }
@@ -50,6 +50,8 @@ type Txn struct {
 	SettingsId uint64 `protobuf:"varint,12,opt,name=settings_id,json=settingsId,proto3" json:"settings_id,omitempty"`
 	// Transaction sign via public key
 	Sign []byte `protobuf:"bytes,13,opt,name=sign,proto3" json:"sign,omitempty"`
+	// Timestamp of backend processing, unix nanoseconds
+	ProcessedAt int64 `protobuf:"varint,15,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
 	// Hash of important fields
 	Hash []byte `protobuf:"bytes,21,opt,name=hash,proto3" json:"hash,omitempty"`
 }
@@ -122,6 +124,13 @@ func (m *Txn) GetSign() []byte {
 	return nil
 }
 
+func (m *Txn) GetProcessedAt() int64 {
+	if m != nil {
+		return m.ProcessedAt
+	}
+	return 0
+}
+
 func (m *Txn) GetHash() []byte {
 	if m != nil {
 		return m.Hash
@@ -147,6 +156,16 @@ type Settings struct {
 	Hash []byte `protobuf:"bytes,7,opt,name=hash,proto3" json:"hash,omitempty"`
 	// Flag to verify transactions sign
 	VerifyTransferSign bool `protobuf:"varint,8,opt,name=verify_transfer_sign,json=verifyTransferSign,proto3" json:"verify_transfer_sign,omitempty"`
+	// Flag to chain transactions in order they come ignoring request prev_hash
+	ServerSequencing bool `protobuf:"varint,9,opt,name=server_sequencing,json=serverSequencing,proto3" json:"server_sequencing,omitempty"`
+	// Account was explicitly registered
+	Registered bool `protobuf:"varint,10,opt,name=registered,proto3" json:"registered,omitempty"`
+	// Account is closed, no more activity allowed
+	Closed bool `protobuf:"varint,11,opt,name=closed,proto3" json:"closed,omitempty"`
+	// Account balance was swept to on close
+	SweepTo uint64 `protobuf:"varint,12,opt,name=sweep_to,json=sweepTo,proto3" json:"sweep_to,omitempty"`
+	// Timestamp of backend processing, unix nanoseconds
+	ProcessedAt int64 `protobuf:"varint,13,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
 }
 
 func (m *Settings) Reset()                    { *m = Settings{} }
@@ -210,6 +229,41 @@ func (m *Settings) GetVerifyTransferSign() bool {
 	return false
 }
 
+func (m *Settings) GetServerSequencing() bool {
+	if m != nil {
+		return m.ServerSequencing
+	}
+	return false
+}
+
+func (m *Settings) GetRegistered() bool {
+	if m != nil {
+		return m.Registered
+	}
+	return false
+}
+
+func (m *Settings) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+func (m *Settings) GetSweepTo() uint64 {
+	if m != nil {
+		return m.SweepTo
+	}
+	return 0
+}
+
+func (m *Settings) GetProcessedAt() int64 {
+	if m != nil {
+		return m.ProcessedAt
+	}
+	return 0
+}
+
 // TxnID is am ID of transaction
 type TxnID struct {
 	// Account
@@ -246,27 +300,32 @@ func init() {
 func init() { proto.RegisterFile("chain.proto", fileDescriptorChain) }
 
 var fileDescriptorChain = []byte{
-	// 344 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x4d, 0x6f, 0xe2, 0x30,
-	0x10, 0x95, 0x43, 0xc8, 0xc7, 0xc0, 0xee, 0xc1, 0xda, 0x5d, 0x79, 0x8b, 0xaa, 0x46, 0x9c, 0x72,
-	0xaa, 0x5a, 0xf5, 0x1f, 0x20, 0x0e, 0x45, 0xbd, 0x05, 0x4e, 0xbd, 0x44, 0x4e, 0x62, 0x88, 0x55,
-	0x6a, 0x22, 0xdb, 0x20, 0xf2, 0x8b, 0xfb, 0x27, 0x7a, 0xa8, 0x32, 0x0e, 0x54, 0xb4, 0xbd, 0xcd,
-	0x7b, 0x4f, 0x6f, 0xf4, 0xde, 0x68, 0x60, 0x54, 0xd6, 0x5c, 0xaa, 0xdb, 0x46, 0xef, 0xec, 0x8e,
-	0x0e, 0x11, 0x4c, 0xdf, 0x09, 0x0c, 0x56, 0x47, 0x45, 0x7f, 0x83, 0xb7, 0x98, 0x33, 0x92, 0x90,
-	0xd4, 0xcf, 0xbc, 0xc5, 0x9c, 0xfe, 0x83, 0xc0, 0x08, 0x55, 0x09, 0xcd, 0x7c, 0xe4, 0x7a, 0x44,
-	0xaf, 0x20, 0xd2, 0xa2, 0x14, 0xf2, 0x20, 0x34, 0x1b, 0xa2, 0x72, 0xc6, 0x9d, 0x87, 0xbf, 0xee,
-	0xf6, 0xca, 0xb2, 0x20, 0x21, 0xe9, 0x20, 0xeb, 0x11, 0x65, 0x10, 0x16, 0x7c, 0xcb, 0x55, 0x29,
-	0x58, 0x88, 0xc2, 0x09, 0xd2, 0xff, 0x10, 0x99, 0x46, 0x28, 0x9b, 0x17, 0x2d, 0x8b, 0x71, 0x5b,
-	0x88, 0x78, 0xd6, 0xd2, 0x09, 0xc4, 0x8d, 0x16, 0x87, 0xbc, 0xe6, 0xa6, 0x66, 0x90, 0x90, 0x74,
-	0x9c, 0x45, 0x1d, 0xf1, 0xc8, 0x4d, 0x4d, 0x6f, 0x60, 0x64, 0x84, 0xb5, 0x52, 0x6d, 0x4c, 0x2e,
-	0x2b, 0x36, 0x46, 0x2b, 0x9c, 0xa8, 0x45, 0x45, 0x29, 0xf8, 0x46, 0x6e, 0x14, 0xfb, 0x85, 0x46,
-	0x9c, 0x3b, 0x0e, 0x97, 0xfd, 0x75, 0x5c, 0x37, 0x4f, 0xdf, 0x08, 0x44, 0xcb, 0xde, 0xf6, 0xed,
-	0x06, 0x0c, 0x42, 0x5e, 0x96, 0x58, 0xc8, 0x73, 0xe1, 0x7a, 0x78, 0x19, 0x6e, 0xf0, 0x25, 0xdc,
-	0x04, 0xe2, 0x8a, 0x5b, 0xee, 0x44, 0xdf, 0x89, 0x1d, 0x81, 0xe2, 0x35, 0x40, 0xb3, 0x2f, 0xb6,
-	0xb2, 0xcc, 0x5f, 0x44, 0x8b, 0x17, 0x1c, 0x67, 0xb1, 0x63, 0x9e, 0x44, 0x7b, 0xce, 0x1d, 0xfc,
-	0x90, 0x3b, 0xfc, 0xcc, 0x4d, 0xef, 0xe0, 0xcf, 0x41, 0x68, 0xb9, 0x6e, 0x73, 0xab, 0xb9, 0x32,
-	0x6b, 0xa1, 0x73, 0xf4, 0x45, 0x09, 0x49, 0xa3, 0x8c, 0x3a, 0x6d, 0xd5, 0x4b, 0x4b, 0xb9, 0x51,
-	0xd3, 0x7b, 0x18, 0xae, 0x8e, 0xea, 0xb2, 0x15, 0xb9, 0x6c, 0xe5, 0xfa, 0x7b, 0xa7, 0xfe, 0xb3,
-	0xf8, 0x39, 0xc4, 0x27, 0x69, 0x8a, 0x22, 0xc0, 0xa7, 0x79, 0xf8, 0x08, 0x00, 0x00, 0xff, 0xff,
-	0xb7, 0x4d, 0xf5, 0xa7, 0x43, 0x02, 0x00, 0x00,
+	// 432 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x52, 0x4d, 0x8f, 0xd3, 0x30,
+	0x14, 0x54, 0xd2, 0x8f, 0x24, 0xaf, 0x5d, 0x3e, 0x2c, 0x40, 0x86, 0x15, 0x50, 0x7a, 0xaa, 0x84,
+	0x84, 0x40, 0xfc, 0x02, 0x56, 0x7b, 0xa0, 0xe2, 0x96, 0xf6, 0xc4, 0x25, 0x72, 0x9c, 0xb7, 0xad,
+	0x45, 0x71, 0x82, 0xed, 0x96, 0xcd, 0x5f, 0xe0, 0x4f, 0xf0, 0x57, 0x91, 0x9f, 0xd3, 0xd0, 0x6e,
+	0x6f, 0x9e, 0x99, 0x8c, 0x33, 0x7e, 0xf3, 0x60, 0x22, 0xb7, 0x42, 0xe9, 0x0f, 0x8d, 0xa9, 0x5d,
+	0xcd, 0x46, 0x04, 0xe6, 0x7f, 0x63, 0x18, 0xac, 0xef, 0x35, 0x7b, 0x04, 0xf1, 0xf2, 0x96, 0x47,
+	0xb3, 0x68, 0x31, 0xcc, 0xe3, 0xe5, 0x2d, 0x7b, 0x01, 0x63, 0x8b, 0xba, 0x42, 0xc3, 0x87, 0xc4,
+	0x75, 0x88, 0xbd, 0x82, 0xd4, 0xa0, 0x44, 0x75, 0x40, 0xc3, 0x47, 0xa4, 0xf4, 0xd8, 0x7b, 0xc4,
+	0xcf, 0x7a, 0xaf, 0x1d, 0x1f, 0xcf, 0xa2, 0xc5, 0x20, 0xef, 0x10, 0xe3, 0x90, 0x94, 0x62, 0x27,
+	0xb4, 0x44, 0x9e, 0x90, 0x70, 0x84, 0xec, 0x25, 0xa4, 0xb6, 0x41, 0xed, 0x8a, 0xb2, 0xe5, 0x19,
+	0xdd, 0x96, 0x10, 0xbe, 0x69, 0xd9, 0x35, 0x64, 0x8d, 0xc1, 0x43, 0xb1, 0x15, 0x76, 0xcb, 0x61,
+	0x16, 0x2d, 0xa6, 0x79, 0xea, 0x89, 0xaf, 0xc2, 0x6e, 0xd9, 0x5b, 0x98, 0x58, 0x74, 0x4e, 0xe9,
+	0x8d, 0x2d, 0x54, 0xc5, 0xa7, 0x64, 0x85, 0x23, 0xb5, 0xac, 0x18, 0x83, 0xa1, 0x55, 0x1b, 0xcd,
+	0xaf, 0xc8, 0x48, 0x67, 0xf6, 0x0e, 0xa6, 0x8d, 0xa9, 0x25, 0x5a, 0x8b, 0x55, 0x21, 0x1c, 0x7f,
+	0x4c, 0x59, 0x26, 0x3d, 0xf7, 0xc5, 0x79, 0x1b, 0xfd, 0xef, 0x79, 0xb0, 0xf9, 0xf3, 0xfc, 0xcf,
+	0x00, 0xd2, 0x55, 0x77, 0xf3, 0xc5, 0x98, 0x38, 0x24, 0x42, 0x4a, 0x7a, 0x73, 0x1c, 0xf2, 0x77,
+	0xf0, 0x3c, 0xff, 0xe0, 0x41, 0xfe, 0x6b, 0xc8, 0x2a, 0xe1, 0x44, 0x10, 0x87, 0x41, 0xf4, 0x04,
+	0x89, 0xaf, 0x01, 0x9a, 0x7d, 0xb9, 0x53, 0xb2, 0xf8, 0x81, 0x2d, 0x0d, 0x79, 0x9a, 0x67, 0x81,
+	0xf9, 0x86, 0x6d, 0xff, 0xb4, 0xf1, 0xc9, 0xd3, 0x8e, 0xb9, 0x93, 0xff, 0xb9, 0xd9, 0x47, 0x78,
+	0x76, 0x40, 0xa3, 0xee, 0xda, 0xc2, 0x19, 0xa1, 0xed, 0x1d, 0x9a, 0x82, 0x7c, 0xe9, 0x2c, 0x5a,
+	0xa4, 0x39, 0x0b, 0xda, 0xba, 0x93, 0x56, 0xfe, 0x96, 0xf7, 0xf0, 0xd4, 0xa2, 0x39, 0xf8, 0x0f,
+	0xf1, 0xd7, 0x1e, 0xb5, 0x54, 0x7a, 0x43, 0xb5, 0xa4, 0xf9, 0x93, 0x20, 0xac, 0x7a, 0x9e, 0xbd,
+	0x01, 0x30, 0xb8, 0x51, 0xd6, 0xa1, 0xc1, 0x8a, 0x0a, 0x4a, 0xf3, 0x13, 0xc6, 0x2f, 0x83, 0xdc,
+	0xd5, 0x16, 0x2b, 0x3e, 0x21, 0xad, 0x43, 0x54, 0xf9, 0x6f, 0xc4, 0xa6, 0x70, 0x75, 0xd7, 0x5b,
+	0x42, 0x78, 0x5d, 0x5f, 0x14, 0x74, 0x75, 0x51, 0xd0, 0xfc, 0x13, 0x8c, 0xd6, 0xf7, 0xfa, 0x7c,
+	0xf0, 0xd1, 0xf9, 0xe0, 0x43, 0x45, 0xf1, 0xb1, 0xa2, 0x9b, 0xec, 0x7b, 0x42, 0xab, 0xde, 0x94,
+	0xe5, 0x98, 0x56, 0xff, 0xf3, 0xbf, 0x01, 0x00, 0x96, 0x3b, 0x81, 0x7f, 0x09, 0x03, 0x00, 0x00,
 }
@@ -28,6 +28,10 @@ It has these top-level messages:
 	SettingsResponse
 	GetLastSettingsRequest
 	GetLastSettingsResponse
+	CloseAccountRequest
+	CloseAccountResponse
+	GetPendingIncomingRequest
+	GetPendingIncomingResponse
 */
 package gatepb
 
@@ -35,6 +39,7 @@ import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/any"
+import chain "github.com/qiwitech/qdp/proto/chainpb"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -58,17 +63,21 @@ const (
 	TransferCode_INTERNAL_ERROR    TransferCode = 5
 	TransferCode_SEE_OTHER         TransferCode = 6
 	TransferCode_RETRY             TransferCode = 7
+	TransferCode_ACCOUNT_CLOSED    TransferCode = 9
+	TransferCode_INVALID_RECEIVER  TransferCode = 10
 )
 
 var TransferCode_name = map[int32]string{
-	0: "OK",
-	1: "INVALID_PREV_HASH",
-	2: "INVALID_SIGN",
-	3: "BAD_REQUEST",
-	4: "NO_BALANCE",
-	5: "INTERNAL_ERROR",
-	6: "SEE_OTHER",
-	7: "RETRY",
+	0:  "OK",
+	1:  "INVALID_PREV_HASH",
+	2:  "INVALID_SIGN",
+	3:  "BAD_REQUEST",
+	4:  "NO_BALANCE",
+	5:  "INTERNAL_ERROR",
+	6:  "SEE_OTHER",
+	7:  "RETRY",
+	9:  "ACCOUNT_CLOSED",
+	10: "INVALID_RECEIVER",
 }
 var TransferCode_value = map[string]int32{
 	"OK":                0,
@@ -79,6 +88,8 @@ var TransferCode_value = map[string]int32{
 	"INTERNAL_ERROR":    5,
 	"SEE_OTHER":         6,
 	"RETRY":             7,
+	"ACCOUNT_CLOSED":    9,
+	"INVALID_RECEIVER":  10,
 }
 
 func (x TransferCode) String() string {
@@ -129,6 +140,8 @@ type RouteMap struct {
 	Version uint32   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	Target  string   `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
 	Nodes   []string `protobuf:"bytes,4,rep,name=nodes" json:"nodes,omitempty"`
+	// Routing table version. It's monotonically increasing, older tables are rejected.
+	Epoch uint64 `protobuf:"varint,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
 }
 
 func (m *RouteMap) Reset()                    { *m = RouteMap{} }
@@ -164,6 +177,13 @@ func (m *RouteMap) GetNodes() []string {
 	return nil
 }
 
+func (m *RouteMap) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
 type TestRouteMapAnotherType struct {
 	Type    uint32   `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
 	Version uint32   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
@@ -239,6 +259,9 @@ type TransferRequest struct {
 	// Hash sum of the previous transaction (omit or "" if first)
 	PrevHash string `protobuf:"bytes,4,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
 	Sign     string `protobuf:"bytes,5,opt,name=sign,proto3" json:"sign,omitempty"`
+	// Routing table epoch the request was routed with (0 if unknown)
+	RouteEpoch uint64 `protobuf:"varint,6,opt,name=route_epoch,json=routeEpoch,proto3" json:"route_epoch,omitempty"`
+	Hops       uint32 `protobuf:"varint,7,opt,name=hops,proto3" json:"hops,omitempty"`
 }
 
 func (m *TransferRequest) Reset()                    { *m = TransferRequest{} }
@@ -281,6 +304,20 @@ func (m *TransferRequest) GetSign() string {
 	return ""
 }
 
+func (m *TransferRequest) GetRouteEpoch() uint64 {
+	if m != nil {
+		return m.RouteEpoch
+	}
+	return 0
+}
+
+func (m *TransferRequest) GetHops() uint32 {
+	if m != nil {
+		return m.Hops
+	}
+	return 0
+}
+
 type TransferResponse struct {
 	Status     *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
 	TxnId      string  `protobuf:"bytes,2,opt,name=txn_id,json=txnId,proto3" json:"txn_id,omitempty"`
@@ -338,7 +375,9 @@ func (m *TransferResponse) GetSettingsId() uint64 {
 }
 
 type GetPrevHashRequest struct {
-	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	Account    uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	RouteEpoch uint64 `protobuf:"varint,2,opt,name=route_epoch,json=routeEpoch,proto3" json:"route_epoch,omitempty"`
+	Hops       uint32 `protobuf:"varint,3,opt,name=hops,proto3" json:"hops,omitempty"`
 }
 
 func (m *GetPrevHashRequest) Reset()                    { *m = GetPrevHashRequest{} }
@@ -353,6 +392,20 @@ func (m *GetPrevHashRequest) GetAccount() uint64 {
 	return 0
 }
 
+func (m *GetPrevHashRequest) GetRouteEpoch() uint64 {
+	if m != nil {
+		return m.RouteEpoch
+	}
+	return 0
+}
+
+func (m *GetPrevHashRequest) GetHops() uint32 {
+	if m != nil {
+		return m.Hops
+	}
+	return 0
+}
+
 type GetPrevHashResponse struct {
 	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
 	Hash   string  `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
@@ -378,7 +431,9 @@ func (m *GetPrevHashResponse) GetHash() string {
 }
 
 type GetBalanceRequest struct {
-	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	Account    uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	RouteEpoch uint64 `protobuf:"varint,2,opt,name=route_epoch,json=routeEpoch,proto3" json:"route_epoch,omitempty"`
+	Hops       uint32 `protobuf:"varint,3,opt,name=hops,proto3" json:"hops,omitempty"`
 }
 
 func (m *GetBalanceRequest) Reset()                    { *m = GetBalanceRequest{} }
@@ -393,6 +448,20 @@ func (m *GetBalanceRequest) GetAccount() uint64 {
 	return 0
 }
 
+func (m *GetBalanceRequest) GetRouteEpoch() uint64 {
+	if m != nil {
+		return m.RouteEpoch
+	}
+	return 0
+}
+
+func (m *GetBalanceRequest) GetHops() uint32 {
+	if m != nil {
+		return m.Hops
+	}
+	return 0
+}
+
 type GetBalanceResponse struct {
 	Status  *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
 	Balance int64   `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
@@ -424,6 +493,10 @@ type SettingsRequest struct {
 	DataHash           string `protobuf:"bytes,4,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
 	Sign               string `protobuf:"bytes,5,opt,name=sign,proto3" json:"sign,omitempty"`
 	VerifyTransferSign bool   `protobuf:"varint,6,opt,name=verify_transfer_sign,json=verifyTransferSign,proto3" json:"verify_transfer_sign,omitempty"`
+	RouteEpoch         uint64 `protobuf:"varint,7,opt,name=route_epoch,json=routeEpoch,proto3" json:"route_epoch,omitempty"`
+	Hops               uint32 `protobuf:"varint,8,opt,name=hops,proto3" json:"hops,omitempty"`
+	ServerSequencing   bool   `protobuf:"varint,9,opt,name=server_sequencing,json=serverSequencing,proto3" json:"server_sequencing,omitempty"`
+	Registered         bool   `protobuf:"varint,10,opt,name=registered,proto3" json:"registered,omitempty"`
 }
 
 func (m *SettingsRequest) Reset()                    { *m = SettingsRequest{} }
@@ -473,6 +546,34 @@ func (m *SettingsRequest) GetVerifyTransferSign() bool {
 	return false
 }
 
+func (m *SettingsRequest) GetRouteEpoch() uint64 {
+	if m != nil {
+		return m.RouteEpoch
+	}
+	return 0
+}
+
+func (m *SettingsRequest) GetHops() uint32 {
+	if m != nil {
+		return m.Hops
+	}
+	return 0
+}
+
+func (m *SettingsRequest) GetServerSequencing() bool {
+	if m != nil {
+		return m.ServerSequencing
+	}
+	return false
+}
+
+func (m *SettingsRequest) GetRegistered() bool {
+	if m != nil {
+		return m.Registered
+	}
+	return false
+}
+
 type SettingsResponse struct {
 	Status     *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
 	SettingsId string  `protobuf:"bytes,2,opt,name=settings_id,json=settingsId,proto3" json:"settings_id,omitempty"`
@@ -506,7 +607,9 @@ func (m *SettingsResponse) GetHash() string {
 }
 
 type GetLastSettingsRequest struct {
-	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	Account    uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	RouteEpoch uint64 `protobuf:"varint,2,opt,name=route_epoch,json=routeEpoch,proto3" json:"route_epoch,omitempty"`
+	Hops       uint32 `protobuf:"varint,3,opt,name=hops,proto3" json:"hops,omitempty"`
 }
 
 func (m *GetLastSettingsRequest) Reset()         { *m = GetLastSettingsRequest{} }
@@ -523,6 +626,20 @@ func (m *GetLastSettingsRequest) GetAccount() uint64 {
 	return 0
 }
 
+func (m *GetLastSettingsRequest) GetRouteEpoch() uint64 {
+	if m != nil {
+		return m.RouteEpoch
+	}
+	return 0
+}
+
+func (m *GetLastSettingsRequest) GetHops() uint32 {
+	if m != nil {
+		return m.Hops
+	}
+	return 0
+}
+
 type GetLastSettingsResponse struct {
 	Status             *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
 	Id                 uint64  `protobuf:"varint,4,opt,name=id,proto3" json:"id,omitempty"`
@@ -533,6 +650,10 @@ type GetLastSettingsResponse struct {
 	DataHash           string  `protobuf:"bytes,9,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
 	Sign               string  `protobuf:"bytes,10,opt,name=sign,proto3" json:"sign,omitempty"`
 	VerifyTransferSign bool    `protobuf:"varint,11,opt,name=verify_transfer_sign,json=verifyTransferSign,proto3" json:"verify_transfer_sign,omitempty"`
+	ServerSequencing   bool    `protobuf:"varint,12,opt,name=server_sequencing,json=serverSequencing,proto3" json:"server_sequencing,omitempty"`
+	Registered         bool    `protobuf:"varint,13,opt,name=registered,proto3" json:"registered,omitempty"`
+	Closed             bool    `protobuf:"varint,14,opt,name=closed,proto3" json:"closed,omitempty"`
+	SweepTo            uint64  `protobuf:"varint,15,opt,name=sweep_to,json=sweepTo,proto3" json:"sweep_to,omitempty"`
 }
 
 func (m *GetLastSettingsResponse) Reset()         { *m = GetLastSettingsResponse{} }
@@ -605,6 +726,200 @@ func (m *GetLastSettingsResponse) GetVerifyTransferSign() bool {
 	return false
 }
 
+func (m *GetLastSettingsResponse) GetServerSequencing() bool {
+	if m != nil {
+		return m.ServerSequencing
+	}
+	return false
+}
+
+func (m *GetLastSettingsResponse) GetRegistered() bool {
+	if m != nil {
+		return m.Registered
+	}
+	return false
+}
+
+func (m *GetLastSettingsResponse) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+func (m *GetLastSettingsResponse) GetSweepTo() uint64 {
+	if m != nil {
+		return m.SweepTo
+	}
+	return 0
+}
+
+type CloseAccountRequest struct {
+	Account    uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	SweepTo    uint64 `protobuf:"varint,2,opt,name=sweep_to,json=sweepTo,proto3" json:"sweep_to,omitempty"`
+	PrevHash   string `protobuf:"bytes,3,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Sign       string `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`
+	RouteEpoch uint64 `protobuf:"varint,5,opt,name=route_epoch,json=routeEpoch,proto3" json:"route_epoch,omitempty"`
+	Hops       uint32 `protobuf:"varint,6,opt,name=hops,proto3" json:"hops,omitempty"`
+}
+
+func (m *CloseAccountRequest) Reset()                    { *m = CloseAccountRequest{} }
+func (m *CloseAccountRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloseAccountRequest) ProtoMessage()               {}
+func (*CloseAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptorGateService, []int{14} }
+
+func (m *CloseAccountRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *CloseAccountRequest) GetSweepTo() uint64 {
+	if m != nil {
+		return m.SweepTo
+	}
+	return 0
+}
+
+func (m *CloseAccountRequest) GetPrevHash() string {
+	if m != nil {
+		return m.PrevHash
+	}
+	return ""
+}
+
+func (m *CloseAccountRequest) GetSign() string {
+	if m != nil {
+		return m.Sign
+	}
+	return ""
+}
+
+func (m *CloseAccountRequest) GetRouteEpoch() uint64 {
+	if m != nil {
+		return m.RouteEpoch
+	}
+	return 0
+}
+
+func (m *CloseAccountRequest) GetHops() uint32 {
+	if m != nil {
+		return m.Hops
+	}
+	return 0
+}
+
+type CloseAccountResponse struct {
+	Status     *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	SettingsId string  `protobuf:"bytes,2,opt,name=settings_id,json=settingsId,proto3" json:"settings_id,omitempty"`
+	Hash       string  `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	TxnId      string  `protobuf:"bytes,4,opt,name=txn_id,json=txnId,proto3" json:"txn_id,omitempty"`
+	TxnHash    string  `protobuf:"bytes,5,opt,name=txn_hash,json=txnHash,proto3" json:"txn_hash,omitempty"`
+}
+
+func (m *CloseAccountResponse) Reset()         { *m = CloseAccountResponse{} }
+func (m *CloseAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*CloseAccountResponse) ProtoMessage()    {}
+func (*CloseAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorGateService, []int{15}
+}
+
+func (m *CloseAccountResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *CloseAccountResponse) GetSettingsId() string {
+	if m != nil {
+		return m.SettingsId
+	}
+	return ""
+}
+
+func (m *CloseAccountResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *CloseAccountResponse) GetTxnId() string {
+	if m != nil {
+		return m.TxnId
+	}
+	return ""
+}
+
+func (m *CloseAccountResponse) GetTxnHash() string {
+	if m != nil {
+		return m.TxnHash
+	}
+	return ""
+}
+
+type GetPendingIncomingRequest struct {
+	Account    uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	RouteEpoch uint64 `protobuf:"varint,2,opt,name=route_epoch,json=routeEpoch,proto3" json:"route_epoch,omitempty"`
+	Hops       uint32 `protobuf:"varint,3,opt,name=hops,proto3" json:"hops,omitempty"`
+}
+
+func (m *GetPendingIncomingRequest) Reset()         { *m = GetPendingIncomingRequest{} }
+func (m *GetPendingIncomingRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPendingIncomingRequest) ProtoMessage()    {}
+func (*GetPendingIncomingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorGateService, []int{16}
+}
+
+func (m *GetPendingIncomingRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *GetPendingIncomingRequest) GetRouteEpoch() uint64 {
+	if m != nil {
+		return m.RouteEpoch
+	}
+	return 0
+}
+
+func (m *GetPendingIncomingRequest) GetHops() uint32 {
+	if m != nil {
+		return m.Hops
+	}
+	return 0
+}
+
+type GetPendingIncomingResponse struct {
+	Status *Status      `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	Txns   []*chain.Txn `protobuf:"bytes,2,rep,name=txns" json:"txns,omitempty"`
+}
+
+func (m *GetPendingIncomingResponse) Reset()         { *m = GetPendingIncomingResponse{} }
+func (m *GetPendingIncomingResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPendingIncomingResponse) ProtoMessage()    {}
+func (*GetPendingIncomingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorGateService, []int{17}
+}
+
+func (m *GetPendingIncomingResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetPendingIncomingResponse) GetTxns() []*chain.Txn {
+	if m != nil {
+		return m.Txns
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Status)(nil), "gate.Status")
 	proto.RegisterType((*RouteMap)(nil), "gate.RouteMap")
@@ -620,67 +935,92 @@ func init() {
 	proto.RegisterType((*SettingsResponse)(nil), "gate.SettingsResponse")
 	proto.RegisterType((*GetLastSettingsRequest)(nil), "gate.GetLastSettingsRequest")
 	proto.RegisterType((*GetLastSettingsResponse)(nil), "gate.GetLastSettingsResponse")
+	proto.RegisterType((*CloseAccountRequest)(nil), "gate.CloseAccountRequest")
+	proto.RegisterType((*CloseAccountResponse)(nil), "gate.CloseAccountResponse")
+	proto.RegisterType((*GetPendingIncomingRequest)(nil), "gate.GetPendingIncomingRequest")
+	proto.RegisterType((*GetPendingIncomingResponse)(nil), "gate.GetPendingIncomingResponse")
 	proto.RegisterEnum("gate.TransferCode", TransferCode_name, TransferCode_value)
 }
 
 func init() { proto.RegisterFile("gate_service.proto", fileDescriptorGateService) }
 
 var fileDescriptorGateService = []byte{
-	// 886 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdd, 0x6e, 0xe2, 0x46,
-	0x14, 0x2e, 0x60, 0x0c, 0x1c, 0x12, 0xf0, 0x9e, 0xe6, 0xc7, 0xcb, 0x76, 0xd5, 0xc8, 0xaa, 0x2a,
-	0x54, 0xa9, 0x6c, 0x45, 0x1f, 0xa0, 0x85, 0x5d, 0x2b, 0x41, 0x9b, 0x42, 0x3a, 0xb0, 0x2b, 0xb5,
-	0x37, 0xd6, 0x80, 0x4f, 0xc0, 0x6a, 0x62, 0x53, 0xcf, 0x80, 0x96, 0x3e, 0x44, 0x9f, 0xa0, 0xb7,
-	0xbd, 0xed, 0x83, 0xf4, 0x51, 0xfa, 0x14, 0x95, 0xc7, 0x1e, 0x08, 0xe4, 0x67, 0x93, 0x8b, 0xbd,
-	0xf3, 0xf9, 0xf1, 0x7c, 0xdf, 0x77, 0xce, 0xe7, 0x01, 0xc0, 0x29, 0x97, 0xe4, 0x09, 0x8a, 0x97,
-	0xc1, 0x84, 0x5a, 0xf3, 0x38, 0x92, 0x11, 0x1a, 0x49, 0xae, 0xf1, 0x7c, 0x1a, 0x45, 0xd3, 0x2b,
-	0x7a, 0xa5, 0x72, 0xe3, 0xc5, 0xe5, 0x2b, 0x1e, 0xae, 0xd2, 0x06, 0xe7, 0x0f, 0x30, 0x87, 0x92,
-	0xcb, 0x85, 0xc0, 0xaf, 0xc1, 0x98, 0x44, 0x3e, 0xd9, 0xb9, 0x93, 0x5c, 0xb3, 0xd6, 0xc6, 0x56,
-	0xf2, 0x66, 0x6b, 0x14, 0xf3, 0x50, 0x5c, 0x52, 0xfc, 0x3a, 0xf2, 0x89, 0xa9, 0x3a, 0xda, 0x50,
-	0xba, 0x26, 0x21, 0xf8, 0x94, 0xec, 0xfc, 0x49, 0xae, 0x59, 0x61, 0x3a, 0xc4, 0x16, 0x94, 0x7c,
-	0x92, 0x3c, 0xb8, 0x12, 0x76, 0xe1, 0xa4, 0xd0, 0xac, 0xb6, 0x0f, 0x5a, 0x29, 0x70, 0x4b, 0x03,
-	0xb7, 0x3a, 0xe1, 0x8a, 0xe9, 0x26, 0xe7, 0x12, 0xca, 0x2c, 0x5a, 0x48, 0xfa, 0x89, 0xcf, 0x11,
-	0xc1, 0x90, 0xab, 0x79, 0x8a, 0xbe, 0xcf, 0xd4, 0x73, 0x82, 0xb4, 0xa4, 0x58, 0x04, 0x51, 0xa8,
-	0x90, 0xf6, 0x99, 0x0e, 0xf1, 0x08, 0x4c, 0xc9, 0xe3, 0x29, 0x49, 0xbb, 0xa0, 0x28, 0x64, 0x11,
-	0x1e, 0x40, 0x31, 0x8c, 0x7c, 0x12, 0xb6, 0x71, 0x52, 0x68, 0x56, 0x58, 0x1a, 0x38, 0x0b, 0x38,
-	0x1e, 0x91, 0x90, 0x1a, 0xab, 0x13, 0x46, 0x72, 0x46, 0xf1, 0x28, 0x81, 0xf8, 0x94, 0xb0, 0x5d,
-	0xd8, 0xd3, 0xe3, 0xeb, 0x49, 0xba, 0xc6, 0x06, 0x94, 0x63, 0x9a, 0x50, 0xb0, 0xa4, 0x58, 0xe1,
-	0x19, 0x6c, 0x1d, 0x27, 0x27, 0xf3, 0xeb, 0x68, 0x11, 0x4a, 0x05, 0x59, 0x60, 0x59, 0xe4, 0xfc,
-	0x9d, 0x83, 0xba, 0x3e, 0x84, 0xd1, 0xef, 0x0b, 0x12, 0x32, 0xe9, 0x15, 0x14, 0xfa, 0xeb, 0x53,
-	0xb2, 0x08, 0x9b, 0x50, 0x1c, 0x73, 0x39, 0x99, 0xd9, 0x79, 0x35, 0xfc, 0x9d, 0x0d, 0x26, 0x14,
-	0x58, 0xda, 0x80, 0x5f, 0x42, 0x55, 0x90, 0x94, 0x41, 0x38, 0x15, 0x5e, 0xe0, 0x2b, 0x31, 0x06,
-	0x03, 0x9d, 0xea, 0xf9, 0xf8, 0x02, 0x2a, 0xf3, 0x98, 0x96, 0xde, 0x8c, 0x8b, 0x99, 0x6d, 0x28,
-	0xad, 0xe5, 0x24, 0x71, 0xc6, 0xc5, 0x2c, 0x99, 0x99, 0x08, 0xa6, 0xa1, 0x5d, 0x54, 0x79, 0xf5,
-	0xec, 0xfc, 0x93, 0x03, 0x6b, 0xc3, 0x53, 0xcc, 0xa3, 0x50, 0x10, 0x7e, 0x05, 0xa6, 0x50, 0xde,
-	0x52, 0x44, 0xab, 0xed, 0xbd, 0x94, 0x51, 0xea, 0x37, 0x96, 0xd5, 0xf0, 0x10, 0x4c, 0xf9, 0x21,
-	0x4c, 0x78, 0xa4, 0x76, 0x2a, 0xca, 0x0f, 0x61, 0xcf, 0x4f, 0x50, 0x14, 0x7a, 0x3a, 0x69, 0xf5,
-	0x9c, 0x6c, 0x86, 0x4f, 0x26, 0x6a, 0x4c, 0x86, 0xe2, 0xac, 0x43, 0xac, 0x41, 0x3e, 0xf0, 0x15,
-	0x23, 0x83, 0xe5, 0x03, 0x7f, 0x57, 0xa1, 0xb9, 0xab, 0xd0, 0x69, 0x01, 0x9e, 0x92, 0xbc, 0xc8,
-	0x34, 0xe9, 0xd1, 0xde, 0x00, 0xc8, 0x6d, 0x01, 0x38, 0x03, 0xf8, 0x7c, 0xab, 0xff, 0x49, 0x12,
-	0xb5, 0x96, 0xfc, 0x46, 0x8b, 0xf3, 0x2d, 0x3c, 0x3b, 0x25, 0xd9, 0xe5, 0x57, 0x3c, 0x9c, 0xd0,
-	0xc7, 0xf1, 0x47, 0x8a, 0xef, 0xba, 0xfd, 0x49, 0xf0, 0x36, 0x94, 0xc6, 0xe9, 0x8b, 0x99, 0xbb,
-	0x74, 0xe8, 0xfc, 0x9b, 0x83, 0xfa, 0x30, 0x1b, 0xca, 0x47, 0x39, 0xe0, 0x4b, 0x80, 0xf9, 0x62,
-	0x7c, 0x15, 0x4c, 0xbc, 0xdf, 0x68, 0x95, 0x89, 0xa9, 0xa4, 0x99, 0xb7, 0xb4, 0xda, 0x36, 0x4d,
-	0x61, 0xc7, 0x34, 0x2f, 0xa0, 0xe2, 0x73, 0xc9, 0xb7, 0x1c, 0x95, 0x24, 0xee, 0x73, 0x14, 0x7e,
-	0x07, 0x07, 0x4b, 0x8a, 0x83, 0xcb, 0x95, 0x27, 0x33, 0x5f, 0x79, 0xaa, 0x27, 0x59, 0x65, 0x99,
-	0x61, 0x5a, 0xd3, 0x96, 0x1b, 0x26, 0x1e, 0xbc, 0x06, 0x6b, 0xa3, 0xe5, 0x49, 0x03, 0xda, 0x71,
-	0x4b, 0xaa, 0xec, 0xe6, 0xf7, 0x70, 0x87, 0x19, 0x9d, 0x36, 0x1c, 0x9d, 0x92, 0x3c, 0xe7, 0x42,
-	0x3e, 0x7a, 0x82, 0xce, 0x5f, 0x79, 0x38, 0xbe, 0xf5, 0xd2, 0x93, 0xa8, 0xa6, 0x46, 0x37, 0xd6,
-	0x46, 0xd7, 0xcc, 0x8a, 0x77, 0x7f, 0x26, 0xe6, 0x43, 0x1b, 0x2c, 0x3d, 0xb8, 0xc1, 0xf2, 0x43,
-	0x1b, 0xac, 0xdc, 0xb3, 0x41, 0x78, 0xc4, 0x06, 0xab, 0xf7, 0x6d, 0xf0, 0x9b, 0x3f, 0x73, 0x9b,
-	0x2b, 0x33, 0xf9, 0xc5, 0x41, 0x13, 0xf2, 0x83, 0xb7, 0xd6, 0x67, 0x78, 0x08, 0xcf, 0x7a, 0xfd,
-	0xf7, 0x9d, 0xf3, 0xde, 0x1b, 0xef, 0x82, 0xb9, 0xef, 0xbd, 0xb3, 0xce, 0xf0, 0xcc, 0xca, 0xa1,
-	0x05, 0x7b, 0x3a, 0x3d, 0xec, 0x9d, 0xf6, 0xad, 0x3c, 0xd6, 0xa1, 0xda, 0xed, 0xbc, 0xf1, 0x98,
-	0xfb, 0xf3, 0x3b, 0x77, 0x38, 0xb2, 0x0a, 0x58, 0x03, 0xe8, 0x0f, 0xbc, 0x6e, 0xe7, 0xbc, 0xd3,
-	0x7f, 0xed, 0x5a, 0x06, 0x22, 0xd4, 0x7a, 0xfd, 0x91, 0xcb, 0xfa, 0x9d, 0x73, 0xcf, 0x65, 0x6c,
-	0xc0, 0xac, 0x22, 0xee, 0x43, 0x65, 0xe8, 0xba, 0xde, 0x60, 0x74, 0xe6, 0x32, 0xcb, 0xc4, 0x0a,
-	0x14, 0x99, 0x3b, 0x62, 0xbf, 0x58, 0xa5, 0xf6, 0x7f, 0x79, 0xb0, 0x2e, 0xe2, 0x68, 0x42, 0x42,
-	0x44, 0xf1, 0x30, 0xfd, 0x65, 0xc5, 0x1f, 0xa1, 0x9e, 0xe5, 0x34, 0x57, 0x3c, 0xdc, 0xbe, 0x6b,
-	0x33, 0x23, 0x34, 0x8e, 0x76, 0xd3, 0xd9, 0xaa, 0xbb, 0x50, 0xbd, 0x71, 0x99, 0xa0, 0x9d, 0xb6,
-	0xdd, 0xbe, 0x8f, 0x1a, 0xcf, 0xef, 0xa8, 0x64, 0x67, 0xfc, 0x00, 0xb0, 0xb9, 0x10, 0xf0, 0x78,
-	0xdd, 0xb8, 0x7d, 0xa3, 0x34, 0xec, 0xdb, 0x85, 0xf5, 0x01, 0xb5, 0x77, 0x73, 0x9f, 0x4b, 0xd2,
-	0x4e, 0xd4, 0x2a, 0x76, 0xec, 0xac, 0x55, 0xdc, 0x32, 0x6c, 0x1f, 0xea, 0x3b, 0x5e, 0xc6, 0x2f,
-	0xd6, 0x68, 0x77, 0x7c, 0x17, 0x8d, 0x97, 0xf7, 0x54, 0xd3, 0xf3, 0xba, 0xe5, 0x5f, 0xcd, 0xa4,
-	0x3e, 0x1f, 0x8f, 0x4d, 0xf5, 0x7f, 0xe1, 0xfb, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0xbb, 0x36,
-	0x7c, 0x4a, 0xd2, 0x08, 0x00, 0x00,
+	// 1218 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0xc6, 0xb6, 0x2c, 0xdb, 0x27, 0x7f, 0xea, 0x36, 0x4d, 0x15, 0x95, 0xb6, 0x19, 0x0d, 0xc3,
+	0x64, 0x60, 0xc6, 0x81, 0xf0, 0x00, 0xe0, 0xb8, 0x9a, 0xc4, 0xd3, 0x60, 0x97, 0xb5, 0x5b, 0x06,
+	0x6e, 0x34, 0xb2, 0xb4, 0x95, 0x05, 0xf1, 0x4a, 0xd5, 0xae, 0xd3, 0x98, 0xe1, 0x09, 0x78, 0x06,
+	0xee, 0xb8, 0x67, 0xb8, 0xe4, 0x21, 0x78, 0x02, 0x9e, 0x86, 0xd9, 0x5d, 0xc9, 0x96, 0x1d, 0x3b,
+	0x4d, 0x98, 0xc9, 0x55, 0x74, 0x7e, 0x7c, 0xbe, 0x73, 0xbe, 0xfd, 0xf6, 0x27, 0x80, 0x42, 0x8f,
+	0x13, 0x97, 0x91, 0xf4, 0x32, 0xf2, 0x49, 0x33, 0x49, 0x63, 0x1e, 0x23, 0x4d, 0xf8, 0xac, 0xfd,
+	0x30, 0x8e, 0xc3, 0x0b, 0x72, 0x24, 0x7d, 0xc3, 0xc9, 0xdb, 0x23, 0x8f, 0x4e, 0x55, 0x82, 0xf5,
+	0x65, 0x18, 0xf1, 0xd1, 0x64, 0xd8, 0xf4, 0xe3, 0xf1, 0xd1, 0xbb, 0xe8, 0x7d, 0xc4, 0x89, 0x3f,
+	0x3a, 0x7a, 0x17, 0x24, 0x2a, 0xf7, 0xc8, 0x1f, 0x79, 0x11, 0x4d, 0x86, 0xea, 0xaf, 0xfa, 0x89,
+	0xfd, 0x0b, 0xe8, 0x7d, 0xee, 0xf1, 0x09, 0x43, 0x9f, 0x82, 0xe6, 0xc7, 0x01, 0x31, 0x4b, 0x07,
+	0xa5, 0xc3, 0xed, 0x63, 0xd4, 0x14, 0x60, 0xcd, 0x41, 0xea, 0x51, 0xf6, 0x96, 0xa4, 0xed, 0x38,
+	0x20, 0x58, 0xc6, 0x91, 0x09, 0xb5, 0x31, 0x61, 0xcc, 0x0b, 0x89, 0x59, 0x3e, 0x28, 0x1d, 0x36,
+	0x70, 0x6e, 0xa2, 0x26, 0xd4, 0x02, 0xc2, 0xbd, 0xe8, 0x82, 0x99, 0x95, 0x83, 0xca, 0xe1, 0xc6,
+	0xf1, 0x6e, 0x53, 0xf5, 0xda, 0xcc, 0x7b, 0x6d, 0xb6, 0xe8, 0x14, 0xe7, 0x49, 0xf6, 0xaf, 0x50,
+	0xc7, 0xf1, 0x84, 0x93, 0x6f, 0xbd, 0x04, 0x21, 0xd0, 0xf8, 0x34, 0x51, 0xe8, 0x5b, 0x58, 0x7e,
+	0x0b, 0xa4, 0x4b, 0x92, 0xb2, 0x28, 0xa6, 0x12, 0x69, 0x0b, 0xe7, 0x26, 0xda, 0x03, 0x9d, 0x7b,
+	0x69, 0x48, 0xb8, 0x59, 0x91, 0x2d, 0x64, 0x16, 0xda, 0x85, 0x2a, 0x8d, 0x03, 0xc2, 0x4c, 0xed,
+	0xa0, 0x72, 0xd8, 0xc0, 0xca, 0x10, 0x5e, 0x92, 0xc4, 0xfe, 0xc8, 0xac, 0x1e, 0x94, 0x0e, 0x35,
+	0xac, 0x0c, 0x7b, 0x02, 0x8f, 0x07, 0x84, 0xf1, 0xbc, 0x83, 0x16, 0x8d, 0xf9, 0x88, 0xa4, 0x03,
+	0x01, 0x7c, 0x8f, 0xcd, 0xd8, 0x27, 0xb0, 0x99, 0x93, 0xda, 0xe1, 0x64, 0x8c, 0x2c, 0xa8, 0xa7,
+	0xc4, 0x27, 0xd1, 0x25, 0x49, 0x25, 0x9e, 0x86, 0x67, 0xb6, 0xa8, 0xec, 0x8d, 0xe3, 0x09, 0xe5,
+	0x12, 0xb2, 0x82, 0x33, 0xcb, 0xfe, 0xb7, 0x04, 0x3b, 0x79, 0x11, 0x4c, 0xde, 0x4d, 0x08, 0xe3,
+	0x22, 0x97, 0x11, 0x1a, 0xcc, 0xaa, 0x64, 0x16, 0x3a, 0x84, 0xea, 0xd0, 0xe3, 0xfe, 0xc8, 0x2c,
+	0xcb, 0x25, 0x59, 0x5a, 0x57, 0xd1, 0x02, 0x56, 0x09, 0xe8, 0x39, 0x6c, 0x30, 0xc2, 0x79, 0x44,
+	0x43, 0xe6, 0x46, 0x81, 0x1c, 0x46, 0xc3, 0x90, 0xbb, 0x3a, 0x01, 0x7a, 0x02, 0x8d, 0x24, 0x25,
+	0x97, 0xee, 0xc8, 0x63, 0x23, 0x53, 0x93, 0xb3, 0xd6, 0x85, 0xe3, 0xcc, 0x63, 0x23, 0xc1, 0x19,
+	0x8b, 0x42, 0x2a, 0x39, 0x6e, 0x60, 0xf9, 0x2d, 0x2a, 0xa6, 0x82, 0x5e, 0x57, 0xd1, 0xaf, 0xab,
+	0x8a, 0xd2, 0xe5, 0x08, 0x8f, 0xf8, 0xd1, 0x28, 0x4e, 0x98, 0x59, 0x53, 0x44, 0x8b, 0x6f, 0xfb,
+	0xcf, 0x12, 0x18, 0xf3, 0xe1, 0x58, 0x12, 0x53, 0x46, 0xd0, 0x27, 0xa0, 0x33, 0x29, 0x53, 0x39,
+	0xdd, 0xc6, 0xf1, 0xa6, 0x1a, 0x43, 0x49, 0x17, 0x67, 0x31, 0xf4, 0x08, 0x74, 0x7e, 0x45, 0x45,
+	0xf3, 0x4a, 0x99, 0x55, 0x7e, 0x45, 0x3b, 0x81, 0x44, 0x11, 0x2d, 0xab, 0xe5, 0x91, 0xdf, 0x62,
+	0x39, 0x3d, 0xdf, 0x97, 0xdc, 0x6a, 0xb2, 0xad, 0xdc, 0x44, 0xdb, 0x50, 0x8e, 0x82, 0x4c, 0x2a,
+	0xe5, 0x28, 0x58, 0xa6, 0x45, 0x5f, 0xa6, 0xc5, 0xf6, 0x01, 0x9d, 0x12, 0xfe, 0x2a, 0x23, 0x22,
+	0x5f, 0x8f, 0x02, 0x40, 0x69, 0x11, 0x60, 0x89, 0x95, 0xf2, 0x5a, 0x56, 0x2a, 0x05, 0x56, 0x7a,
+	0xf0, 0x70, 0x01, 0xe4, 0x4e, 0xbc, 0xe4, 0x04, 0x94, 0xe7, 0x04, 0xd8, 0x43, 0x78, 0x70, 0x4a,
+	0xf8, 0x89, 0x77, 0xe1, 0x51, 0x9f, 0xdc, 0x53, 0xd3, 0x03, 0x40, 0x45, 0x8c, 0x3b, 0xf5, 0x6c,
+	0x42, 0x6d, 0xa8, 0x7e, 0x98, 0x89, 0x3f, 0x37, 0xed, 0x7f, 0xca, 0xb0, 0xd3, 0xcf, 0xe8, 0xff,
+	0x70, 0xe3, 0x4f, 0x01, 0x92, 0xc9, 0xf0, 0x22, 0xf2, 0xdd, 0x9f, 0xc9, 0x34, 0x63, 0xa0, 0xa1,
+	0x3c, 0x2f, 0xc9, 0x74, 0x51, 0xd3, 0x95, 0x25, 0x4d, 0x3f, 0x81, 0x46, 0xe0, 0x71, 0x6f, 0x41,
+	0xf0, 0xc2, 0xb1, 0x56, 0xf0, 0x5f, 0xc0, 0xee, 0x25, 0x49, 0xa3, 0xb7, 0x53, 0x97, 0x67, 0x0a,
+	0x76, 0x65, 0x8e, 0x10, 0x4d, 0x1d, 0x23, 0x15, 0xcb, 0xc5, 0xdd, 0x5f, 0xb1, 0x45, 0x6a, 0x6b,
+	0x79, 0xad, 0xcf, 0x79, 0x45, 0x9f, 0xc3, 0x03, 0x71, 0x33, 0x88, 0xea, 0x62, 0x7e, 0xea, 0x47,
+	0x34, 0x34, 0x1b, 0x12, 0xc3, 0x50, 0x81, 0xfe, 0xcc, 0x8f, 0x9e, 0x01, 0xa4, 0x24, 0x8c, 0x18,
+	0x27, 0x29, 0x09, 0x4c, 0x90, 0x59, 0x05, 0x8f, 0x3d, 0x06, 0x63, 0xce, 0xe6, 0x9d, 0x96, 0x68,
+	0x69, 0x67, 0x28, 0x6e, 0x8b, 0x07, 0xc6, 0x8a, 0x8d, 0x67, 0x87, 0xb0, 0x77, 0x4a, 0xf8, 0xb9,
+	0xc7, 0xf8, 0xed, 0xd7, 0xf0, 0x7f, 0x89, 0xef, 0xf7, 0x0a, 0x3c, 0xbe, 0x86, 0x74, 0xa7, 0xf9,
+	0xd4, 0x49, 0xa0, 0xcd, 0x4e, 0x82, 0x7c, 0x9c, 0xea, 0xea, 0x73, 0x44, 0xbf, 0x49, 0x78, 0xb5,
+	0x1b, 0x85, 0x57, 0xbf, 0x49, 0x78, 0x8d, 0x35, 0xc2, 0x83, 0x5b, 0x08, 0x6f, 0x63, 0xad, 0xf0,
+	0x56, 0x6a, 0x68, 0xf3, 0x56, 0x1a, 0xda, 0x5a, 0xd6, 0x90, 0xb8, 0x7c, 0xfc, 0x8b, 0x98, 0x91,
+	0xc0, 0xdc, 0x96, 0xb1, 0xcc, 0x42, 0xfb, 0x50, 0x67, 0xef, 0x09, 0x49, 0x5c, 0x1e, 0x9b, 0x3b,
+	0x8a, 0x1e, 0x69, 0x0f, 0x62, 0xfb, 0xaf, 0x12, 0x3c, 0x6c, 0x8b, 0xac, 0x96, 0xe2, 0xeb, 0xc3,
+	0x2a, 0x28, 0x16, 0x2b, 0x2f, 0x14, 0xbb, 0x79, 0x17, 0xe7, 0x7c, 0x69, 0xeb, 0x6f, 0xa6, 0xea,
+	0x5a, 0x45, 0xe9, 0x05, 0x45, 0xfd, 0x51, 0x82, 0xdd, 0xc5, 0x96, 0xef, 0x7d, 0xbb, 0x14, 0xae,
+	0x34, 0xad, 0x78, 0xa5, 0xed, 0x43, 0x5d, 0xb8, 0x0b, 0x72, 0xac, 0xf1, 0x2b, 0x2a, 0xc6, 0xb5,
+	0x7f, 0x82, 0x7d, 0x71, 0x53, 0x10, 0x1a, 0x44, 0x34, 0xec, 0x50, 0x3f, 0x1e, 0x47, 0x34, 0xbc,
+	0xa7, 0x3d, 0x36, 0x04, 0x6b, 0x15, 0xd6, 0x9d, 0x68, 0x79, 0x06, 0x1a, 0xbf, 0xa2, 0x2c, 0x7b,
+	0x9f, 0x40, 0x53, 0xbd, 0x4e, 0x07, 0x57, 0x14, 0x4b, 0xff, 0x67, 0x7f, 0x97, 0x60, 0xb3, 0xf8,
+	0x0c, 0x45, 0x3a, 0x94, 0x7b, 0x2f, 0x8d, 0x8f, 0xd0, 0x23, 0x78, 0xd0, 0xe9, 0xbe, 0x69, 0x9d,
+	0x77, 0x5e, 0xb8, 0xaf, 0xb0, 0xf3, 0xc6, 0x3d, 0x6b, 0xf5, 0xcf, 0x8c, 0x12, 0x32, 0x60, 0x33,
+	0x77, 0xf7, 0x3b, 0xa7, 0x5d, 0xa3, 0x8c, 0x76, 0x60, 0xe3, 0xa4, 0xf5, 0xc2, 0xc5, 0xce, 0x77,
+	0xaf, 0x9d, 0xfe, 0xc0, 0xa8, 0xa0, 0x6d, 0x80, 0x6e, 0xcf, 0x3d, 0x69, 0x9d, 0xb7, 0xba, 0x6d,
+	0xc7, 0xd0, 0x10, 0x82, 0xed, 0x4e, 0x77, 0xe0, 0xe0, 0x6e, 0xeb, 0xdc, 0x75, 0x30, 0xee, 0x61,
+	0xa3, 0x8a, 0xb6, 0xa0, 0xd1, 0x77, 0x1c, 0xb7, 0x37, 0x38, 0x73, 0xb0, 0xa1, 0xa3, 0x06, 0x54,
+	0xb1, 0x33, 0xc0, 0x3f, 0x18, 0x35, 0x91, 0xdd, 0x6a, 0xb7, 0x7b, 0xaf, 0xbb, 0x03, 0xb7, 0x7d,
+	0xde, 0xeb, 0x3b, 0x2f, 0x8c, 0x06, 0xda, 0x05, 0x23, 0x07, 0xc5, 0x4e, 0xdb, 0xe9, 0xbc, 0x71,
+	0xb0, 0x01, 0xc7, 0xbf, 0x69, 0x60, 0xbc, 0x4a, 0x63, 0x9f, 0x30, 0x16, 0xa7, 0x7d, 0xf5, 0x96,
+	0x47, 0xdf, 0xc0, 0x4e, 0xe6, 0xcb, 0xa7, 0x42, 0x8f, 0x16, 0x1f, 0x65, 0xd9, 0x62, 0x59, 0x7b,
+	0xcb, 0xee, 0x8c, 0xd7, 0x13, 0xd8, 0x28, 0xbc, 0x05, 0x90, 0xa9, 0xd2, 0xae, 0xbf, 0x41, 0xac,
+	0xfd, 0x15, 0x91, 0xac, 0xc6, 0xd7, 0x00, 0xf3, 0xab, 0x19, 0x3d, 0x9e, 0x25, 0x2e, 0x3e, 0x08,
+	0x2c, 0xf3, 0x7a, 0x60, 0x56, 0x60, 0xfb, 0x75, 0x12, 0x78, 0x9c, 0xe4, 0x87, 0x6b, 0x3e, 0xc5,
+	0xd2, 0xb1, 0x6e, 0xed, 0x2d, 0xbb, 0xb3, 0x02, 0x5d, 0xd8, 0x59, 0x3a, 0x9e, 0xd1, 0xc7, 0x33,
+	0xb4, 0x15, 0xf7, 0x83, 0xf5, 0x74, 0x4d, 0x34, 0xab, 0xe7, 0xc0, 0x66, 0x71, 0x73, 0xa2, 0x6c,
+	0xf8, 0x15, 0x67, 0x8c, 0x65, 0xad, 0x0a, 0x65, 0x65, 0xbe, 0x07, 0x74, 0x5d, 0xd2, 0xe8, 0xf9,
+	0x9c, 0xc9, 0x95, 0x1b, 0xcb, 0x3a, 0x58, 0x9f, 0xa0, 0x0a, 0x9f, 0xd4, 0x7f, 0xd4, 0x45, 0x4a,
+	0x32, 0x1c, 0xea, 0xf2, 0xdf, 0xa1, 0xaf, 0xfe, 0x1b, 0x00, 0x15, 0x56, 0xa5, 0x08, 0xe4, 0x0d,
+	0x00, 0x00,
 }
@@ -52,6 +52,20 @@ func RegisterProcessorServiceHandlers(s *tcprpc.Server, prefix string, srv Proce
 			return srv.GetLastSettings(ctx, args)
 		}))
 
+	s.Handle(prefix+"CloseAccount", tcprpc.NewHandler(
+		func() proto.Message { return new(CloseAccountRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*CloseAccountRequest)
+			return srv.CloseAccount(ctx, args)
+		}))
+
+	s.Handle(prefix+"GetPendingIncoming", tcprpc.NewHandler(
+		func() proto.Message { return new(GetPendingIncomingRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetPendingIncomingRequest)
+			return srv.GetPendingIncoming(ctx, args)
+		}))
+
 }
 
 type TCPRPCProcessorServiceClient struct {
@@ -111,6 +125,24 @@ func (cl TCPRPCProcessorServiceClient) GetLastSettings(ctx context.Context, args
 	return &resp, nil
 }
 
+func (cl TCPRPCProcessorServiceClient) CloseAccount(ctx context.Context, args *CloseAccountRequest) (*CloseAccountResponse, error) {
+	var resp CloseAccountResponse
+	err := cl.cl.Call(ctx, cl.pref+"CloseAccount", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCProcessorServiceClient) GetPendingIncoming(ctx context.Context, args *GetPendingIncomingRequest) (*GetPendingIncomingResponse, error) {
+	var resp GetPendingIncomingResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetPendingIncoming", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 type ProcessorServiceInterface interface {
 	ProcessTransfer(context.Context, *TransferRequest) (*TransferResponse, error)
 
@@ -121,4 +153,8 @@ type ProcessorServiceInterface interface {
 	UpdateSettings(context.Context, *SettingsRequest) (*SettingsResponse, error)
 
 	GetLastSettings(context.Context, *GetLastSettingsRequest) (*GetLastSettingsResponse, error)
+
+	CloseAccount(context.Context, *CloseAccountRequest) (*CloseAccountResponse, error)
+
+	GetPendingIncoming(context.Context, *GetPendingIncomingRequest) (*GetPendingIncomingResponse, error)
 }
@@ -19,6 +19,24 @@ It has these top-level messages:
 	FetchResponse
 	GetTxnMultiRequest
 	GetTxnMultiResponse
+	GetStatementRequest
+	GetStatementResponse
+	GetBalanceAtRequest
+	GetBalanceAtResponse
+	GetPendingIncomingRequest
+	GetPendingIncomingResponse
+	GetSettingsHistoryRequest
+	SettingsHistoryEntry
+	GetSettingsHistoryResponse
+	PutDataRequest
+	PutDataResponse
+	GetDataRequest
+	GetDataResponse
+	GetReplicaLagRequest
+	GetReplicaLagResponse
+	StreamChangesRequest
+	Change
+	StreamChangesResponse
 */
 package plutodbpb
 
@@ -45,17 +63,23 @@ const (
 	DBStatusCode_OK            DBStatusCode = 0
 	DBStatusCode_INVALID_TOKEN DBStatusCode = 1
 	DBStatusCode_OTHER_ERROR   DBStatusCode = 2
+	DBStatusCode_BAD_REQUEST   DBStatusCode = 3
+	DBStatusCode_NOT_FOUND     DBStatusCode = 4
 )
 
 var DBStatusCode_name = map[int32]string{
 	0: "OK",
 	1: "INVALID_TOKEN",
 	2: "OTHER_ERROR",
+	3: "BAD_REQUEST",
+	4: "NOT_FOUND",
 }
 var DBStatusCode_value = map[string]int32{
 	"OK":            0,
 	"INVALID_TOKEN": 1,
 	"OTHER_ERROR":   2,
+	"BAD_REQUEST":   3,
+	"NOT_FOUND":     4,
 }
 
 func (x DBStatusCode) String() string {
@@ -63,6 +87,30 @@ func (x DBStatusCode) String() string {
 }
 func (DBStatusCode) EnumDescriptor() ([]byte, []int) { return fileDescriptorDbService, []int{0} }
 
+type HistoryDirection int32
+
+const (
+	HistoryDirection_ALL      HistoryDirection = 0
+	HistoryDirection_INCOMING HistoryDirection = 1
+	HistoryDirection_OUTGOING HistoryDirection = 2
+)
+
+var HistoryDirection_name = map[int32]string{
+	0: "ALL",
+	1: "INCOMING",
+	2: "OUTGOING",
+}
+var HistoryDirection_value = map[string]int32{
+	"ALL":      0,
+	"INCOMING": 1,
+	"OUTGOING": 2,
+}
+
+func (x HistoryDirection) String() string {
+	return proto.EnumName(HistoryDirection_name, int32(x))
+}
+func (HistoryDirection) EnumDescriptor() ([]byte, []int) { return fileDescriptorDbService, []int{1} }
+
 type Status struct {
 	// A simple error code that can be easily handled by the client.
 	Code DBStatusCode `protobuf:"varint,1,opt,name=code,proto3,enum=plutodbpb.DBStatusCode" json:"code,omitempty"`
@@ -104,6 +152,13 @@ type GetHistoryRequest struct {
 	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
 	Limit   uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Token   string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	// processed_at range in unix nanoseconds [from_time, to_time), zero means unbounded
+	FromTime     int64            `protobuf:"varint,4,opt,name=from_time,json=fromTime,proto3" json:"from_time,omitempty"`
+	ToTime       int64            `protobuf:"varint,5,opt,name=to_time,json=toTime,proto3" json:"to_time,omitempty"`
+	Direction    HistoryDirection `protobuf:"varint,6,opt,name=direction,proto3,enum=plutodbpb.HistoryDirection" json:"direction,omitempty"`
+	Counterparty uint64           `protobuf:"varint,7,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	MinAmount    int64            `protobuf:"varint,8,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	MaxAmount    int64            `protobuf:"varint,9,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
 }
 
 func (m *GetHistoryRequest) Reset()                    { *m = GetHistoryRequest{} }
@@ -132,6 +187,48 @@ func (m *GetHistoryRequest) GetToken() string {
 	return ""
 }
 
+func (m *GetHistoryRequest) GetFromTime() int64 {
+	if m != nil {
+		return m.FromTime
+	}
+	return 0
+}
+
+func (m *GetHistoryRequest) GetToTime() int64 {
+	if m != nil {
+		return m.ToTime
+	}
+	return 0
+}
+
+func (m *GetHistoryRequest) GetDirection() HistoryDirection {
+	if m != nil {
+		return m.Direction
+	}
+	return HistoryDirection_ALL
+}
+
+func (m *GetHistoryRequest) GetCounterparty() uint64 {
+	if m != nil {
+		return m.Counterparty
+	}
+	return 0
+}
+
+func (m *GetHistoryRequest) GetMinAmount() int64 {
+	if m != nil {
+		return m.MinAmount
+	}
+	return 0
+}
+
+func (m *GetHistoryRequest) GetMaxAmount() int64 {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return 0
+}
+
 type GetHistoryResponse struct {
 	Status *Status      `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
 	Txns   []*chain.Txn `protobuf:"bytes,2,rep,name=txns" json:"txns,omitempty"`
@@ -239,6 +336,8 @@ func (m *GetTxnMultiRequest) GetIDs() []*chain.TxnID {
 type GetTxnMultiResponse struct {
 	Status *Status      `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
 	Txns   []*chain.Txn `protobuf:"bytes,2,rep,name=txns" json:"txns,omitempty"`
+	// found is parallel to txns, not found txns are zero ones
+	Found []bool `protobuf:"varint,3,rep,packed,name=found" json:"found,omitempty"`
 }
 
 func (m *GetTxnMultiResponse) Reset()                    { *m = GetTxnMultiResponse{} }
@@ -260,6 +359,536 @@ func (m *GetTxnMultiResponse) GetTxns() []*chain.Txn {
 	return nil
 }
 
+func (m *GetTxnMultiResponse) GetFound() []bool {
+	if m != nil {
+		return m.Found
+	}
+	return nil
+}
+
+type GetStatementRequest struct {
+	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	// period in unix nanoseconds [from_time, to_time), zero means unbounded
+	FromTime   int64 `protobuf:"varint,2,opt,name=from_time,json=fromTime,proto3" json:"from_time,omitempty"`
+	ToTime     int64 `protobuf:"varint,3,opt,name=to_time,json=toTime,proto3" json:"to_time,omitempty"`
+	TotalsOnly bool  `protobuf:"varint,4,opt,name=totals_only,json=totalsOnly,proto3" json:"totals_only,omitempty"`
+}
+
+func (m *GetStatementRequest) Reset()                    { *m = GetStatementRequest{} }
+func (m *GetStatementRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetStatementRequest) ProtoMessage()               {}
+func (*GetStatementRequest) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{7} }
+
+func (m *GetStatementRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *GetStatementRequest) GetFromTime() int64 {
+	if m != nil {
+		return m.FromTime
+	}
+	return 0
+}
+
+func (m *GetStatementRequest) GetToTime() int64 {
+	if m != nil {
+		return m.ToTime
+	}
+	return 0
+}
+
+func (m *GetStatementRequest) GetTotalsOnly() bool {
+	if m != nil {
+		return m.TotalsOnly
+	}
+	return false
+}
+
+type GetStatementResponse struct {
+	Status         *Status      `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	OpeningBalance int64        `protobuf:"varint,2,opt,name=opening_balance,json=openingBalance,proto3" json:"opening_balance,omitempty"`
+	ClosingBalance int64        `protobuf:"varint,3,opt,name=closing_balance,json=closingBalance,proto3" json:"closing_balance,omitempty"`
+	Debits         int64        `protobuf:"varint,4,opt,name=debits,proto3" json:"debits,omitempty"`
+	Credits        int64        `protobuf:"varint,5,opt,name=credits,proto3" json:"credits,omitempty"`
+	TxnCount       uint64       `protobuf:"varint,6,opt,name=txn_count,json=txnCount,proto3" json:"txn_count,omitempty"`
+	Txns           []*chain.Txn `protobuf:"bytes,7,rep,name=txns" json:"txns,omitempty"`
+}
+
+func (m *GetStatementResponse) Reset()                    { *m = GetStatementResponse{} }
+func (m *GetStatementResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetStatementResponse) ProtoMessage()               {}
+func (*GetStatementResponse) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{8} }
+
+func (m *GetStatementResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetStatementResponse) GetOpeningBalance() int64 {
+	if m != nil {
+		return m.OpeningBalance
+	}
+	return 0
+}
+
+func (m *GetStatementResponse) GetClosingBalance() int64 {
+	if m != nil {
+		return m.ClosingBalance
+	}
+	return 0
+}
+
+func (m *GetStatementResponse) GetDebits() int64 {
+	if m != nil {
+		return m.Debits
+	}
+	return 0
+}
+
+func (m *GetStatementResponse) GetCredits() int64 {
+	if m != nil {
+		return m.Credits
+	}
+	return 0
+}
+
+func (m *GetStatementResponse) GetTxnCount() uint64 {
+	if m != nil {
+		return m.TxnCount
+	}
+	return 0
+}
+
+func (m *GetStatementResponse) GetTxns() []*chain.Txn {
+	if m != nil {
+		return m.Txns
+	}
+	return nil
+}
+
+type GetBalanceAtRequest struct {
+	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	// balance right after account outgoing txn
+	TxnId uint64 `protobuf:"varint,2,opt,name=txn_id,json=txnId,proto3" json:"txn_id,omitempty"`
+	// or balance before time in unix nanoseconds
+	Time int64 `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (m *GetBalanceAtRequest) Reset()                    { *m = GetBalanceAtRequest{} }
+func (m *GetBalanceAtRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetBalanceAtRequest) ProtoMessage()               {}
+func (*GetBalanceAtRequest) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{9} }
+
+func (m *GetBalanceAtRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *GetBalanceAtRequest) GetTxnId() uint64 {
+	if m != nil {
+		return m.TxnId
+	}
+	return 0
+}
+
+func (m *GetBalanceAtRequest) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type GetBalanceAtResponse struct {
+	Status          *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	Balance         int64   `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	PendingIncoming int64   `protobuf:"varint,3,opt,name=pending_incoming,json=pendingIncoming,proto3" json:"pending_incoming,omitempty"`
+	TxnId           uint64  `protobuf:"varint,4,opt,name=txn_id,json=txnId,proto3" json:"txn_id,omitempty"`
+}
+
+func (m *GetBalanceAtResponse) Reset()                    { *m = GetBalanceAtResponse{} }
+func (m *GetBalanceAtResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetBalanceAtResponse) ProtoMessage()               {}
+func (*GetBalanceAtResponse) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{10} }
+
+func (m *GetBalanceAtResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetBalanceAtResponse) GetBalance() int64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *GetBalanceAtResponse) GetPendingIncoming() int64 {
+	if m != nil {
+		return m.PendingIncoming
+	}
+	return 0
+}
+
+func (m *GetBalanceAtResponse) GetTxnId() uint64 {
+	if m != nil {
+		return m.TxnId
+	}
+	return 0
+}
+
+type GetPendingIncomingRequest struct {
+	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+}
+
+func (m *GetPendingIncomingRequest) Reset()         { *m = GetPendingIncomingRequest{} }
+func (m *GetPendingIncomingRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPendingIncomingRequest) ProtoMessage()    {}
+func (*GetPendingIncomingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorDbService, []int{11}
+}
+
+func (m *GetPendingIncomingRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+type GetPendingIncomingResponse struct {
+	Status *Status      `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	Txns   []*chain.Txn `protobuf:"bytes,2,rep,name=txns" json:"txns,omitempty"`
+}
+
+func (m *GetPendingIncomingResponse) Reset()         { *m = GetPendingIncomingResponse{} }
+func (m *GetPendingIncomingResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPendingIncomingResponse) ProtoMessage()    {}
+func (*GetPendingIncomingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorDbService, []int{12}
+}
+
+func (m *GetPendingIncomingResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetPendingIncomingResponse) GetTxns() []*chain.Txn {
+	if m != nil {
+		return m.Txns
+	}
+	return nil
+}
+
+type GetSettingsHistoryRequest struct {
+	Account uint64 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	Limit   uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Token   string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (m *GetSettingsHistoryRequest) Reset()         { *m = GetSettingsHistoryRequest{} }
+func (m *GetSettingsHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSettingsHistoryRequest) ProtoMessage()    {}
+func (*GetSettingsHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorDbService, []int{13}
+}
+
+func (m *GetSettingsHistoryRequest) GetAccount() uint64 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *GetSettingsHistoryRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetSettingsHistoryRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type SettingsHistoryEntry struct {
+	Settings *chain.Settings `protobuf:"bytes,1,opt,name=settings" json:"settings,omitempty"`
+	// public key differs from the previous settings one
+	KeyChanged bool `protobuf:"varint,2,opt,name=key_changed,json=keyChanged,proto3" json:"key_changed,omitempty"`
+}
+
+func (m *SettingsHistoryEntry) Reset()                    { *m = SettingsHistoryEntry{} }
+func (m *SettingsHistoryEntry) String() string            { return proto.CompactTextString(m) }
+func (*SettingsHistoryEntry) ProtoMessage()               {}
+func (*SettingsHistoryEntry) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{14} }
+
+func (m *SettingsHistoryEntry) GetSettings() *chain.Settings {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *SettingsHistoryEntry) GetKeyChanged() bool {
+	if m != nil {
+		return m.KeyChanged
+	}
+	return false
+}
+
+type GetSettingsHistoryResponse struct {
+	Status   *Status                 `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	Settings []*SettingsHistoryEntry `protobuf:"bytes,2,rep,name=settings" json:"settings,omitempty"`
+	Token    string                  `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (m *GetSettingsHistoryResponse) Reset()         { *m = GetSettingsHistoryResponse{} }
+func (m *GetSettingsHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSettingsHistoryResponse) ProtoMessage()    {}
+func (*GetSettingsHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorDbService, []int{15}
+}
+
+func (m *GetSettingsHistoryResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetSettingsHistoryResponse) GetSettings() []*SettingsHistoryEntry {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *GetSettingsHistoryResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type PutDataRequest struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *PutDataRequest) Reset()                    { *m = PutDataRequest{} }
+func (m *PutDataRequest) String() string            { return proto.CompactTextString(m) }
+func (*PutDataRequest) ProtoMessage()               {}
+func (*PutDataRequest) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{16} }
+
+func (m *PutDataRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type PutDataResponse struct {
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// content address of the data
+	Hash []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *PutDataResponse) Reset()                    { *m = PutDataResponse{} }
+func (m *PutDataResponse) String() string            { return proto.CompactTextString(m) }
+func (*PutDataResponse) ProtoMessage()               {}
+func (*PutDataResponse) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{17} }
+
+func (m *PutDataResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *PutDataResponse) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+type GetDataRequest struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *GetDataRequest) Reset()                    { *m = GetDataRequest{} }
+func (m *GetDataRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDataRequest) ProtoMessage()               {}
+func (*GetDataRequest) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{18} }
+
+func (m *GetDataRequest) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+type GetDataResponse struct {
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	Data   []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *GetDataResponse) Reset()                    { *m = GetDataResponse{} }
+func (m *GetDataResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDataResponse) ProtoMessage()               {}
+func (*GetDataResponse) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{19} }
+
+func (m *GetDataResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetDataResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type GetReplicaLagRequest struct {
+}
+
+func (m *GetReplicaLagRequest) Reset()                    { *m = GetReplicaLagRequest{} }
+func (m *GetReplicaLagRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetReplicaLagRequest) ProtoMessage()               {}
+func (*GetReplicaLagRequest) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{20} }
+
+type GetReplicaLagResponse struct {
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// replication lag in nanoseconds, zero on primary
+	Lag int64 `protobuf:"varint,2,opt,name=lag,proto3" json:"lag,omitempty"`
+}
+
+func (m *GetReplicaLagResponse) Reset()                    { *m = GetReplicaLagResponse{} }
+func (m *GetReplicaLagResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetReplicaLagResponse) ProtoMessage()               {}
+func (*GetReplicaLagResponse) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{21} }
+
+func (m *GetReplicaLagResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetReplicaLagResponse) GetLag() int64 {
+	if m != nil {
+		return m.Lag
+	}
+	return 0
+}
+
+type StreamChangesRequest struct {
+	// changes after cursor are returned, zero to start from the beginning
+	Cursor uint64 `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// max number of changes to return
+	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *StreamChangesRequest) Reset()                    { *m = StreamChangesRequest{} }
+func (m *StreamChangesRequest) String() string            { return proto.CompactTextString(m) }
+func (*StreamChangesRequest) ProtoMessage()               {}
+func (*StreamChangesRequest) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{22} }
+
+func (m *StreamChangesRequest) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+func (m *StreamChangesRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// Change is either txn or settings stored at seq
+type Change struct {
+	Seq      uint64          `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Txn      *chain.Txn      `protobuf:"bytes,2,opt,name=txn" json:"txn,omitempty"`
+	Settings *chain.Settings `protobuf:"bytes,3,opt,name=settings" json:"settings,omitempty"`
+}
+
+func (m *Change) Reset()                    { *m = Change{} }
+func (m *Change) String() string            { return proto.CompactTextString(m) }
+func (*Change) ProtoMessage()               {}
+func (*Change) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{23} }
+
+func (m *Change) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Change) GetTxn() *chain.Txn {
+	if m != nil {
+		return m.Txn
+	}
+	return nil
+}
+
+func (m *Change) GetSettings() *chain.Settings {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+type StreamChangesResponse struct {
+	Status *Status `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	// changes in commit order
+	Changes []*Change `protobuf:"bytes,2,rep,name=changes" json:"changes,omitempty"`
+	// cursor to continue from
+	Cursor uint64 `protobuf:"varint,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (m *StreamChangesResponse) Reset()                    { *m = StreamChangesResponse{} }
+func (m *StreamChangesResponse) String() string            { return proto.CompactTextString(m) }
+func (*StreamChangesResponse) ProtoMessage()               {}
+func (*StreamChangesResponse) Descriptor() ([]byte, []int) { return fileDescriptorDbService, []int{24} }
+
+func (m *StreamChangesResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *StreamChangesResponse) GetChanges() []*Change {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *StreamChangesResponse) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Status)(nil), "plutodbpb.Status")
 	proto.RegisterType((*GetHistoryRequest)(nil), "plutodbpb.GetHistoryRequest")
@@ -268,43 +897,110 @@ func init() {
 	proto.RegisterType((*FetchResponse)(nil), "plutodbpb.FetchResponse")
 	proto.RegisterType((*GetTxnMultiRequest)(nil), "plutodbpb.GetTxnMultiRequest")
 	proto.RegisterType((*GetTxnMultiResponse)(nil), "plutodbpb.GetTxnMultiResponse")
+	proto.RegisterType((*GetStatementRequest)(nil), "plutodbpb.GetStatementRequest")
+	proto.RegisterType((*GetStatementResponse)(nil), "plutodbpb.GetStatementResponse")
+	proto.RegisterType((*GetBalanceAtRequest)(nil), "plutodbpb.GetBalanceAtRequest")
+	proto.RegisterType((*GetBalanceAtResponse)(nil), "plutodbpb.GetBalanceAtResponse")
+	proto.RegisterType((*GetPendingIncomingRequest)(nil), "plutodbpb.GetPendingIncomingRequest")
+	proto.RegisterType((*GetPendingIncomingResponse)(nil), "plutodbpb.GetPendingIncomingResponse")
+	proto.RegisterType((*GetSettingsHistoryRequest)(nil), "plutodbpb.GetSettingsHistoryRequest")
+	proto.RegisterType((*SettingsHistoryEntry)(nil), "plutodbpb.SettingsHistoryEntry")
+	proto.RegisterType((*GetSettingsHistoryResponse)(nil), "plutodbpb.GetSettingsHistoryResponse")
+	proto.RegisterType((*PutDataRequest)(nil), "plutodbpb.PutDataRequest")
+	proto.RegisterType((*PutDataResponse)(nil), "plutodbpb.PutDataResponse")
+	proto.RegisterType((*GetDataRequest)(nil), "plutodbpb.GetDataRequest")
+	proto.RegisterType((*GetDataResponse)(nil), "plutodbpb.GetDataResponse")
+	proto.RegisterType((*GetReplicaLagRequest)(nil), "plutodbpb.GetReplicaLagRequest")
+	proto.RegisterType((*GetReplicaLagResponse)(nil), "plutodbpb.GetReplicaLagResponse")
+	proto.RegisterType((*StreamChangesRequest)(nil), "plutodbpb.StreamChangesRequest")
+	proto.RegisterType((*Change)(nil), "plutodbpb.Change")
+	proto.RegisterType((*StreamChangesResponse)(nil), "plutodbpb.StreamChangesResponse")
 	proto.RegisterEnum("plutodbpb.DBStatusCode", DBStatusCode_name, DBStatusCode_value)
+	proto.RegisterEnum("plutodbpb.HistoryDirection", HistoryDirection_name, HistoryDirection_value)
 }
 
 func init() { proto.RegisterFile("db_service.proto", fileDescriptorDbService) }
 
 var fileDescriptorDbService = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xc5, 0x49, 0x9a, 0xd2, 0x49, 0xd2, 0x26, 0x4b, 0x25, 0x4c, 0x04, 0x51, 0xe4, 0x53, 0xa0,
-	0x92, 0x23, 0x05, 0xb8, 0xf4, 0x80, 0xd4, 0xe2, 0x40, 0xad, 0x96, 0x06, 0x6d, 0x22, 0x24, 0x4e,
-	0xc1, 0x1f, 0x5b, 0x67, 0x55, 0x67, 0xd7, 0x64, 0xd7, 0x28, 0x39, 0x71, 0xe4, 0x97, 0xf2, 0x3f,
-	0x90, 0x77, 0xed, 0xd6, 0xb4, 0xca, 0xa5, 0xea, 0xc9, 0x9a, 0x9d, 0x37, 0xf3, 0xde, 0x1b, 0xcf,
-	0x40, 0x3b, 0xf4, 0xe7, 0x82, 0xac, 0x7e, 0xd1, 0x80, 0xd8, 0xc9, 0x8a, 0x4b, 0x8e, 0xf6, 0x92,
-	0x38, 0x95, 0x3c, 0xf4, 0x13, 0xbf, 0xfb, 0x22, 0xe2, 0x3c, 0x8a, 0xc9, 0x50, 0x25, 0xfc, 0xf4,
-	0x6a, 0xe8, 0xb1, 0x8d, 0x46, 0x75, 0xdf, 0x47, 0x54, 0x2e, 0x52, 0xdf, 0x0e, 0xf8, 0x72, 0xc8,
-	0xe8, 0xb5, 0xc7, 0xc2, 0x2b, 0xbe, 0x1a, 0xaa, 0x52, 0xa1, 0xf1, 0xc3, 0x60, 0xe1, 0x51, 0x96,
-	0xf8, 0xfa, 0xab, 0xcb, 0xac, 0xdf, 0x50, 0x9f, 0x4a, 0x4f, 0xa6, 0x02, 0x1d, 0x41, 0x2d, 0xe0,
-	0x21, 0x31, 0x8d, 0xbe, 0x31, 0xd8, 0x1f, 0x3d, 0xb7, 0x6f, 0x58, 0x6d, 0xe7, 0x54, 0x43, 0x3e,
-	0xf2, 0x90, 0x60, 0x05, 0x42, 0x26, 0xec, 0x2e, 0x89, 0x10, 0x5e, 0x44, 0xcc, 0x4a, 0xdf, 0x18,
-	0xec, 0xe1, 0x22, 0x44, 0x36, 0xec, 0x86, 0x44, 0x7a, 0x34, 0x16, 0x66, 0xb5, 0x5f, 0x1d, 0x34,
-	0x46, 0x87, 0xb6, 0x16, 0x6d, 0x17, 0xa2, 0xed, 0x13, 0xb6, 0xc1, 0x05, 0xc8, 0xfa, 0x0e, 0x9d,
-	0xcf, 0x44, 0x9e, 0x51, 0x21, 0xf9, 0x6a, 0x83, 0xc9, 0xcf, 0x94, 0x08, 0x99, 0xb5, 0xf7, 0x82,
-	0x80, 0xa7, 0x4c, 0x2a, 0x39, 0x35, 0x5c, 0x84, 0xe8, 0x10, 0x76, 0x62, 0xba, 0xa4, 0x52, 0xd1,
-	0xb6, 0xb0, 0x0e, 0xb2, 0x57, 0xc9, 0xaf, 0x09, 0x33, 0xab, 0x4a, 0x8c, 0x0e, 0xac, 0x14, 0x50,
-	0xb9, 0xb5, 0x48, 0x38, 0x13, 0x04, 0xbd, 0x86, 0xba, 0x50, 0x76, 0x54, 0xeb, 0xc6, 0xa8, 0x53,
-	0x72, 0xaa, 0x7d, 0xe2, 0x1c, 0x80, 0x7a, 0x50, 0x93, 0x6b, 0x26, 0xcc, 0x8a, 0x32, 0x02, 0xb6,
-	0x1e, 0xdc, 0x6c, 0xcd, 0xb0, 0x7a, 0xdf, 0x42, 0xfb, 0x01, 0x9a, 0x9f, 0x88, 0x0c, 0x16, 0x0f,
-	0x34, 0x63, 0xfd, 0x31, 0xa0, 0x95, 0x37, 0x78, 0x7c, 0xc9, 0x47, 0xf0, 0x54, 0x10, 0x29, 0x29,
-	0x8b, 0x84, 0x52, 0xdd, 0x18, 0x1d, 0xe4, 0x98, 0x69, 0xfe, 0x8c, 0x6f, 0x00, 0xd6, 0x3b, 0x35,
-	0xc0, 0xd9, 0x9a, 0x7d, 0x49, 0x63, 0x49, 0x0b, 0x3f, 0x3d, 0xa8, 0xba, 0x4e, 0x26, 0x25, 0x63,
-	0x68, 0xde, 0x32, 0xb8, 0x0e, 0xce, 0x12, 0xd6, 0x0f, 0x78, 0xf6, 0x5f, 0xd5, 0xa3, 0x9b, 0x78,
-	0x73, 0x0c, 0xcd, 0xf2, 0x4e, 0xa2, 0x3a, 0x54, 0x26, 0xe7, 0xed, 0x27, 0xa8, 0x03, 0x2d, 0xf7,
-	0xf2, 0xdb, 0xc9, 0x85, 0xeb, 0xcc, 0x67, 0x93, 0xf3, 0xf1, 0x65, 0xdb, 0x40, 0x07, 0xd0, 0x98,
-	0xcc, 0xce, 0xc6, 0x78, 0x3e, 0xc6, 0x78, 0x82, 0xdb, 0x95, 0xd1, 0x5f, 0x03, 0xf6, 0xbf, 0x66,
-	0xc4, 0xce, 0xe9, 0x54, 0x9f, 0x19, 0x72, 0x01, 0x6e, 0xf7, 0x04, 0xbd, 0x2c, 0xe9, 0xba, 0xb7,
-	0x99, 0xdd, 0x57, 0x5b, 0xb2, 0xb9, 0xc9, 0x63, 0xd8, 0x51, 0xbf, 0x0e, 0x95, 0xef, 0xa7, 0xbc,
-	0x0d, 0x5d, 0xf3, 0x7e, 0x22, 0xaf, 0xbd, 0x80, 0x46, 0x69, 0x6e, 0xe8, 0x0e, 0xd3, 0x9d, 0xbf,
-	0xd0, 0xed, 0x6d, 0x4b, 0xeb, 0x6e, 0x7e, 0x5d, 0x9d, 0xdb, 0xdb, 0x7f, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0xc7, 0xe9, 0xb5, 0x7b, 0x50, 0x04, 0x00, 0x00,
+	// 1265 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x5f, 0x6f, 0xdb, 0x36,
+	0x10, 0xaf, 0x6c, 0x47, 0xb6, 0x2f, 0x4e, 0xe2, 0x72, 0x69, 0xeb, 0xaa, 0x7f, 0x12, 0x08, 0x2d,
+	0x96, 0xb6, 0x80, 0x83, 0x65, 0x1b, 0x86, 0x6d, 0xc0, 0x30, 0x27, 0x76, 0x5d, 0xa3, 0xa9, 0x9d,
+	0x31, 0xee, 0x1e, 0xfa, 0x62, 0xd0, 0x12, 0x6b, 0x0b, 0x95, 0x28, 0x47, 0xa2, 0x3b, 0xfb, 0x69,
+	0xc0, 0x1e, 0xd6, 0xef, 0x30, 0x60, 0xdf, 0x60, 0x5f, 0x70, 0x6f, 0x83, 0x48, 0x2a, 0x91, 0x64,
+	0x1b, 0x99, 0xb7, 0xf6, 0xc9, 0xe2, 0xdd, 0x8f, 0x77, 0x3f, 0xfe, 0xc8, 0x23, 0xcf, 0x50, 0xb5,
+	0x87, 0x83, 0x90, 0x06, 0xef, 0x1d, 0x8b, 0xd6, 0x27, 0x81, 0xcf, 0x7d, 0x54, 0x9e, 0xb8, 0x53,
+	0xee, 0xdb, 0xc3, 0xc9, 0xd0, 0xb8, 0x3b, 0xf2, 0xfd, 0x91, 0x4b, 0x0f, 0x85, 0x63, 0x38, 0x7d,
+	0x7b, 0x48, 0xd8, 0x5c, 0xa2, 0x8c, 0x2f, 0x46, 0x0e, 0x1f, 0x4f, 0x87, 0x75, 0xcb, 0xf7, 0x0e,
+	0x2f, 0x9c, 0x5f, 0x1c, 0x4e, 0xad, 0xf1, 0xe1, 0x85, 0x3d, 0x91, 0xd8, 0x43, 0x6b, 0x4c, 0x1c,
+	0x36, 0x19, 0xca, 0x5f, 0x39, 0xc5, 0xfc, 0x15, 0xf4, 0x73, 0x4e, 0xf8, 0x34, 0x44, 0xcf, 0xa0,
+	0x60, 0xf9, 0x36, 0xad, 0x69, 0xfb, 0xda, 0xc1, 0xf6, 0xd1, 0x9d, 0xfa, 0x65, 0xc6, 0x7a, 0xf3,
+	0x58, 0x42, 0x4e, 0x7c, 0x9b, 0x62, 0x01, 0x42, 0x35, 0x28, 0x7a, 0x34, 0x0c, 0xc9, 0x88, 0xd6,
+	0x72, 0xfb, 0xda, 0x41, 0x19, 0xc7, 0x43, 0x54, 0x87, 0xa2, 0x4d, 0x39, 0x71, 0xdc, 0xb0, 0x96,
+	0xdf, 0xcf, 0x1f, 0x6c, 0x1e, 0xed, 0xd6, 0x25, 0xe1, 0x7a, 0x4c, 0xb8, 0xde, 0x60, 0x73, 0x1c,
+	0x83, 0xcc, 0xbf, 0x72, 0x70, 0xb3, 0x4d, 0xf9, 0x0b, 0x27, 0xe4, 0x7e, 0x30, 0xc7, 0xf4, 0x62,
+	0x4a, 0x43, 0x1e, 0xc5, 0x27, 0x96, 0xe5, 0x4f, 0x19, 0x17, 0x7c, 0x0a, 0x38, 0x1e, 0xa2, 0x5d,
+	0xd8, 0x70, 0x1d, 0xcf, 0xe1, 0x22, 0xef, 0x16, 0x96, 0x83, 0xc8, 0xca, 0xfd, 0x77, 0x94, 0xd5,
+	0xf2, 0x82, 0x8d, 0x1c, 0xa0, 0x7b, 0x50, 0x7e, 0x1b, 0xf8, 0xde, 0x80, 0x3b, 0x1e, 0xad, 0x15,
+	0xf6, 0xb5, 0x83, 0x3c, 0x2e, 0x45, 0x86, 0xbe, 0xe3, 0x51, 0x74, 0x07, 0x8a, 0xdc, 0x97, 0xae,
+	0x0d, 0xe1, 0xd2, 0xb9, 0x2f, 0x1c, 0xdf, 0x42, 0xd9, 0x76, 0x02, 0x6a, 0x71, 0xc7, 0x67, 0x35,
+	0x5d, 0xa8, 0x71, 0x2f, 0xa1, 0x86, 0x62, 0xda, 0x8c, 0x21, 0xf8, 0x0a, 0x8d, 0x4c, 0xa8, 0x08,
+	0x96, 0x34, 0x98, 0x90, 0x80, 0xcf, 0x6b, 0x45, 0xc1, 0x3d, 0x65, 0x43, 0x0f, 0x00, 0x3c, 0x87,
+	0x0d, 0x88, 0x27, 0x56, 0x57, 0x12, 0xa9, 0xcb, 0x9e, 0xc3, 0x1a, 0xc2, 0x20, 0xdc, 0x64, 0x16,
+	0xbb, 0xcb, 0xca, 0x4d, 0x66, 0xd2, 0x6d, 0x4e, 0x01, 0x25, 0xd5, 0x0a, 0x27, 0x3e, 0x0b, 0x29,
+	0x7a, 0x02, 0x7a, 0x28, 0xb6, 0x48, 0xa8, 0xb5, 0x79, 0x74, 0x33, 0xc1, 0x57, 0xee, 0x1d, 0x56,
+	0x00, 0xf4, 0x10, 0x0a, 0x7c, 0xc6, 0xc2, 0x5a, 0x4e, 0x6c, 0x0e, 0xd4, 0xe5, 0x61, 0xe8, 0xcf,
+	0x18, 0x16, 0xf6, 0xe5, 0x4a, 0x9a, 0x3f, 0x40, 0xe5, 0x39, 0xe5, 0xd6, 0xf8, 0x3f, 0xee, 0x8f,
+	0xf9, 0x41, 0x83, 0x2d, 0x15, 0xe0, 0xe3, 0x53, 0x7e, 0x06, 0xa5, 0x90, 0x72, 0xee, 0xb0, 0x51,
+	0x28, 0x58, 0x6f, 0x1e, 0xed, 0x28, 0xcc, 0xb9, 0x32, 0xe3, 0x4b, 0x80, 0xf9, 0x95, 0x10, 0xb0,
+	0x3f, 0x63, 0xaf, 0xa6, 0x2e, 0x77, 0xe2, 0xf5, 0x3c, 0x84, 0x7c, 0xa7, 0x19, 0x51, 0x89, 0x32,
+	0x54, 0xae, 0x32, 0x74, 0x9a, 0x38, 0x72, 0x98, 0xef, 0xe1, 0xb3, 0xd4, 0xac, 0x4f, 0xa2, 0xfb,
+	0x5b, 0x7f, 0xca, 0x6c, 0x51, 0x35, 0x25, 0x2c, 0x07, 0xe6, 0xef, 0x9a, 0x48, 0x1c, 0xc5, 0xa2,
+	0x1e, 0x65, 0xfc, 0x7a, 0xfd, 0x53, 0x67, 0x3e, 0xb7, 0xfa, 0xcc, 0xe7, 0x53, 0x67, 0x7e, 0x0f,
+	0x36, 0xb9, 0xcf, 0x89, 0x1b, 0x0e, 0x7c, 0xe6, 0xce, 0x45, 0xad, 0x94, 0x30, 0x48, 0x53, 0x8f,
+	0xb9, 0x73, 0xf3, 0xb7, 0x1c, 0xec, 0xa6, 0x89, 0xac, 0x2f, 0xc1, 0xe7, 0xb0, 0xe3, 0x4f, 0x28,
+	0x73, 0xd8, 0x68, 0x30, 0x24, 0x2e, 0x61, 0x56, 0x4c, 0x70, 0x5b, 0x99, 0x8f, 0xa5, 0x35, 0x02,
+	0x5a, 0xae, 0x1f, 0x26, 0x81, 0x92, 0xee, 0xb6, 0x32, 0xc7, 0xc0, 0xdb, 0xa0, 0xdb, 0x74, 0xe8,
+	0xf0, 0x50, 0x55, 0xb7, 0x1a, 0x45, 0xf2, 0x58, 0x01, 0xb5, 0x23, 0x87, 0xac, 0xed, 0x78, 0x18,
+	0xc9, 0xc3, 0x67, 0x6c, 0x20, 0xa5, 0xd3, 0x85, 0x74, 0x25, 0x3e, 0x63, 0x27, 0x42, 0xbb, 0x78,
+	0x8f, 0x8a, 0xcb, 0xf7, 0xc8, 0x7c, 0x23, 0x36, 0x43, 0x25, 0x6f, 0xfc, 0x8b, 0xcd, 0xb8, 0x05,
+	0x7a, 0x94, 0xcd, 0xb1, 0xc5, 0x42, 0x0b, 0x78, 0x83, 0xcf, 0x58, 0xc7, 0x46, 0x08, 0x0a, 0x89,
+	0x3d, 0x10, 0xdf, 0xe6, 0x9f, 0x1a, 0xec, 0xa6, 0x83, 0xaf, 0x2f, 0x70, 0x0d, 0x8a, 0x69, 0x61,
+	0xe3, 0x21, 0x7a, 0x02, 0xd5, 0x09, 0x65, 0x76, 0xa4, 0xa8, 0xc3, 0x2c, 0xdf, 0x73, 0xd8, 0x48,
+	0x65, 0xdf, 0x51, 0xf6, 0x8e, 0x32, 0x27, 0x38, 0x17, 0x12, 0x9c, 0xcd, 0xaf, 0xe1, 0x6e, 0x9b,
+	0xf2, 0xb3, 0x34, 0xf8, 0x5a, 0x05, 0xcc, 0x11, 0x18, 0xcb, 0xa6, 0x7d, 0xf4, 0xfa, 0x31, 0x89,
+	0xe0, 0x17, 0x17, 0xfc, 0xa7, 0x78, 0x4e, 0x4c, 0x1b, 0x76, 0x33, 0xf1, 0x5b, 0x8c, 0x07, 0xf3,
+	0xd4, 0xfd, 0xa3, 0x5d, 0x73, 0xff, 0x44, 0x95, 0xf6, 0x8e, 0xce, 0x07, 0xd6, 0x98, 0xb0, 0x11,
+	0x95, 0xe7, 0xa2, 0x84, 0xe1, 0x1d, 0x9d, 0x9f, 0x48, 0x8b, 0xf9, 0x87, 0x26, 0x24, 0x5b, 0x58,
+	0xc9, 0xfa, 0x92, 0x7d, 0x9f, 0xe0, 0x25, 0x65, 0xdb, 0x4b, 0x82, 0x97, 0x2c, 0x25, 0xc1, 0x73,
+	0xb9, 0x04, 0x8f, 0x60, 0xfb, 0x6c, 0xca, 0x9b, 0x84, 0x93, 0x58, 0x5a, 0x04, 0x05, 0x9b, 0x70,
+	0x22, 0xd8, 0x54, 0xb0, 0xf8, 0x36, 0xcf, 0x60, 0xe7, 0x12, 0xb5, 0x3e, 0x6d, 0x04, 0x85, 0x31,
+	0x09, 0xc7, 0x42, 0x9a, 0x0a, 0x16, 0xdf, 0x51, 0xde, 0x36, 0xcd, 0xe6, 0x15, 0x28, 0x2d, 0x81,
+	0x3a, 0x83, 0x9d, 0x36, 0xfd, 0x3f, 0x79, 0xc5, 0x4a, 0x72, 0x89, 0x95, 0xdc, 0x16, 0x45, 0x89,
+	0xe9, 0xc4, 0x75, 0x2c, 0x72, 0x4a, 0xe2, 0x03, 0x6f, 0xf6, 0xe1, 0x56, 0xc6, 0xbe, 0x7e, 0xbe,
+	0x2a, 0xe4, 0x5d, 0x32, 0x52, 0x95, 0x1a, 0x7d, 0x9a, 0x4d, 0xd8, 0x3d, 0xe7, 0x01, 0x25, 0x9e,
+	0x3c, 0x0b, 0x61, 0xbc, 0xd6, 0xdb, 0xa0, 0x5b, 0xd3, 0x20, 0xf4, 0x03, 0x75, 0x7a, 0xd5, 0x68,
+	0xc5, 0x5b, 0x4b, 0x41, 0x97, 0xf3, 0xa3, 0x0c, 0x21, 0xbd, 0x50, 0x93, 0xa2, 0x4f, 0x74, 0x1f,
+	0xf2, 0x7c, 0xc6, 0x04, 0x3e, 0x5d, 0x44, 0x91, 0x79, 0xbd, 0x87, 0xf4, 0x83, 0x06, 0xb7, 0x32,
+	0x6c, 0xd7, 0xd7, 0xe0, 0x19, 0x14, 0x65, 0x25, 0xc4, 0x27, 0x34, 0x89, 0x95, 0x71, 0x71, 0x8c,
+	0x48, 0xc8, 0x90, 0x4f, 0xca, 0xf0, 0xf4, 0x0d, 0x54, 0x92, 0x2d, 0x2a, 0xd2, 0x21, 0xd7, 0x7b,
+	0x59, 0xbd, 0x81, 0x6e, 0xc2, 0x56, 0xa7, 0xfb, 0x73, 0xe3, 0xb4, 0xd3, 0x1c, 0xf4, 0x7b, 0x2f,
+	0x5b, 0xdd, 0xaa, 0x86, 0x76, 0x60, 0xb3, 0xd7, 0x7f, 0xd1, 0xc2, 0x83, 0x16, 0xc6, 0x3d, 0x5c,
+	0xcd, 0x45, 0x86, 0xe3, 0x46, 0x73, 0x80, 0x5b, 0x3f, 0xbd, 0x6e, 0x9d, 0xf7, 0xab, 0x79, 0xb4,
+	0x05, 0xe5, 0x6e, 0xaf, 0x3f, 0x78, 0xde, 0x7b, 0xdd, 0x6d, 0x56, 0x0b, 0x4f, 0xbf, 0x81, 0x6a,
+	0xb6, 0xe1, 0x43, 0x45, 0xc8, 0x37, 0x4e, 0x4f, 0xab, 0x37, 0x50, 0x05, 0x4a, 0x9d, 0xee, 0x49,
+	0xef, 0x55, 0xa7, 0xdb, 0xae, 0x6a, 0xd1, 0xa8, 0xf7, 0xba, 0xdf, 0xee, 0x45, 0xa3, 0xdc, 0xd1,
+	0xdf, 0x3a, 0x6c, 0x9f, 0x45, 0x4b, 0x69, 0x1e, 0x9f, 0xcb, 0x56, 0x1e, 0x75, 0x00, 0xae, 0x7a,
+	0x37, 0x74, 0x3f, 0xb1, 0xd2, 0x85, 0x06, 0xd8, 0x78, 0xb0, 0xc2, 0xab, 0x24, 0xfe, 0x0e, 0x36,
+	0x44, 0x3b, 0x85, 0x92, 0x7d, 0x7a, 0xb2, 0x43, 0x33, 0x6a, 0x8b, 0x0e, 0x35, 0xf7, 0x14, 0x36,
+	0x13, 0xbd, 0x0c, 0xca, 0x64, 0xca, 0x74, 0x46, 0xc6, 0xc3, 0x55, 0x6e, 0x15, 0xad, 0x07, 0x95,
+	0x64, 0x5f, 0x80, 0x32, 0xf8, 0x6c, 0xe7, 0x62, 0xec, 0xad, 0xf4, 0xa7, 0x02, 0x5e, 0xbe, 0x83,
+	0xd9, 0x80, 0xd9, 0xd7, 0xd7, 0xd8, 0x5b, 0xe9, 0x57, 0x01, 0x09, 0xa0, 0xc5, 0x27, 0x08, 0x3d,
+	0x4a, 0x4f, 0x5b, 0xfe, 0xb0, 0x19, 0x8f, 0xaf, 0x41, 0xa5, 0x52, 0x64, 0x6e, 0xd4, 0x6c, 0x8a,
+	0xe5, 0x6f, 0x93, 0xf1, 0xf8, 0x1a, 0x94, 0x4a, 0xf1, 0x23, 0x14, 0xd5, 0x9d, 0x8a, 0xee, 0x26,
+	0x66, 0xa4, 0x6f, 0x63, 0xc3, 0x58, 0xe6, 0xba, 0x8a, 0xd0, 0xa6, 0x8b, 0x11, 0xda, 0x74, 0x65,
+	0x84, 0xec, 0x65, 0x8a, 0x61, 0x2b, 0x75, 0xeb, 0xa1, 0x8c, 0xf6, 0x0b, 0xf7, 0xa4, 0xb1, 0xbf,
+	0x1a, 0x70, 0x15, 0x33, 0x75, 0x8b, 0xa4, 0x62, 0x2e, 0xbb, 0x0d, 0x8d, 0xfd, 0xd5, 0x00, 0x19,
+	0x73, 0xa8, 0x8b, 0xbf, 0x9a, 0x5f, 0xfe, 0x33, 0x00, 0x64, 0x69, 0x26, 0xc1, 0x48, 0x0f, 0x00,
+	0x00,
 }
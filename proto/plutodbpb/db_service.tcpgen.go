@@ -38,6 +38,62 @@ func RegisterPlutoDBServiceHandlers(s *tcprpc.Server, prefix string, srv PlutoDB
 			return srv.GetTxnMulti(ctx, args)
 		}))
 
+	s.Handle(prefix+"GetStatement", tcprpc.NewHandler(
+		func() proto.Message { return new(GetStatementRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetStatementRequest)
+			return srv.GetStatement(ctx, args)
+		}))
+
+	s.Handle(prefix+"GetBalanceAt", tcprpc.NewHandler(
+		func() proto.Message { return new(GetBalanceAtRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetBalanceAtRequest)
+			return srv.GetBalanceAt(ctx, args)
+		}))
+
+	s.Handle(prefix+"GetPendingIncoming", tcprpc.NewHandler(
+		func() proto.Message { return new(GetPendingIncomingRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetPendingIncomingRequest)
+			return srv.GetPendingIncoming(ctx, args)
+		}))
+
+	s.Handle(prefix+"GetSettingsHistory", tcprpc.NewHandler(
+		func() proto.Message { return new(GetSettingsHistoryRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetSettingsHistoryRequest)
+			return srv.GetSettingsHistory(ctx, args)
+		}))
+
+	s.Handle(prefix+"PutData", tcprpc.NewHandler(
+		func() proto.Message { return new(PutDataRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*PutDataRequest)
+			return srv.PutData(ctx, args)
+		}))
+
+	s.Handle(prefix+"GetData", tcprpc.NewHandler(
+		func() proto.Message { return new(GetDataRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetDataRequest)
+			return srv.GetData(ctx, args)
+		}))
+
+	s.Handle(prefix+"GetReplicaLag", tcprpc.NewHandler(
+		func() proto.Message { return new(GetReplicaLagRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*GetReplicaLagRequest)
+			return srv.GetReplicaLag(ctx, args)
+		}))
+
+	s.Handle(prefix+"StreamChanges", tcprpc.NewHandler(
+		func() proto.Message { return new(StreamChangesRequest) },
+		func(ctx context.Context, inp proto.Message) (proto.Message, error) {
+			args := inp.(*StreamChangesRequest)
+			return srv.StreamChanges(ctx, args)
+		}))
+
 }
 
 type TCPRPCPlutoDBServiceClient struct {
@@ -79,10 +135,98 @@ func (cl TCPRPCPlutoDBServiceClient) GetTxnMulti(ctx context.Context, args *GetT
 	return &resp, nil
 }
 
+func (cl TCPRPCPlutoDBServiceClient) GetStatement(ctx context.Context, args *GetStatementRequest) (*GetStatementResponse, error) {
+	var resp GetStatementResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetStatement", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCPlutoDBServiceClient) GetBalanceAt(ctx context.Context, args *GetBalanceAtRequest) (*GetBalanceAtResponse, error) {
+	var resp GetBalanceAtResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetBalanceAt", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCPlutoDBServiceClient) GetPendingIncoming(ctx context.Context, args *GetPendingIncomingRequest) (*GetPendingIncomingResponse, error) {
+	var resp GetPendingIncomingResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetPendingIncoming", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCPlutoDBServiceClient) GetSettingsHistory(ctx context.Context, args *GetSettingsHistoryRequest) (*GetSettingsHistoryResponse, error) {
+	var resp GetSettingsHistoryResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetSettingsHistory", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCPlutoDBServiceClient) PutData(ctx context.Context, args *PutDataRequest) (*PutDataResponse, error) {
+	var resp PutDataResponse
+	err := cl.cl.Call(ctx, cl.pref+"PutData", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCPlutoDBServiceClient) GetData(ctx context.Context, args *GetDataRequest) (*GetDataResponse, error) {
+	var resp GetDataResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetData", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCPlutoDBServiceClient) GetReplicaLag(ctx context.Context, args *GetReplicaLagRequest) (*GetReplicaLagResponse, error) {
+	var resp GetReplicaLagResponse
+	err := cl.cl.Call(ctx, cl.pref+"GetReplicaLag", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (cl TCPRPCPlutoDBServiceClient) StreamChanges(ctx context.Context, args *StreamChangesRequest) (*StreamChangesResponse, error) {
+	var resp StreamChangesResponse
+	err := cl.cl.Call(ctx, cl.pref+"StreamChanges", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 type PlutoDBServiceInterface interface {
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 
 	Fetch(context.Context, *FetchRequest) (*FetchResponse, error)
 
 	GetTxnMulti(context.Context, *GetTxnMultiRequest) (*GetTxnMultiResponse, error)
+
+	GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error)
+
+	GetBalanceAt(context.Context, *GetBalanceAtRequest) (*GetBalanceAtResponse, error)
+
+	GetPendingIncoming(context.Context, *GetPendingIncomingRequest) (*GetPendingIncomingResponse, error)
+
+	GetSettingsHistory(context.Context, *GetSettingsHistoryRequest) (*GetSettingsHistoryResponse, error)
+
+	PutData(context.Context, *PutDataRequest) (*PutDataResponse, error)
+
+	GetData(context.Context, *GetDataRequest) (*GetDataResponse, error)
+
+	GetReplicaLag(context.Context, *GetReplicaLagRequest) (*GetReplicaLagResponse, error)
+
+	StreamChanges(context.Context, *StreamChangesRequest) (*StreamChangesResponse, error)
 }
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"hash"
 	"strconv"
+	"strings"
 
 	"github.com/btcsuite/btcutil/base58"
 
@@ -57,6 +58,8 @@ type (
 		// Transfer Sign.
 		// If more that one transactions were in the batch only first contains sign of the whole request
 		Sign Sign
+		// Processor assigned timestamp in unix nanoseconds. It grows monotonically for each Sender.
+		ProcessedAt int64
 	}
 
 	// Settings is an account settings.
@@ -69,6 +72,16 @@ type (
 		VerifyTransferSign bool
 		DataHash           Hash
 		Sign               Sign
+		// Transfers are chained by processor in order they come, request PrevHash is ignored.
+		// Transfer sign is made with zero PrevHash then.
+		ServerSequencing bool
+		// Registered account is explicitly opened. It can't be unregistered then.
+		Registered bool
+		// Closed account is terminal. Its balance was swept to SweepTo account and no more activity is allowed.
+		Closed  bool
+		SweepTo AccID
+		// Processor assigned timestamp in unix nanoseconds. It grows monotonically for each Account.
+		ProcessedAt int64
 	}
 
 	// TransferItem is an part of Transfer request.
@@ -105,9 +118,14 @@ type ( // Services interfaces
 		ProcessTransfer(ctx context.Context, t Transfer) (TransferResult, error)
 		GetPrevHash(ctx context.Context, acc AccID) (Hash, error)
 		GetBalance(ctx context.Context, acc AccID) (int64, error)
+		// GetPendingIncoming returns incoming txns not spent by any outgoing txn yet
+		GetPendingIncoming(ctx context.Context, acc AccID) ([]Txn, error)
+		// Sweep transfers the whole balance of closed account to its SweepTo account
+		Sweep(ctx context.Context, acc AccID) (TransferResult, error)
 		SetPusher(Pusher)
 		SetPreloader(Preloader)
 		SetSettingsChain(SettingsChain)
+		SetRegistry(Registry)
 	}
 
 	SettingsChain interface {
@@ -119,9 +137,20 @@ type ( // Services interfaces
 
 	SettingsProcessor interface {
 		ProcessSettings(ctx context.Context, s *Settings) (SettingsResult, error)
+		// CloseAccount writes terminal settings. Request contains Account, SweepTo, PrevHash and Sign only,
+		// the rest is copied from the last settings.
+		CloseAccount(ctx context.Context, s *Settings) (SettingsResult, error)
 		GetLastSettings(ctx context.Context, acc AccID) (*Settings, error)
 	}
 
+	// Registry knows accounts lifecycle state.
+	Registry interface {
+		// IsOpen checks if account is registered and not closed
+		IsOpen(ctx context.Context, acc AccID) (bool, error)
+		// Closed drops cached state of just closed account. Other nodes are notified in background.
+		Closed(acc AccID)
+	}
+
 	// Chain is an local cache of last account transaftions.
 	// It's used to process new transfers fast.
 	Chain interface {
@@ -148,8 +177,11 @@ type ( // Services interfaces
 		GetHostByKey(key string) string
 		// All nodes hostnames of the cluster
 		Nodes() []string
-		// Sets cluster nodes
-		SetNodes(nodes []string)
+		// Routing table version
+		Epoch() uint64
+		// Sets cluster nodes if epoch is not older than current one.
+		// Returns false if table was rejected
+		SetEpochNodes(epoch uint64, nodes []string) bool
 		// Checks if given node is our own, so we must process request not redirect it
 		IsSelf(node string) bool
 	}
@@ -276,10 +308,25 @@ func GetHash(h hash.Hash, txn *Txn) Hash {
 
 	h.Write(txn.PrevHash[:])
 
+	// txns processed before timestamps were introduced keep their hashes
+	if txn.ProcessedAt != 0 {
+		order.PutUint64(buf, uint64(txn.ProcessedAt))
+		h.Write(buf[:8])
+	}
+
 	_ = h.Sum(buf[:0])
 	return txn.Hash
 }
 
+// GetDataHash returns hash of settings user data which is set as Settings.DataHash
+func GetDataHash(data []byte) Hash {
+	var r Hash
+	h := HashNew()
+	h.Write(data)
+	h.Sum(r[:0])
+	return r
+}
+
 func GetSettingsHashDefault(s *Settings) Hash {
 	h := HashNew()
 	return GetSettingsHash(h, s)
@@ -301,21 +348,46 @@ func GetSettingsHash(h hash.Hash, s *Settings) Hash {
 	order.PutUint64(buf, uint64(s.Account))
 	h.Write(buf[:8])
 
-	if s.VerifyTransferSign {
-		buf[0] = 1
-	} else {
-		buf[0] = 0
-	}
+	buf[0] = settingsFlags(s)
 	h.Write(buf[:1])
 
 	h.Write(s.PrevHash[:])
 	h.Write(s.PublicKey[:])
 	h.Write(s.DataHash[:])
 
+	if s.Closed {
+		order.PutUint64(buf, uint64(s.SweepTo))
+		h.Write(buf[:8])
+	}
+
+	if s.ProcessedAt != 0 {
+		order.PutUint64(buf, uint64(s.ProcessedAt))
+		h.Write(buf[:8])
+	}
+
 	_ = h.Sum(buf[:0])
 	return s.Hash
 }
 
+// settingsFlags packs settings flags into a byte.
+// Settings without new flags have the same hash as before they were added.
+func settingsFlags(s *Settings) byte {
+	var f byte
+	if s.VerifyTransferSign {
+		f |= 1
+	}
+	if s.ServerSequencing {
+		f |= 2
+	}
+	if s.Registered {
+		f |= 4
+	}
+	if s.Closed {
+		f |= 8
+	}
+	return f
+}
+
 func GetTransferHashDefault(t Transfer) Hash {
 	h := HashNew()
 	return GetTransferHash(h, t)
@@ -371,21 +443,53 @@ func GetSettingsRequestHash(h hash.Hash, s *Settings) Hash {
 	order.PutUint64(buf, uint64(s.Account))
 	h.Write(buf[:8])
 
-	if s.VerifyTransferSign {
-		buf[0] = 1
-	} else {
-		buf[0] = 0
-	}
+	buf[0] = settingsFlags(s)
 	h.Write(buf[:1])
 
 	h.Write(s.PrevHash[:])
 	h.Write(s.PublicKey[:])
 	h.Write(s.DataHash[:])
 
+	if s.Closed {
+		order.PutUint64(buf, uint64(s.SweepTo))
+		h.Write(buf[:8])
+	}
+
 	_ = h.Sum(buf[:0])
 	return s.Hash
 }
 
+func GetAliasHashDefault(alias string, acc AccID) Hash {
+	h := HashNew()
+	return GetAliasHash(h, alias, acc)
+}
+
+// NormalizeAlias returns alias as it's stored: case insensitive and without surrounding spaces
+func NormalizeAlias(a string) string {
+	return strings.ToLower(strings.TrimSpace(a))
+}
+
+// GetAliasHash is a hash account signs to prove alias ownership.
+// Alias must be normalized by NormalizeAlias.
+func GetAliasHash(h hash.Hash, alias string, acc AccID) Hash {
+	var hbuf Hash
+	if h.Size() != len(hbuf) {
+		panic("hash size differs")
+	}
+
+	h.Reset()
+
+	buf := hbuf[:]
+
+	h.Write([]byte("alias:"))
+	binary.BigEndian.PutUint64(buf, uint64(acc))
+	h.Write(buf[:8])
+	h.Write([]byte(alias))
+
+	h.Sum(buf[:0])
+	return hbuf
+}
+
 func NewSingleTransfer(sender, receiver AccID, amount int64) Transfer {
 	transfer := Transfer{Sender: sender}
 	transfer.AddReceiver(receiver, amount)
@@ -102,6 +102,24 @@ func TestHash(t *testing.T) {
 	assert.Equal(t, HashFromString("fb175d2e658883ed5adae1e15602a70fc7d132b38d03cc42af86350319b766ab"), GetHashDefault(txn))
 }
 
+func TestHashProcessedAt(t *testing.T) {
+	txn := &Txn{ID: 1, Sender: 10, Receiver: 20, Amount: 2000, Balance: 3000, PrevHash: HashFromString("123123")}
+	h := GetHashDefault(txn)
+
+	txn.ProcessedAt = 1500000000000000000
+	ts := GetHashDefault(txn)
+	assert.NotEqual(t, h, ts)
+
+	txn.ProcessedAt++
+	assert.NotEqual(t, ts, GetHashDefault(txn))
+
+	s := &Settings{ID: 1, Account: 20, PrevHash: HashFromString("123123")}
+	h = GetSettingsHashDefault(s)
+
+	s.ProcessedAt = 1500000000000000000
+	assert.NotEqual(t, h, GetSettingsHashDefault(s))
+}
+
 func BenchmarkGetHash(b *testing.B) {
 	txn := &Txn{
 		ID:       1,
@@ -144,6 +162,28 @@ func TestGetSettingsHash(t *testing.T) {
 	assert.Equal(t, HashFromString("c85a0e427c75f05bafd0f873b9b98ee4bc2f3e6a9f71388ec0f7391fb509fc68"), GetSettingsHashDefault(s))
 	s.VerifyTransferSign = false
 	assert.Equal(t, HashFromString("437937ce2e5b49b0f9e4a18b034491c2ceec1d051b325ea9c122fb8ef5fca57c"), GetSettingsHashDefault(s))
+	s.ServerSequencing = true
+	assert.NotEqual(t, HashFromString("437937ce2e5b49b0f9e4a18b034491c2ceec1d051b325ea9c122fb8ef5fca57c"), GetSettingsHashDefault(s))
+}
+
+func TestGetSettingsHashLifecycle(t *testing.T) {
+	s := &Settings{ID: 1, Account: 20, PrevHash: HashFromString("123123")}
+	open := GetSettingsHashDefault(s)
+
+	s.Registered = true
+	registered := GetSettingsHashDefault(s)
+	assert.NotEqual(t, open, registered)
+
+	// SweepTo is meaningful for closed account only
+	s.SweepTo = 30
+	assert.Equal(t, registered, GetSettingsHashDefault(s))
+
+	s.Closed = true
+	closed := GetSettingsHashDefault(s)
+	assert.NotEqual(t, registered, closed)
+
+	s.SweepTo = 31
+	assert.NotEqual(t, closed, GetSettingsHashDefault(s))
 }
 
 func TestGetTransferHash(t *testing.T) {
@@ -155,6 +195,13 @@ func TestGetTransferHash(t *testing.T) {
 	assert.Equal(t, HashFromString("f4e6b88b76c9dd326b2fff91a0848ece9a6e20c1a44ffdba724dddccf507df42"), h)
 }
 
+func TestGetAliasHash(t *testing.T) {
+	h := GetAliasHashDefault("alice", 10)
+	assert.Equal(t, h, GetAliasHashDefault("alice", 10))
+	assert.NotEqual(t, h, GetAliasHashDefault("alice", 11))
+	assert.NotEqual(t, h, GetAliasHashDefault("alicE", 10))
+}
+
 func TestSignTransfer(t *testing.T) {
 	transfer := NewSingleTransfer(0, 10, 20)
 	transfer.PrevHash = HashFromString("d1365234717958d8489b700f900bfaa0ecf0db5b137c25a5b43058de75f118a1")
@@ -336,3 +383,12 @@ func TestCoverStrings(t *testing.T) {
 	txn := &Txn{Sender: 10, Receiver: 30, ID: 4}
 	txn.String()
 }*/
+
+func TestGetDataHash(t *testing.T) {
+	h := HashNew()
+	h.Write([]byte("data"))
+
+	res := GetDataHash([]byte("data"))
+	assert.Equal(t, h.Sum(nil), res[:])
+	assert.NotEqual(t, GetDataHash(nil), GetDataHash([]byte("data")))
+}
@@ -16,14 +16,15 @@ func txnsToProto(in []pt.Txn) []*chainpb.Txn {
 	for i := range in {
 		t := in[i]
 		txns[i] = &chainpb.Txn{
-			ID:         uint64(t.ID),
-			Sender:     uint64(t.Sender),
-			Receiver:   uint64(t.Receiver),
-			Amount:     t.Amount,
-			Balance:    t.Balance,
-			SpentBy:    uint64(t.SpentBy),
-			SettingsId: uint64(t.SettingsID),
-			PrevHash:   t.PrevHash[:],
+			ID:          uint64(t.ID),
+			Sender:      uint64(t.Sender),
+			Receiver:    uint64(t.Receiver),
+			Amount:      t.Amount,
+			Balance:     t.Balance,
+			SpentBy:     uint64(t.SpentBy),
+			SettingsId:  uint64(t.SettingsID),
+			PrevHash:    t.PrevHash[:],
+			ProcessedAt: t.ProcessedAt,
 		}
 		if t.Hash != pt.ZeroHash {
 			txns[i].Hash = t.Hash[:]
@@ -37,13 +38,19 @@ func txnsToProto(in []pt.Txn) []*chainpb.Txn {
 
 func settToProto(in *pt.Settings) *chainpb.Settings {
 	sett := &chainpb.Settings{
-		ID:        uint64(in.ID),
-		Account:   uint64(in.Account),
-		Hash:      in.Hash[:],
-		PrevHash:  in.PrevHash[:],
-		PublicKey: in.PublicKey[:],
-		Sign:      in.Sign[:],
-		DataHash:  in.DataHash[:],
+		ID:                 uint64(in.ID),
+		Account:            uint64(in.Account),
+		Hash:               in.Hash[:],
+		PrevHash:           in.PrevHash[:],
+		PublicKey:          in.PublicKey[:],
+		Sign:               in.Sign[:],
+		DataHash:           in.DataHash[:],
+		VerifyTransferSign: in.VerifyTransferSign,
+		ServerSequencing:   in.ServerSequencing,
+		Registered:         in.Registered,
+		Closed:             in.Closed,
+		SweepTo:            uint64(in.SweepTo),
+		ProcessedAt:        in.ProcessedAt,
 	}
 	return sett
 }
@@ -64,9 +71,14 @@ func NewHTTPClient(baseurl string) pt.Pusher {
 }
 
 func NewDBPusher(baseurl string) *PusherClient {
+	return NewPrefixDBPusher(baseurl, "v1/")
+}
+
+// NewPrefixDBPusher creates client for pusher services registered with given prefix
+func NewPrefixDBPusher(baseurl, prefix string) *PusherClient {
 	g := tcprpc.NewClient(baseurl)
-	txns := pusherpb.NewTCPRPCPusherServiceClient(g, "v1/")
-	settings := pusherpb.NewTCPRPCSettingsPusherServiceClient(g, "v1/")
+	txns := pusherpb.NewTCPRPCPusherServiceClient(g, prefix)
+	settings := pusherpb.NewTCPRPCSettingsPusherServiceClient(g, prefix)
 	return NewClient(txns, settings)
 }
 
@@ -27,6 +27,8 @@ func txnsFromProto(in []*chainpb.Txn) ([]pt.Txn, error) {
 		txns[i].Amount = t.Amount
 		txns[i].Balance = t.Balance
 		txns[i].SpentBy = pt.ID(t.SpentBy)
+		txns[i].SettingsID = pt.ID(t.SettingsId)
+		txns[i].ProcessedAt = t.ProcessedAt
 
 		if len(t.PrevHash) != 0 && len(t.PrevHash) != len(pt.ZeroHash) {
 			return nil, errors.Errorf("invalid prev_hash size %d for txn_id=%d, sender_id=%d", len(t.PrevHash), t.ID, t.Sender)
@@ -22,9 +22,15 @@ func settFromProto(in []*chainpb.Settings) ([]pt.Settings, error) {
 	sett := make([]pt.Settings, len(in))
 	for i, s := range in {
 		sett[i] = pt.Settings{
-			ID:        pt.ID(s.ID),
-			Account:   pt.AccID(s.Account),
-			PublicKey: make([]byte, len(s.PublicKey)),
+			ID:                 pt.ID(s.ID),
+			Account:            pt.AccID(s.Account),
+			PublicKey:          make([]byte, len(s.PublicKey)),
+			VerifyTransferSign: s.VerifyTransferSign,
+			ServerSequencing:   s.ServerSequencing,
+			Registered:         s.Registered,
+			Closed:             s.Closed,
+			SweepTo:            pt.AccID(s.SweepTo),
+			ProcessedAt:        s.ProcessedAt,
 		}
 		copy(sett[i].Hash[:], s.Hash)
 		copy(sett[i].PrevHash[:], s.PrevHash)
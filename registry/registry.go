@@ -0,0 +1,217 @@
+// Package registry tells if account is registered and not closed.
+// Accounts state is taken from BigChain which is the only point of truth.
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/pkg/errors"
+	"github.com/pressly/chi"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/qiwitech/qdp/pt"
+)
+
+var (
+	Lookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "plutos",
+		Subsystem: "registry",
+		Name:      "lookups",
+		Help:      "number of account state lookups by source (cache|bigchain)",
+	}, []string{"source"})
+)
+
+func init() {
+	prometheus.MustRegister(Lookups)
+}
+
+type entry struct {
+	open   bool
+	closed bool
+	exp    time.Time
+}
+
+// Registry caches accounts state fetched from BigChain.
+// Closed state is terminal so it's cached forever. Open state is cached for TTL.
+// Unregistered accounts are not cached, so registration is seen immediately.
+// Closed accounts are dropped from cache of all the cluster nodes by Closed (best effort),
+// so TTL only matters if notification was lost.
+type Registry struct {
+	mu       sync.Mutex
+	cache    map[pt.AccID]entry
+	bigchain pt.BigChain
+	router   pt.Router
+
+	TTL time.Duration
+	now func() time.Time
+}
+
+func New(bigchain pt.BigChain) *Registry {
+	return &Registry{
+		cache:    make(map[pt.AccID]entry),
+		bigchain: bigchain,
+		TTL:      time.Minute,
+		now:      time.Now,
+	}
+}
+
+// SetRouter enables notifying other cluster nodes about closed accounts
+func (r *Registry) SetRouter(router pt.Router) {
+	r.router = router
+}
+
+// IsOpen checks if account is registered and not closed
+func (r *Registry) IsOpen(ctx context.Context, acc pt.AccID) (bool, error) {
+	now := r.now()
+
+	r.mu.Lock()
+	e, ok := r.cache[acc]
+	r.mu.Unlock()
+
+	if ok && (e.closed || now.Before(e.exp)) {
+		Lookups.WithLabelValues("cache").Inc()
+		return e.open, nil
+	}
+
+	Lookups.WithLabelValues("bigchain").Inc()
+
+	_, sett, err := r.bigchain.Fetch(ctx, acc, 0)
+	if err != nil {
+		return false, errors.Wrap(err, "fetch")
+	}
+
+	if sett == nil || !sett.Registered && !sett.Closed {
+		return false, nil
+	}
+
+	e = entry{
+		open:   !sett.Closed,
+		closed: sett.Closed,
+		exp:    now.Add(r.TTL),
+	}
+
+	r.mu.Lock()
+	r.cache[acc] = e
+	r.mu.Unlock()
+
+	return e.open, nil
+}
+
+// Closed marks account closed and notifies other nodes in background.
+// Notifications outlive the request closed the account, so they have their own timeouts.
+func (r *Registry) Closed(acc pt.AccID) {
+	r.markClosed(acc)
+
+	if r.router == nil {
+		return
+	}
+
+	for _, n := range hosts(r.router.Nodes()) {
+		if r.router.IsSelf(n) {
+			continue
+		}
+		go func(n string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			err := notify(ctx, n, acc)
+			if err != nil {
+				log.Printf("registry: notify %v about closed %v: %v", n, acc, err)
+			}
+		}(n)
+	}
+}
+
+// hosts strips shard points of static router table and dedups nodes owning several shards
+func hosts(nodes []string) []string {
+	var res []string
+	seen := make(map[string]struct{})
+	for _, n := range nodes {
+		if i := strings.Index(n, "="); i != -1 {
+			n = n[i+1:]
+		}
+		if _, ok := seen[n]; ok {
+			continue
+		}
+		seen[n] = struct{}{}
+		res = append(res, n)
+	}
+	return res
+}
+
+func (r *Registry) markClosed(acc pt.AccID) {
+	defer r.mu.Unlock()
+	r.mu.Lock()
+
+	r.cache[acc] = entry{closed: true}
+}
+
+type HTTPData struct {
+	Account pt.AccID
+	Error   string `json:"error,omitempty"`
+}
+
+// Handler receives closed accounts notifications from other nodes
+func Handler(r *Registry) http.Handler {
+	e := chi.NewRouter()
+
+	e.Post("/cfg/registry/closed", func(w http.ResponseWriter, req *http.Request) {
+		var d HTTPData
+
+		err := render.DecodeJSON(req.Body, &d)
+		if err != nil {
+			render.JSON(w, req, map[string]string{"error": err.Error()})
+			return
+		}
+
+		r.markClosed(d.Account)
+
+		render.JSON(w, req, d)
+	})
+
+	return e
+}
+
+func notify(ctx context.Context, host string, acc pt.AccID) error {
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(HTTPData{Account: acc})
+	if err != nil {
+		return errors.Wrap(err, "encode request")
+	}
+
+	u := url.URL{Scheme: "http", Host: host, Path: "/cfg/registry/closed"}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("bad response status: %v", resp.Status)
+	}
+
+	var d HTTPData
+	err = json.NewDecoder(resp.Body).Decode(&d)
+	if err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+	if d.Error != "" {
+		return errors.New(d.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/mocks"
+	"github.com/qiwitech/qdp/pt"
+	"github.com/qiwitech/qdp/router"
+)
+
+func TestRegistry(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	bc := mocks.NewMockBigChain(mock)
+
+	r := New(bc)
+	now := time.Unix(1000, 0)
+	r.now = func() time.Time { return now }
+
+	// unregistered isn't cached
+	bc.EXPECT().Fetch(gomock.Any(), pt.AccID(1), 0).Times(2).Return(nil, nil, nil)
+	bc.EXPECT().Fetch(gomock.Any(), pt.AccID(2), 0).Times(2).Return(nil, &pt.Settings{Account: 2, Registered: true}, nil)
+	bc.EXPECT().Fetch(gomock.Any(), pt.AccID(3), 0).Times(1).Return(nil, &pt.Settings{Account: 3, Registered: true, Closed: true}, nil)
+	bc.EXPECT().Fetch(gomock.Any(), pt.AccID(4), 0).Times(1).Return(nil, nil, errors.New("db"))
+
+	for i := 0; i < 2; i++ {
+		ok, err := r.IsOpen(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		ok, err = r.IsOpen(context.TODO(), 2)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = r.IsOpen(context.TODO(), 3)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	}
+
+	// open state expires, closed doesn't
+	now = now.Add(2 * r.TTL)
+
+	ok, err := r.IsOpen(context.TODO(), 2)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = r.IsOpen(context.TODO(), 3)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = r.IsOpen(context.TODO(), 4)
+	assert.Error(t, err)
+}
+
+func TestRegistryClosed(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	bc := mocks.NewMockBigChain(mock)
+	bc.EXPECT().Fetch(gomock.Any(), pt.AccID(2), 0).Times(1).Return(nil, &pt.Settings{Account: 2, Registered: true}, nil)
+	bc.EXPECT().Fetch(gomock.Any(), pt.AccID(3), 0).Times(1).Return(nil, &pt.Settings{Account: 3, Registered: true}, nil)
+
+	remote := New(bc)
+	srv := httptest.NewServer(Handler(remote))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	r := New(bc)
+
+	router := mocks.NewMockRouter(mock)
+	router.EXPECT().Nodes().Return([]string{"self", host}).Times(2)
+	router.EXPECT().IsSelf("self").Return(true).Times(2)
+	router.EXPECT().IsSelf(host).Return(false).Times(2)
+	r.SetRouter(router)
+
+	ok, err := r.IsOpen(context.TODO(), 2)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = remote.IsOpen(context.TODO(), 3)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// local cache is dropped at once
+	r.Closed(2)
+
+	ok, err = r.IsOpen(context.TODO(), 2)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// remote one is notified
+	r.Closed(3)
+
+	for i := 0; i < 100; i++ {
+		ok, err = remote.IsOpen(context.TODO(), 3)
+		if err != nil || !ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRegistryClosedStaticRouter(t *testing.T) {
+	var selfHits, remoteHits int32
+	count := func(n *int32, h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(n, 1)
+			h.ServeHTTP(w, req)
+		})
+	}
+
+	remote := New(nil)
+	rsrv := httptest.NewServer(count(&remoteHits, Handler(remote)))
+	defer rsrv.Close()
+	rhost := strings.TrimPrefix(rsrv.URL, "http://")
+
+	r := New(nil)
+	ssrv := httptest.NewServer(count(&selfHits, Handler(r)))
+	defer ssrv.Close()
+	shost := strings.TrimPrefix(ssrv.URL, "http://")
+
+	// remote owns two shards
+	rt := router.NewStatic(shost)
+	assert.True(t, rt.SetEpochNodes(1, []string{"0=" + shost, "100=" + rhost, "200=" + rhost}))
+	r.SetRouter(rt)
+
+	r.Closed(4)
+
+	for i := 0; i < 100 && atomic.LoadInt32(&remoteHits) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&remoteHits))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&selfHits))
+
+	ok, err := remote.IsOpen(context.TODO(), 4)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
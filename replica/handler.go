@@ -0,0 +1,62 @@
+package replica
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/render"
+	"github.com/pkg/errors"
+	"github.com/pressly/chi"
+)
+
+type HTTPData struct {
+	Promoted bool
+}
+
+func Handler(r *Replica) http.Handler {
+	e := chi.NewRouter()
+
+	e.Get("/cfg/replica", func(w http.ResponseWriter, req *http.Request) {
+		render.JSON(w, req, HTTPData{Promoted: r.IsPromoted()})
+	})
+	e.Post("/cfg/replica/promote", func(w http.ResponseWriter, req *http.Request) {
+		r.Promote()
+
+		render.JSON(w, req, HTTPData{Promoted: r.IsPromoted()})
+	})
+
+	return e
+}
+
+// PromoteNode calls promote handler at standby node
+func PromoteNode(ctx context.Context, host string) error {
+	u := url.URL{Scheme: "http", Host: host, Path: "/cfg/replica/promote"}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "promote")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("bad response status: %v", resp.Status)
+	}
+
+	var d HTTPData
+	err = json.NewDecoder(resp.Body).Decode(&d)
+	if err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+
+	if !d.Promoted {
+		return errors.New("replica wasn't promoted")
+	}
+
+	return nil
+}
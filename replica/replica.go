@@ -0,0 +1,146 @@
+// Package replica implements hot-standby plutos node.
+//
+// Primary pushes its committed transactions and settings to standby through Pusher
+// and standby keeps warm chain.Chain and chain.SettingsChain for the primary's accounts.
+// When primary fails standby is promoted and routing table is switched to it.
+package replica
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/qiwitech/qdp/pt"
+)
+
+// Prefix is a prefix replication pusher services are registered with at standby node
+const Prefix = "replica/v1/"
+
+var (
+	ReplicationErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "plutos",
+		Subsystem: "replica",
+		Name:      "push_errors",
+		Help:      "number of failed pushes to standby replicas",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(ReplicationErrors)
+}
+
+var ErrPromoted = errors.New("replica: promoted, replication is stopped")
+
+// Replica is a standby side. It applies primary's transactions to local cache.
+type Replica struct {
+	mu       sync.Mutex
+	promoted bool
+
+	chain         pt.Chain
+	settingsChain pt.SettingsChain
+	preloader     pt.Preloader
+}
+
+func New(chain pt.Chain, settingsChain pt.SettingsChain, preloader pt.Preloader) *Replica {
+	return &Replica{
+		chain:         chain,
+		settingsChain: settingsChain,
+		preloader:     preloader,
+	}
+}
+
+// Push applies transactions committed by primary.
+// The first one must be an output transaction, others are outputs of the same sender or its inputs spent (as Processor pushes them).
+// Account is preloaded from BigChain when it's seen for the first time or if some transactions were missed.
+func (r *Replica) Push(ctx context.Context, txns []pt.Txn) error {
+	if r.IsPromoted() {
+		return ErrPromoted
+	}
+	if len(txns) == 0 {
+		return nil
+	}
+
+	acc := txns[0].Sender
+
+	if last := r.chain.GetLastTxn(acc); last != nil && last.ID+1 != txns[0].ID {
+		// we've missed something, refetch account
+		r.preloader.Reset(ctx, acc)
+	}
+
+	err := r.preloader.Preload(ctx, acc)
+	if err != nil {
+		return errors.Wrap(err, "replica preload")
+	}
+
+	r.chain.PutTo(acc, txns)
+
+	return nil
+}
+
+// PushSettings applies settings committed by primary
+func (r *Replica) PushSettings(ctx context.Context, sett *pt.Settings) error {
+	if r.IsPromoted() {
+		return ErrPromoted
+	}
+
+	err := r.preloader.Preload(ctx, sett.Account)
+	if err != nil {
+		return errors.Wrap(err, "replica preload")
+	}
+
+	r.settingsChain.Put(sett)
+
+	return nil
+}
+
+// Promote stops replication, so zombie primary can't change our state anymore.
+// Routing table must be switched to this node after that.
+func (r *Replica) Promote() {
+	defer r.mu.Unlock()
+	r.mu.Lock()
+
+	r.promoted = true
+}
+
+func (r *Replica) IsPromoted() bool {
+	defer r.mu.Unlock()
+	r.mu.Lock()
+
+	return r.promoted
+}
+
+// Pusher is a primary side. It pushes committed data to standby.
+// Standby failures don't fail primary requests, they are logged and counted.
+// Standby refetches account from BigChain if it misses some transactions.
+type Pusher struct {
+	name     string
+	pusher   pt.Pusher
+	settings pt.SettingsPusher
+}
+
+func NewPusher(name string, pusher pt.Pusher, settings pt.SettingsPusher) *Pusher {
+	return &Pusher{
+		name:     name,
+		pusher:   pusher,
+		settings: settings,
+	}
+}
+
+func (p *Pusher) Push(ctx context.Context, txns []pt.Txn) error {
+	if err := p.pusher.Push(ctx, txns); err != nil {
+		ReplicationErrors.Inc()
+		log.Printf("replica %v: push: %v", p.name, err)
+	}
+	return nil
+}
+
+func (p *Pusher) PushSettings(ctx context.Context, sett *pt.Settings) error {
+	if err := p.settings.PushSettings(ctx, sett); err != nil {
+		ReplicationErrors.Inc()
+		log.Printf("replica %v: push settings: %v", p.name, err)
+	}
+	return nil
+}
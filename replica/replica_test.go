@@ -0,0 +1,100 @@
+package replica
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/chain"
+	"github.com/qiwitech/qdp/mocks"
+	"github.com/qiwitech/qdp/pt"
+)
+
+func TestReplicaPush(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	c := chain.NewChain()
+	sc := chain.NewSettingsChain()
+	pl := mocks.NewMockPreloader(mock)
+
+	r := New(c, sc, pl)
+
+	pl.EXPECT().Preload(gomock.Any(), pt.AccID(1)).Return(nil)
+
+	err := r.Push(context.TODO(), []pt.Txn{{ID: 1, Sender: 1, Receiver: 2, Amount: 10, Balance: 90}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(90), c.GetBalance(1))
+
+	pl.EXPECT().Preload(gomock.Any(), pt.AccID(1)).Return(nil)
+
+	err = r.Push(context.TODO(), []pt.Txn{
+		{ID: 2, Sender: 1, Receiver: 2, Amount: 10, Balance: 100},
+		{ID: 5, Sender: 3, Receiver: 1, Amount: 20, SpentBy: 2},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), c.GetBalance(1))
+
+	// gap
+	pl.EXPECT().Reset(gomock.Any(), pt.AccID(1))
+	pl.EXPECT().Preload(gomock.Any(), pt.AccID(1)).Return(nil)
+
+	err = r.Push(context.TODO(), []pt.Txn{{ID: 4, Sender: 1, Receiver: 2, Amount: 10, Balance: 80}})
+	assert.NoError(t, err)
+
+	// preload error
+	pl.EXPECT().Preload(gomock.Any(), pt.AccID(7)).Return(errors.New("db is down"))
+
+	err = r.Push(context.TODO(), []pt.Txn{{ID: 1, Sender: 7, Receiver: 2, Amount: 10}})
+	assert.EqualError(t, err, "replica preload: db is down")
+}
+
+func TestReplicaPushSettings(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	sc := chain.NewSettingsChain()
+	pl := mocks.NewMockPreloader(mock)
+
+	r := New(chain.NewChain(), sc, pl)
+
+	pl.EXPECT().Preload(gomock.Any(), pt.AccID(1)).Return(nil)
+
+	s := &pt.Settings{ID: 1, Account: 1}
+	err := r.PushSettings(context.TODO(), s)
+	assert.NoError(t, err)
+	assert.True(t, s == sc.GetLastSettings(1))
+}
+
+func TestReplicaPromote(t *testing.T) {
+	r := New(chain.NewChain(), chain.NewSettingsChain(), nil)
+
+	assert.False(t, r.IsPromoted())
+	r.Promote()
+	assert.True(t, r.IsPromoted())
+
+	err := r.Push(context.TODO(), []pt.Txn{{ID: 1, Sender: 1}})
+	assert.Equal(t, ErrPromoted, err)
+
+	err = r.PushSettings(context.TODO(), &pt.Settings{ID: 1, Account: 1})
+	assert.Equal(t, ErrPromoted, err)
+}
+
+func TestPusherIgnoresErrors(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	p := mocks.NewMockPusher(mock)
+	sp := mocks.NewMockSettingsPusher(mock)
+
+	rp := NewPusher("standby", p, sp)
+
+	p.EXPECT().Push(gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+	sp.EXPECT().PushSettings(gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+
+	assert.NoError(t, rp.Push(context.TODO(), []pt.Txn{{ID: 1}}))
+	assert.NoError(t, rp.PushSettings(context.TODO(), &pt.Settings{ID: 1}))
+}
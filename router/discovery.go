@@ -0,0 +1,236 @@
+package router
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Source provides actual list of cluster nodes.
+// self is an address of current node if source knows it or empty string.
+// epoch is the list version set by operator or zero if source has no versions.
+type Source interface {
+	Discover(ctx context.Context) (self string, nodes []string, epoch uint64, err error)
+}
+
+// NewSource creates Source by its description:
+//
+//	srv://_plutos._tcp.example.com - DNS SRV records
+//	file:///etc/plutos/nodes - JSON ({"Nodes": [...], "Epoch": N} or [...]) or text (one node per line) file
+//	service:port - docker swarm service (see CheckService)
+func NewSource(s string) (Source, error) {
+	if !strings.Contains(s, "://") {
+		return SwarmSource(s), nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse source")
+	}
+
+	switch u.Scheme {
+	case "srv":
+		return SRVSource(u.Host), nil
+	case "file":
+		return FileSource(u.Path), nil
+	default:
+		return nil, errors.Errorf("unsupported discovery source: %v", u.Scheme)
+	}
+}
+
+// SwarmSource discovers nodes of docker swarm service by resolving its name
+type SwarmSource string
+
+func (s SwarmSource) Discover(ctx context.Context) (string, []string, uint64, error) {
+	_, p, err := net.SplitHostPort(string(s))
+	if err != nil {
+		return "", nil, 0, errors.Wrap(err, "split host port")
+	}
+
+	me, addrs, err := CheckService(string(s))
+	if err != nil {
+		return "", nil, 0, errors.Wrap(err, "check service")
+	}
+
+	if me != "" {
+		me = me + ":" + p
+	}
+
+	nodes := make([]string, len(addrs))
+	for i, addr := range addrs {
+		nodes[i] = fmt.Sprintf("%s:%s", addr, p)
+	}
+
+	sort.Strings(nodes)
+
+	return me, nodes, 0, nil
+}
+
+var lookupSRV = net.DefaultResolver.LookupSRV
+
+// SRVSource discovers nodes by DNS SRV records of given name
+type SRVSource string
+
+func (s SRVSource) Discover(ctx context.Context) (string, []string, uint64, error) {
+	_, recs, err := lookupSRV(ctx, "", "", string(s))
+	if err != nil {
+		return "", nil, 0, errors.Wrap(err, "lookup srv")
+	}
+
+	nodes := make([]string, len(recs))
+	for i, r := range recs {
+		nodes[i] = net.JoinHostPort(strings.TrimSuffix(r.Target, "."), fmt.Sprintf("%d", r.Port))
+	}
+
+	sort.Strings(nodes)
+
+	return "", nodes, 0, nil
+}
+
+// FileSource reads nodes list from file.
+// It's JSON ({"Nodes": [...], "Epoch": N} or just [...]) or text file with one node per line. Empty lines and lines started with # are skipped.
+// Only JSON object could set epoch.
+// File is reread every time, so it's could be changed at any time.
+type FileSource string
+
+func (s FileSource) Discover(ctx context.Context) (string, []string, uint64, error) {
+	data, err := ioutil.ReadFile(string(s))
+	if err != nil {
+		return "", nil, 0, errors.Wrap(err, "read")
+	}
+
+	data = bytes.TrimSpace(data)
+
+	var nodes []string
+	var epoch uint64
+	switch {
+	case bytes.HasPrefix(data, []byte("[")):
+		err = json.Unmarshal(data, &nodes)
+	case bytes.HasPrefix(data, []byte("{")):
+		var d HTTPData
+		err = json.Unmarshal(data, &d)
+		nodes, epoch = d.Nodes, d.Epoch
+	default:
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		for sc.Scan() {
+			l := strings.TrimSpace(sc.Text())
+			if l == "" || strings.HasPrefix(l, "#") {
+				continue
+			}
+			nodes = append(nodes, l)
+		}
+		err = sc.Err()
+	}
+	if err != nil {
+		return "", nil, 0, errors.Wrap(err, "parse")
+	}
+
+	return "", nodes, epoch, nil
+}
+
+// Discovery periodically updates router nodes from Source.
+// New nodes list is applied only after it was seen Stable times in a row, so membership doesn't flap.
+type Discovery struct {
+	r   UpdatableRouter
+	src Source
+
+	Interval time.Duration
+	Stable   int
+
+	current      []string
+	epoch        uint64
+	pending      []string
+	pendingEpoch uint64
+	seen         int
+}
+
+func NewDiscovery(r UpdatableRouter, src Source) *Discovery {
+	return &Discovery{
+		r:        r,
+		src:      src,
+		Interval: 10 * time.Second,
+		Stable:   3,
+	}
+}
+
+// Check discovers nodes once and updates router if needed. It returns true if router was updated.
+func (d *Discovery) Check(ctx context.Context) (bool, error) {
+	self, nodes, epoch, err := d.src.Discover(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "discover")
+	}
+	if len(nodes) == 0 {
+		return false, errors.New("no nodes discovered")
+	}
+	if err = ValidateNodes(nodes); err != nil {
+		return false, errors.Wrap(err, "discovered nodes")
+	}
+
+	if epoch == 0 {
+		epoch = TableEpoch(nodes)
+	}
+
+	if d.current != nil && epoch == d.epoch && reflect.DeepEqual(nodes, d.current) {
+		d.pending = nil
+		d.seen = 0
+		return false, nil
+	}
+
+	if epoch == d.pendingEpoch && reflect.DeepEqual(nodes, d.pending) {
+		d.seen++
+	} else {
+		d.pending, d.pendingEpoch = nodes, epoch
+		d.seen = 1
+	}
+
+	// first discovered list is applied immediately
+	if d.current != nil && d.seen < d.Stable {
+		return false, nil
+	}
+
+	if self != "" {
+		d.r.SetSelf(self)
+	}
+	if !d.r.SetEpochNodes(epoch, nodes) {
+		return false, errors.Errorf("discovered table rejected at epoch %d, current is %d", epoch, d.r.Epoch())
+	}
+
+	d.current, d.epoch = nodes, epoch
+	d.pending = nil
+	d.seen = 0
+
+	return true, nil
+}
+
+// Run checks Source every Interval until ctx is done
+func (d *Discovery) Run(ctx context.Context) {
+	t := time.NewTicker(d.Interval)
+	defer t.Stop()
+
+	for {
+		updated, err := d.Check(ctx)
+		if err != nil {
+			log.Printf("router discovery: %v", err)
+		} else if updated {
+			log.Printf("router discovery: nodes updated: %q", d.current)
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
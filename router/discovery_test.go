@@ -0,0 +1,184 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSource(t *testing.T) {
+	s, err := NewSource("srv://_plutos._tcp.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, SRVSource("_plutos._tcp.example.com"), s)
+
+	s, err = NewSource("file:///etc/plutos/nodes")
+	assert.NoError(t, err)
+	assert.Equal(t, FileSource("/etc/plutos/nodes"), s)
+
+	s, err = NewSource("plutos:31337")
+	assert.NoError(t, err)
+	assert.Equal(t, SwarmSource("plutos:31337"), s)
+
+	_, err = NewSource("zk://host/path")
+	assert.EqualError(t, err, "unsupported discovery source: zk")
+}
+
+func TestSRVSource(t *testing.T) {
+	defer func() {
+		lookupSRV = net.DefaultResolver.LookupSRV
+	}()
+
+	lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		assert.Equal(t, "_plutos._tcp.example.com", name)
+		return "", []*net.SRV{
+			{Target: "b.example.com.", Port: 31337},
+			{Target: "a.example.com.", Port: 31338},
+		}, nil
+	}
+
+	self, nodes, epoch, err := SRVSource("_plutos._tcp.example.com").Discover(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "", self)
+	assert.Equal(t, uint64(0), epoch)
+	assert.Equal(t, []string{"a.example.com:31338", "b.example.com:31337"}, nodes)
+}
+
+func TestFileSource(t *testing.T) {
+	f, err := ioutil.TempFile("", "nodes")
+	if !assert.NoError(t, err) {
+		return
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	for _, data := range []string{
+		"# cluster nodes\n\na:1\n b:2 \n",
+		`["a:1", "b:2"]`,
+		`{"Nodes": ["a:1", "b:2"]}`,
+	} {
+		err = ioutil.WriteFile(f.Name(), []byte(data), 0644)
+		assert.NoError(t, err)
+
+		_, nodes, epoch, err := FileSource(f.Name()).Discover(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a:1", "b:2"}, nodes, "data: %q", data)
+		assert.Equal(t, uint64(0), epoch, "data: %q", data)
+	}
+
+	err = ioutil.WriteFile(f.Name(), []byte(`{"Nodes": ["a:1"], "Epoch": 7}`), 0644)
+	assert.NoError(t, err)
+	_, nodes, epoch, err := FileSource(f.Name()).Discover(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a:1"}, nodes)
+	assert.Equal(t, uint64(7), epoch)
+}
+
+type testSource struct {
+	nodes []string
+	epoch uint64
+	err   error
+}
+
+func (s *testSource) Discover(ctx context.Context) (string, []string, uint64, error) {
+	return "", s.nodes, s.epoch, s.err
+}
+
+func TestDiscoveryHysteresis(t *testing.T) {
+	r := NewStatic("a")
+	src := &testSource{nodes: []string{"a", "b"}}
+	d := NewDiscovery(r, src)
+	d.Stable = 2
+
+	ok, err := d.Check(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, ok, "first list is applied immediately")
+	assert.Len(t, r.Nodes(), 2)
+
+	src.nodes = []string{"a", "b", "c"}
+	ok, err = d.Check(context.TODO())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// flap back
+	src.nodes = []string{"a", "b"}
+	ok, err = d.Check(context.TODO())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	src.nodes = []string{"a", "b", "c"}
+	ok, err = d.Check(context.TODO())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = d.Check(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, r.Nodes(), 3)
+
+	src.err = errors.New("dns is down")
+	_, err = d.Check(context.TODO())
+	assert.EqualError(t, err, "discover: dns is down")
+
+	src.err = nil
+	src.nodes = nil
+	_, err = d.Check(context.TODO())
+	assert.EqualError(t, err, "no nodes discovered")
+	assert.Len(t, r.Nodes(), 3)
+}
+
+func TestDiscoveryEpochAndValidation(t *testing.T) {
+	r := NewStatic("a")
+	assert.True(t, r.SetEpochNodes(5, []string{"0=a"}))
+
+	src := &testSource{nodes: []string{"0=a", "100=b"}}
+	d := NewDiscovery(r, src)
+
+	ok, err := d.Check(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, TableEpoch([]string{"0=a", "100=b"}), r.Epoch())
+
+	// malformed line in nodes file is an error, not a panic
+	src.nodes = []string{"0=a", "1oo=b"}
+	assert.NotPanics(t, func() {
+		_, err = d.Check(context.TODO())
+	})
+	assert.EqualError(t, err, `discovered nodes: format error: point parsing error in "1oo=b"`)
+	assert.Equal(t, []string{"0=a", "100=b"}, r.Nodes())
+	assert.Equal(t, TableEpoch([]string{"0=a", "100=b"}), r.Epoch())
+
+	// versioned source sets its epoch
+	src.nodes, src.epoch = []string{"0=a", "100=b", "200=c"}, 8
+	d.Stable = 1
+	ok, err = d.Check(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(8), r.Epoch())
+}
+
+func TestDiscoverySameEpoch(t *testing.T) {
+	// nodes with different history discovering the same table agree on epoch
+	a, b := NewStatic("a"), NewStatic("b")
+	assert.True(t, a.SetEpochNodes(5, []string{"a"}))
+	assert.True(t, b.SetEpochNodes(2, []string{"b"}))
+
+	src := &testSource{nodes: []string{"b", "a"}}
+	for _, r := range []*StaticRouter{a, b} {
+		ok, err := NewDiscovery(r, src).Check(context.TODO())
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, a.Epoch(), b.Epoch())
+	assert.Equal(t, TableEpoch([]string{"a", "b"}), a.Epoch())
+
+	// applied again at restart
+	ok, err := NewDiscovery(a, src).Check(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, b.Epoch(), a.Epoch())
+}
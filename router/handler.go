@@ -3,6 +3,7 @@ package router
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"io/ioutil"
 	"log"
 	"net"
@@ -20,7 +21,9 @@ import (
 
 type UpdatableRouter interface {
 	Nodes() []string
-	SetNodes([]string)
+
+	Epoch() uint64
+	SetEpochNodes(uint64, []string) bool
 
 	Self() string
 	SetSelf(string)
@@ -29,6 +32,7 @@ type UpdatableRouter interface {
 type HTTPData struct {
 	Self  string
 	Nodes []string
+	Epoch uint64
 }
 
 func Handler(r UpdatableRouter) http.Handler {
@@ -38,6 +42,7 @@ func Handler(r UpdatableRouter) http.Handler {
 		d := HTTPData{
 			Self:  r.Self(),
 			Nodes: r.Nodes(),
+			Epoch: r.Epoch(),
 		}
 
 		render.JSON(w, req, d)
@@ -51,12 +56,20 @@ func Handler(r UpdatableRouter) http.Handler {
 			return
 		}
 
+		if err := ValidateNodes(d.Nodes); err != nil {
+			render.JSON(w, req, map[string]string{"error": err.Error()})
+			return
+		}
+		if !r.SetEpochNodes(d.Epoch, d.Nodes) {
+			render.JSON(w, req, map[string]string{"error": fmt.Sprintf("stale epoch %d, current is %d", d.Epoch, r.Epoch())})
+			return
+		}
 		r.SetSelf(d.Self)
-		r.SetNodes(d.Nodes)
 
 		d = HTTPData{
 			Self:  r.Self(),
 			Nodes: r.Nodes(),
+			Epoch: r.Epoch(),
 		}
 
 		render.JSON(w, req, d)
@@ -73,6 +86,7 @@ func Handler(r UpdatableRouter) http.Handler {
 		d := HTTPData{
 			Self:  r.Self(),
 			Nodes: r.Nodes(),
+			Epoch: r.Epoch(),
 		}
 
 		render.JSON(w, req, d)
@@ -82,31 +96,57 @@ func Handler(r UpdatableRouter) http.Handler {
 }
 
 func UpdateRouter(r UpdatableRouter, srv string) error {
-	_, p, err := net.SplitHostPort(srv)
+	me, nodes, epoch, err := SwarmSource(srv).Discover(context.TODO())
 	if err != nil {
-		return errors.Wrap(err, "split host port")
+		return err
 	}
 
-	me, addrs, err := CheckService(srv)
-	if err != nil {
-		return errors.Wrap(err, "check service")
+	if err = ValidateNodes(nodes); err != nil {
+		return err
 	}
 
-	sort.Strings(addrs)
+	if epoch == 0 {
+		epoch = TableEpoch(nodes)
+	}
 
-	if me != "" {
-		me = me + ":" + p
+	r.SetSelf(me)
+	if !r.SetEpochNodes(epoch, nodes) {
+		return errors.Errorf("discovered table rejected at epoch %d, current is %d", epoch, r.Epoch())
 	}
 
-	nodes := make([]string, len(addrs))
-	for i, addr := range addrs {
-		nodes[i] = fmt.Sprintf("%s:%s", addr, p)
+	return nil
+}
+
+// DerivedEpoch bit marks epochs derived from the table by TableEpoch
+const DerivedEpoch = 1 << 63
+
+// TableEpoch derives epoch from the sorted table, so all nodes finding the same table agree on it.
+// It's used for tables of sources without versions.
+func TableEpoch(nodes []string) uint64 {
+	sorted := append([]string{}, nodes...)
+	sort.Strings(sorted)
+
+	h := fnv.New64a()
+	for _, n := range sorted {
+		h.Write([]byte(n))
+		h.Write([]byte{0})
 	}
 
-	r.SetSelf(me)
-	r.SetNodes(nodes)
+	return h.Sum64() | DerivedEpoch
+}
 
-	return nil
+// acceptEpoch checks if table could be set at epoch instead of the current one.
+// Epochs set by operator never go back. Derived epochs identify tables rather than order them, so they replace any other epoch.
+// Different table at the same epoch is rejected unless there is no table yet.
+func acceptEpoch(cur, epoch uint64, same, empty bool) bool {
+	switch {
+	case epoch == cur:
+		return same || empty
+	case epoch&DerivedEpoch != 0 || cur&DerivedEpoch != 0:
+		return true
+	default:
+		return epoch > cur
+	}
 }
 
 func CheckService(srv string) (string, []string, error) {
@@ -1,13 +1,19 @@
 package router
 
 import (
+	"reflect"
+	"sync"
+
 	"github.com/serialx/hashring"
 )
 
 type Router struct {
+	mu sync.Mutex
+
 	ring  *hashring.HashRing
 	nodes []string
 	self  string
+	epoch uint64
 }
 
 func New(self string) *Router {
@@ -19,19 +25,57 @@ func New(self string) *Router {
 }
 
 func (r *Router) GetHostByKey(key string) string {
+	defer r.mu.Unlock()
+	r.mu.Lock()
+
 	node, _ := r.ring.GetNode(key)
 	return node
 }
 
 func (r *Router) IsSelf(node string) bool {
+	defer r.mu.Unlock()
+	r.mu.Lock()
+
 	return r.self == node
 }
 
 func (r *Router) Nodes() []string {
+	defer r.mu.Unlock()
+	r.mu.Lock()
+
 	return r.nodes
 }
 
 func (r *Router) SetNodes(nodes []string) {
+	defer r.mu.Unlock()
+	r.mu.Lock()
+
+	r.setNodes(nodes)
+}
+
+func (r *Router) setNodes(nodes []string) {
 	r.ring = hashring.New(nodes)
 	r.nodes = nodes
 }
+
+func (r *Router) Epoch() uint64 {
+	defer r.mu.Unlock()
+	r.mu.Lock()
+
+	return r.epoch
+}
+
+// SetEpochNodes sets nodes only if epoch is not older than current one, see acceptEpoch
+func (r *Router) SetEpochNodes(epoch uint64, nodes []string) bool {
+	defer r.mu.Unlock()
+	r.mu.Lock()
+
+	if !acceptEpoch(r.epoch, epoch, reflect.DeepEqual(nodes, r.nodes), len(r.nodes) == 0) {
+		return false
+	}
+
+	r.setNodes(nodes)
+	r.epoch = epoch
+
+	return true
+}
@@ -26,3 +26,14 @@ func TestSetNodes(t *testing.T) {
 	r.SetNodes([]string{"host1", "host2"})
 	assert.Equal(t, []string{"host1", "host2"}, r.Nodes())
 }
+
+func TestSimpleRouterEpoch(t *testing.T) {
+	r := New("me")
+	assert.True(t, r.SetEpochNodes(0, []string{"host1"}))
+	assert.True(t, r.SetEpochNodes(2, []string{"host1", "host2"}))
+	assert.False(t, r.SetEpochNodes(1, []string{"host3"}))
+	assert.True(t, r.SetEpochNodes(2, []string{"host1", "host2"}))
+	assert.False(t, r.SetEpochNodes(2, []string{"host3"}))
+	assert.Equal(t, []string{"host1", "host2"}, r.Nodes())
+	assert.Equal(t, uint64(2), r.Epoch())
+}
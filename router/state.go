@@ -0,0 +1,209 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/qiwitech/qdp/pt"
+)
+
+// StateRouter is a router which whole state could be saved and restored
+type StateRouter interface {
+	pt.Router
+
+	Self() string
+	SetSelf(string)
+}
+
+// Persistent saves router state to file after each change
+type Persistent struct {
+	StateRouter
+
+	mu   sync.Mutex
+	path string
+}
+
+func NewPersistent(r StateRouter, path string) *Persistent {
+	return &Persistent{
+		StateRouter: r,
+		path:        path,
+	}
+}
+
+func (p *Persistent) SetEpochNodes(epoch uint64, nodes []string) bool {
+	if !p.StateRouter.SetEpochNodes(epoch, nodes) {
+		return false
+	}
+	p.save()
+	return true
+}
+
+func (p *Persistent) SetSelf(s string) {
+	p.StateRouter.SetSelf(s)
+	p.save()
+}
+
+func (p *Persistent) save() {
+	defer p.mu.Unlock()
+	p.mu.Lock()
+
+	err := SaveState(p.path, State(p.StateRouter))
+	if err != nil {
+		log.Printf("router: save state: %v", err)
+	}
+}
+
+// State returns current router state
+func State(r UpdatableRouter) HTTPData {
+	return HTTPData{
+		Self:  r.Self(),
+		Nodes: r.Nodes(),
+		Epoch: r.Epoch(),
+	}
+}
+
+// SaveState atomically writes router state to file
+func SaveState(path string, d HTTPData) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encode")
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "create temp file")
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "write")
+	}
+
+	err = f.Close()
+	if err != nil {
+		return errors.Wrap(err, "close")
+	}
+
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return errors.Wrap(err, "rename")
+	}
+
+	return nil
+}
+
+// LoadState reads router state saved by SaveState. It returns nil if there is no file.
+func LoadState(path string) (*HTTPData, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "read")
+	}
+
+	var d HTTPData
+	err = json.Unmarshal(data, &d)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode")
+	}
+
+	return &d, nil
+}
+
+// RestoreState sets router state. State is ignored if it's older than current one.
+func RestoreState(r UpdatableRouter, d *HTTPData) bool {
+	if !r.SetEpochNodes(d.Epoch, d.Nodes) {
+		return false
+	}
+	r.SetSelf(d.Self)
+	return true
+}
+
+// ComparePeers checks that routing table (nodes and epoch) is the same as at the most of cluster nodes.
+// Unreachable nodes are skipped.
+func ComparePeers(ctx context.Context, d HTTPData) error {
+	our := stateKey(d)
+	votes := map[string]int{our: 1}
+	epochs := map[string]uint64{our: d.Epoch}
+
+	for _, n := range d.Nodes {
+		if i := strings.Index(n, "="); i != -1 {
+			n = n[i+1:]
+		}
+		if n == d.Self {
+			continue
+		}
+
+		p, err := getState(ctx, n)
+		if err != nil {
+			log.Printf("router: peer %v is unavailable: %v", n, err)
+			continue
+		}
+
+		k := stateKey(*p)
+		votes[k]++
+		epochs[k] = p.Epoch
+	}
+
+	for k, v := range votes {
+		if k != our && v > votes[our] {
+			return errors.Errorf("routing table differs from majority: %d nodes have epoch %d, %d nodes (including us) have epoch %d",
+				v, epochs[k], votes[our], d.Epoch)
+		}
+	}
+
+	return nil
+}
+
+func stateKey(d HTTPData) string {
+	nodes := append([]string{}, d.Nodes...)
+	sort.Strings(nodes)
+	b, _ := json.Marshal(struct {
+		Epoch uint64
+		Nodes []string
+	}{d.Epoch, nodes})
+	return string(b)
+}
+
+func getState(ctx context.Context, host string) (*HTTPData, error) {
+	u := url.URL{Scheme: "http", Host: host, Path: "/cfg/router"}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "new request")
+	}
+
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "get")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad response status: %v", resp.Status)
+	}
+
+	var d HTTPData
+	err = json.NewDecoder(resp.Body).Decode(&d)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode")
+	}
+
+	return &d, nil
+}
@@ -0,0 +1,78 @@
+package router
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentRouter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "router_state")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "router.json")
+
+	st, err := LoadState(path)
+	assert.NoError(t, err)
+	assert.Nil(t, st)
+
+	r := NewPersistent(NewStatic("a"), path)
+	assert.True(t, r.SetEpochNodes(3, []string{"0=a", "100=b"}))
+
+	st, err = LoadState(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &HTTPData{Self: "a", Nodes: []string{"0=a", "100=b"}, Epoch: 3}, st)
+
+	assert.False(t, r.SetEpochNodes(2, []string{"0=c"}))
+
+	r2 := NewStatic("")
+	assert.True(t, RestoreState(r2, st))
+	assert.Equal(t, "a", r2.Self())
+	assert.Equal(t, []string{"0=a", "100=b"}, r2.Nodes())
+	assert.Equal(t, uint64(3), r2.Epoch())
+
+	r2.SetEpochNodes(4, []string{"0=a"})
+	assert.False(t, RestoreState(r2, st))
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1, "temp files must be removed")
+}
+
+func TestComparePeers(t *testing.T) {
+	peers := make([]HTTPData, 2)
+	hosts := make([]string, 2)
+	for i := range peers {
+		i := i
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			render.JSON(w, req, peers[i])
+		}))
+		defer s.Close()
+		hosts[i] = strings.TrimPrefix(s.URL, "http://")
+	}
+
+	old := []string{"0=self", "10=" + hosts[0], "20=" + hosts[1], "30=unavailable:1"}
+	newer := []string{"0=self", "10=" + hosts[0], "20=" + hosts[1], "30=another:1"}
+
+	peers[0] = HTTPData{Self: hosts[0], Nodes: newer, Epoch: 2}
+	peers[1] = HTTPData{Self: hosts[1], Nodes: newer, Epoch: 2}
+
+	err := ComparePeers(context.TODO(), HTTPData{Self: "self", Nodes: old, Epoch: 1})
+	assert.EqualError(t, err, "routing table differs from majority: 2 nodes have epoch 2, 1 nodes (including us) have epoch 1")
+
+	peers[0] = HTTPData{Self: hosts[0], Nodes: old, Epoch: 1}
+
+	err = ComparePeers(context.TODO(), HTTPData{Self: "self", Nodes: old, Epoch: 1})
+	assert.NoError(t, err)
+}
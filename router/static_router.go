@@ -2,10 +2,13 @@ package router
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/pkg/errors"
 )
 
 // StaticRouter keeps routing table: which node is responsible for which account
@@ -15,6 +18,7 @@ type StaticRouter struct {
 	shards []uint64
 	nodes  []string
 	self   string
+	epoch  uint64
 }
 
 func NewStatic(self string) *StaticRouter {
@@ -65,12 +69,72 @@ func (r *StaticRouter) Nodes() []string {
 	return nodes
 }
 
+// SetNodes sets nodes keeping the epoch. It panics on malformed table.
 func (r *StaticRouter) SetNodes(shardNodes []string) {
 	defer r.Unlock()
 	r.Lock()
 
-	r.nodes = make([]string, 0, len(shardNodes))
-	r.shards = make([]uint64, 0, len(shardNodes))
+	if err := r.setNodes(shardNodes); err != nil {
+		panic(err)
+	}
+}
+
+// Epoch returns routing table version
+func (r *StaticRouter) Epoch() uint64 {
+	defer r.Unlock()
+	r.Lock()
+
+	return r.epoch
+}
+
+// SetEpochNodes sets nodes only if epoch is not older than current one, see acceptEpoch
+func (r *StaticRouter) SetEpochNodes(epoch uint64, shardNodes []string) bool {
+	defer r.Unlock()
+	r.Lock()
+
+	shards, nodes, err := parseNodes(shardNodes)
+	if err != nil {
+		return false
+	}
+
+	same := reflect.DeepEqual(shards, r.shards) && reflect.DeepEqual(nodes, r.nodes)
+	if !acceptEpoch(r.epoch, epoch, same, len(r.nodes) == 0) {
+		return false
+	}
+
+	r.shards = shards
+	r.nodes = nodes
+	r.epoch = epoch
+
+	return true
+}
+
+// setNodes replaces table, it's left untouched if shardNodes are malformed
+func (r *StaticRouter) setNodes(shardNodes []string) error {
+	shards, nodes, err := parseNodes(shardNodes)
+	if err != nil {
+		return err
+	}
+
+	r.shards = shards
+	r.nodes = nodes
+
+	return nil
+}
+
+// ValidateNodes checks that routing table entries are '{host}' or '{id=host}'
+func ValidateNodes(shardNodes []string) error {
+	_, _, err := parseNodes(shardNodes)
+	return err
+}
+
+func parseNodes(shardNodes []string) (shards []uint64, nodes []string, err error) {
+	nodes = make([]string, 0, len(shardNodes))
+	shards = make([]uint64, 0, len(shardNodes))
+
+	if len(shardNodes) == 0 {
+		return shards, nodes, nil
+	}
 
 	equalPart := (1 << 63) / uint64(len(shardNodes))
 	equalPart <<= 1
@@ -85,20 +149,21 @@ func (r *StaticRouter) SetNodes(shardNodes []string) {
 		case 2:
 			shard, err := strconv.ParseUint(s[0], 10, 64)
 			if err != nil {
-				// don't omit errors
-				panic("format error: point parsing error")
+				return nil, nil, errors.Errorf("format error: point parsing error in %q", n)
 			}
 
-			r.shards = append(r.shards, shard)
-			r.nodes = append(r.nodes, s[1])
+			shards = append(shards, shard)
+			nodes = append(nodes, s[1])
 		case 1:
 			shard := uint64(i) * equalPart
-			r.shards = append(r.shards, shard)
-			r.nodes = append(r.nodes, n)
+			shards = append(shards, shard)
+			nodes = append(nodes, n)
 		default:
-			panic("format error: must be '{host}' or '{id=host}'")
+			return nil, nil, errors.Errorf("format error: must be '{host}' or '{id=host}', got %q", n)
 		}
 	}
+
+	return shards, nodes, nil
 }
 
 func (r *StaticRouter) Self() string {
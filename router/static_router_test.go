@@ -99,3 +99,45 @@ func TestStaticRouterNotEqualRanges(t *testing.T) {
 	assert.Equal(t, "d", r.GetHostByKey(fmt.Sprintf("%d", ^uint64(0)-1)))
 	assert.Equal(t, "d", r.GetHostByKey(fmt.Sprintf("%d", ^uint64(0))))
 }
+
+func TestStaticRouterEpoch(t *testing.T) {
+	r := NewStatic("a")
+
+	assert.True(t, r.SetEpochNodes(2, []string{"a", "b"}))
+	assert.Equal(t, uint64(2), r.Epoch())
+
+	assert.False(t, r.SetEpochNodes(1, []string{"c"}))
+	assert.Equal(t, uint64(2), r.Epoch())
+	assert.Equal(t, "a", r.GetHostByKey("0"))
+
+	assert.True(t, r.SetEpochNodes(3, []string{"c"}))
+	assert.Equal(t, uint64(3), r.Epoch())
+	assert.Equal(t, "c", r.GetHostByKey("0"))
+
+	r.SetNodes([]string{"d"})
+	assert.Equal(t, uint64(3), r.Epoch())
+
+	// malformed table is rejected
+	assert.False(t, r.SetEpochNodes(4, []string{"0=a", "x=b"}))
+	assert.Equal(t, uint64(3), r.Epoch())
+	assert.Equal(t, []string{"0=d"}, r.Nodes())
+
+	// the same table at the same epoch is accepted, different one isn't
+	assert.True(t, r.SetEpochNodes(3, []string{"0=d"}))
+	assert.False(t, r.SetEpochNodes(3, []string{"e"}))
+	assert.Equal(t, "d", r.GetHostByKey("0"))
+
+	// derived epochs replace any other
+	assert.True(t, r.SetEpochNodes(TableEpoch([]string{"e"}), []string{"e"}))
+	assert.Equal(t, "e", r.GetHostByKey("0"))
+	assert.True(t, r.SetEpochNodes(4, []string{"f"}))
+	assert.Equal(t, "f", r.GetHostByKey("0"))
+}
+
+func TestValidateNodes(t *testing.T) {
+	assert.NoError(t, ValidateNodes([]string{"0=a", "", "100=b"}))
+	assert.NoError(t, ValidateNodes([]string{"a", "b"}))
+	assert.NoError(t, ValidateNodes(nil))
+	assert.Error(t, ValidateNodes([]string{"0=a", "aa=b"}))
+	assert.Error(t, ValidateNodes([]string{"0=a=b"}))
+}
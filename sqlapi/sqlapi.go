@@ -206,7 +206,7 @@ func (s *Server) select_(c *mysql.Conn, st *sqlparser.Select, cb callback) error
 		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "processing error: %v", resp.Status.Message)
 	}
 
-	fs := sqltypes.MakeTestFields("sender|  id|receiver|amount|balance|hash|prev_hash|spent_by", "text|text|text|text|text|text|text|text|text")
+	fs := sqltypes.MakeTestFields("sender|  id|receiver|amount|balance|hash|prev_hash|spent_by|processed_at", "text|text|text|text|text|text|text|text|text")
 	err = cb(&sqltypes.Result{Fields: fs})
 	if err != nil {
 		return err
@@ -228,6 +228,7 @@ func (s *Server) select_(c *mysql.Conn, st *sqlparser.Select, cb callback) error
 			sqltypes.NewVarChar(t.Hash),
 			sqltypes.NewVarChar(t.PrevHash),
 			sqltypes.NewVarChar(t.SpentBy),
+			sqltypes.NewVarChar(t.ProcessedAt),
 		})
 	}
 
@@ -379,37 +380,102 @@ func (s *Server) checkSelect(st *sqlparser.Select) error {
 }
 
 func (s *Server) parseWhere(req *apipb.GetHistoryRequest, w *sqlparser.Where) error {
-	if w.Type != "where" {
+	if w == nil || w.Type != "where" {
 		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "only WHERE expression is supported")
 	}
 
-	//	log.Printf("where: %T %+v", w.Expr, w.Expr)
-	switch e := w.Expr.(type) {
-	case *sqlparser.ComparisonExpr:
-		if e.Operator != "=" {
-			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the only supported WHERE form is account = <acc>")
+	err := s.parseCond(req, w.Expr)
+	if err != nil {
+		return err
+	}
+
+	if req.Account == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "account = <acc> condition is required")
+	}
+
+	return nil
+}
+
+// parseCond fills history filters from conditions joined with AND.
+// Supported: account = <acc>, counterparty = <acc>, direction = 'in'|'out',
+// processed_at >= | < '<RFC3339 time>', amount = | >= | <= <amount>
+func (s *Server) parseCond(req *apipb.GetHistoryRequest, e sqlparser.Expr) error {
+	//	log.Printf("where: %T %+v", e, e)
+	switch e := e.(type) {
+	case *sqlparser.AndExpr:
+		if err := s.parseCond(req, e.Left); err != nil {
+			return err
 		}
+		return s.parseCond(req, e.Right)
+	case *sqlparser.ParenExpr:
+		return s.parseCond(req, e.Expr)
+	case *sqlparser.ComparisonExpr:
 		name, err := s.parseName(e.Left)
 		if err != nil {
 			return err
 		}
-		if name != "account" {
-			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the only supported WHERE form is account = <acc>")
-		}
 
-		acc, err := s.parseAccID(e.Right)
-		if err != nil {
-			return err
+		switch {
+		case name == "account" && e.Operator == "=":
+			req.Account, err = s.parseAccID(e.Right)
+		case name == "counterparty" && e.Operator == "=":
+			req.Counterparty, err = s.parseAccID(e.Right)
+		case name == "direction" && e.Operator == "=":
+			err = s.fillDirection(req, e.Right)
+		case name == "processed_at" && e.Operator == ">=":
+			req.FromTime, err = s.parseTime(e.Right)
+		case name == "processed_at" && e.Operator == "<":
+			req.ToTime, err = s.parseTime(e.Right)
+		case name == "amount" && (e.Operator == "=" || e.Operator == ">=" || e.Operator == "<="):
+			var a int64
+			a, err = s.parseAmount(e.Right)
+			if e.Operator != "<=" {
+				req.MinAmount = a
+			}
+			if e.Operator != ">=" {
+				req.MaxAmount = a
+			}
+		default:
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported WHERE condition: %s %s", name, e.Operator)
 		}
 
-		req.Account = acc
+		return err
 	default:
 		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported WHERE expression: %T %v", e, e)
 	}
+}
+
+func (s *Server) fillDirection(req *apipb.GetHistoryRequest, f sqlparser.Expr) error {
+	v, ok := f.(*sqlparser.SQLVal)
+	if !ok {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported direction expression: %T %v", f, f)
+	}
+
+	switch string(v.Val) {
+	case "in":
+		req.Direction = apipb.HistoryDirection_INCOMING
+	case "out":
+		req.Direction = apipb.HistoryDirection_OUTGOING
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "direction must be 'in' or 'out', got %q", v.Val)
+	}
 
 	return nil
 }
 
+func (s *Server) parseTime(f sqlparser.Expr) (string, error) {
+	switch f := f.(type) {
+	case *sqlparser.SQLVal:
+		if _, err := time.Parse(time.RFC3339Nano, string(f.Val)); err != nil {
+			return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "parse time expression %q: %v", f.Val, err)
+		}
+
+		return string(f.Val), nil
+	default:
+		return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported time expression: %T %v", f, f)
+	}
+}
+
 func (s *Server) parseLimit(req *apipb.GetHistoryRequest, l *sqlparser.Limit) error {
 	if l == nil {
 		req.Limit = 20
@@ -1,77 +1,110 @@
 package sqlchain
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"strings"
 
+	"github.com/pkg/errors"
+
+	"github.com/qiwitech/qdp/pagetoken"
 	"github.com/qiwitech/qdp/proto/chainpb"
 	"github.com/qiwitech/qdp/proto/plutodbpb"
 	"github.com/qiwitech/qdp/pt"
 )
 
 type DB struct {
-	c *sql.DB
+	c  *sql.DB
+	dl Dialect
 }
 
+// New migrates schema to the latest version and returns MySQL backed DB
 func New(c *sql.DB) (*DB, error) {
-	_, err := c.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS txns (
-		id          BIGINT UNSIGNED,
-		sender      BIGINT UNSIGNED,
-		receiver    BIGINT UNSIGNED,
-		amount      BIGINT,
-		balance     BIGINT,
-		settings_id BIGINT UNSIGNED,
-		spent_by    BIGINT UNSIGNED,
-		prev_hash   VARCHAR(64),
-		hash        VARCHAR(64),
-		sign        VARCHAR(250),
-		UNIQUE KEY (sender, id)
-	)`))
-	if err != nil {
+	return NewDialect(c, MySQL)
+}
+
+// NewDialect migrates schema to the latest version and returns DB using the given SQL dialect
+func NewDialect(c *sql.DB, dl Dialect) (*DB, error) {
+	if _, _, err := Migrate(context.Background(), c, dl); err != nil {
 		return nil, err
 	}
-	_, err = c.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS sett (
-		id          BIGINT UNSIGNED,
-		account     BIGINT UNSIGNED,
-		verify_transfer_sign BOOL,
-		prev_hash   VARCHAR(64),
-		data_hash   VARCHAR(64),
-		hash        VARCHAR(64),
-		sign        VARCHAR(250),
-		public_key  VARCHAR(250),
-		UNIQUE KEY (account, id)
-	)`))
+	return &DB{c: c, dl: dl}, nil
+}
+
+// NewReadOnly returns DB for read replica. Schema is not migrated as it's replicated from primary,
+// it's only checked to be not newer than this binary supports.
+func NewReadOnly(c *sql.DB, dl Dialect) (*DB, error) {
+	v, err := schemaVersion(context.Background(), c)
 	if err != nil {
 		return nil, err
 	}
-	return &DB{c: c}, nil
+	if v > SchemaVersion() {
+		return nil, errors.Wrapf(ErrSchemaTooNew, "version %d, supported %d", v, SchemaVersion())
+	}
+	return &DB{c: c, dl: dl}, nil
+}
+
+func (d *DB) query(q string, args ...interface{}) (*sql.Rows, error) {
+	return d.c.Query(d.dl.Rebind(q), d.dl.Args(args)...)
+}
+
+func (d *DB) queryRow(q string, args ...interface{}) *sql.Row {
+	return d.c.QueryRow(d.dl.Rebind(q), d.dl.Args(args)...)
 }
 
+func (d *DB) exec(q string, args ...interface{}) (sql.Result, error) {
+	return d.c.Exec(d.dl.Rebind(q), d.dl.Args(args)...)
+}
+
+// maxTxRetries is a number of attempts to run a transaction aborted by deadlock
+const maxTxRetries = 3
+
+// Push stores new txns and updates spent_by of already stored ones in a single transaction.
+// New txns and txns with changed spent_by get change sequence numbers, the others keep theirs.
+// Unchanged txns are told by zero affected rows, so they don't take numbers
+// (mysql DSN must not set clientFoundRows).
 func (d *DB) Push(ctx context.Context, txns []pt.Txn) (err error) {
 	if len(txns) == 0 {
 		return nil
 	}
-	var b strings.Builder
-	b.WriteString(`INSERT INTO txns (id, sender, receiver, amount, balance, settings_id, spent_by, prev_hash, sign, hash) VALUES `)
-	for i, txn := range txns {
-		if i != 0 {
-			b.WriteString(", ")
-		}
-		if txn.Hash == pt.ZeroHash {
-			txn.Hash = pt.GetHashDefault(&txn)
+
+	return d.inTx(ctx, func(tx *sql.Tx) error {
+		seq, err := lockSeq(ctx, tx)
+		if err != nil {
+			return err
 		}
-		b.WriteString(fmt.Sprintf("(%d, %d, %d, %d, %d, %d, %d, %q, %q, %q)", txn.ID, txn.Sender, txn.Receiver, txn.Amount, txn.Balance, txn.SettingsID, txn.SpentBy,
-			hex.EncodeToString(txn.PrevHash[:]), hex.EncodeToString(txn.Sign[:]), hex.EncodeToString(txn.Hash[:])))
-	}
 
-	b.WriteString(` ON DUPLICATE KEY UPDATE spent_by = VALUES(spent_by)`)
+		st, err := tx.PrepareContext(ctx, d.dl.Rebind(`INSERT INTO txns (id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign, hash, seq)
+						VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`+d.dl.UpsertTxns()))
+		if err != nil {
+			return errors.Wrap(err, "prepare")
+		}
+		defer st.Close()
 
-	_, err = d.c.Exec(b.String())
+		for _, txn := range txns {
+			if txn.Hash == pt.ZeroHash {
+				txn.Hash = pt.GetHashDefault(&txn)
+			}
+			res, err := st.ExecContext(ctx, d.dl.Args([]interface{}{uint64(txn.ID), uint64(txn.Sender), uint64(txn.Receiver), txn.Amount, txn.Balance, uint64(txn.SettingsID), uint64(txn.SpentBy), txn.ProcessedAt,
+				hex.EncodeToString(txn.PrevHash[:]), hex.EncodeToString(txn.Sign[:]), hex.EncodeToString(txn.Hash[:]), seq + 1})...)
+			if err != nil {
+				return errors.Wrapf(err, "insert txn %v", pt.NewTxnID(txn.Sender, txn.ID))
+			}
+			n, err := res.RowsAffected()
+			if err != nil {
+				return errors.Wrap(err, "rows affected")
+			}
+			if n != 0 {
+				seq++
+			}
+		}
 
-	return err
+		return d.setSeq(ctx, tx, seq)
+	})
 }
 
 func (d *DB) PushSettings(ctx context.Context, sett *pt.Settings) (err error) {
@@ -81,24 +114,79 @@ func (d *DB) PushSettings(ctx context.Context, sett *pt.Settings) (err error) {
 	if sett.Hash == pt.ZeroHash {
 		sett.Hash = pt.GetSettingsHashDefault(sett)
 	}
-	_, err = d.c.Exec(fmt.Sprintf(`INSERT INTO sett (id, account, verify_transfer_sign, prev_hash, data_hash, sign, public_key, hash)
-						VALUES (%d, %d, %v, %q, %q, %q, %q, %q)`, sett.ID, sett.Account, sett.VerifyTransferSign,
-		hex.EncodeToString(sett.PrevHash[:]),
-		hex.EncodeToString(sett.DataHash[:]),
-		hex.EncodeToString(sett.Sign[:]),
-		hex.EncodeToString(sett.PublicKey[:]),
-		hex.EncodeToString(sett.Hash[:]),
-	))
+
+	return d.inTx(ctx, func(tx *sql.Tx) error {
+		seq, err := lockSeq(ctx, tx)
+		if err != nil {
+			return err
+		}
+		seq++
+
+		_, err = tx.ExecContext(ctx, d.dl.Rebind(`INSERT INTO sett (id, account, verify_transfer_sign, server_sequencing, registered, closed, sweep_to, processed_at, prev_hash, data_hash, sign, public_key, hash, seq)
+						VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`), d.dl.Args([]interface{}{
+			uint64(sett.ID), uint64(sett.Account), sett.VerifyTransferSign, sett.ServerSequencing, sett.Registered, sett.Closed, uint64(sett.SweepTo), sett.ProcessedAt,
+			hex.EncodeToString(sett.PrevHash[:]),
+			hex.EncodeToString(sett.DataHash[:]),
+			hex.EncodeToString(sett.Sign[:]),
+			hex.EncodeToString(sett.PublicKey[:]),
+			hex.EncodeToString(sett.Hash[:]),
+			seq,
+		})...)
+		if err != nil {
+			return err
+		}
+
+		return d.setSeq(ctx, tx, seq)
+	})
+}
+
+// lockSeq returns the last change sequence number and locks it until transaction ends.
+// Writers are serialized by the lock, so sequence numbers are assigned in commit order.
+// That bounds write throughput to a single writer at a time: Push and PushSettings
+// of different accounts wait for each other, which is fine for one plutodb per database.
+// It's taken first in a transaction, so writers can't deadlock on it.
+func lockSeq(ctx context.Context, tx *sql.Tx) (seq uint64, err error) {
+	err = tx.QueryRowContext(ctx, `SELECT seq FROM change_seq FOR UPDATE`).Scan(&seq)
+	return seq, errors.Wrap(err, "lock change_seq")
+}
+
+func (d *DB) setSeq(ctx context.Context, tx *sql.Tx, seq uint64) error {
+	_, err := tx.ExecContext(ctx, d.dl.Rebind(`UPDATE change_seq SET seq = ?`), d.dl.Args([]interface{}{seq})...)
+	return errors.Wrap(err, "update change_seq")
+}
+
+// inTx runs f in a transaction. It's retried from scratch if database aborted it due to deadlock.
+func (d *DB) inTx(ctx context.Context, f func(tx *sql.Tx) error) (err error) {
+	for i := 0; i < maxTxRetries; i++ {
+		err = tryTx(ctx, d.c, f)
+		if err == nil || !d.dl.Deadlock(errors.Cause(err)) {
+			return err
+		}
+	}
 	return err
 }
 
+func tryTx(ctx context.Context, c *sql.DB, f func(tx *sql.Tx) error) error {
+	tx, err := c.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "begin")
+	}
+
+	if err = f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (d *DB) Fetch(ctx context.Context, req *plutodbpb.FetchRequest) (resp *plutodbpb.FetchResponse, err error) {
 	var txns []*chainpb.Txn
 	add := func(rows *sql.Rows) error {
 		for rows.Next() {
 			var txn chainpb.Txn
 			var ph, sign string
-			err := rows.Scan(&txn.ID, &txn.Sender, &txn.Receiver, &txn.Amount, &txn.Balance, &txn.SettingsId, &txn.SpentBy, &ph, &sign)
+			err := rows.Scan(&txn.ID, &txn.Sender, &txn.Receiver, &txn.Amount, &txn.Balance, &txn.SettingsId, &txn.SpentBy, &txn.ProcessedAt, &ph, &sign)
 			if err != nil {
 				return err
 			}
@@ -113,8 +201,7 @@ func (d *DB) Fetch(ctx context.Context, req *plutodbpb.FetchRequest) (resp *plut
 		return rows.Close()
 	}
 
-	q := fmt.Sprintf(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, prev_hash, sign FROM txns WHERE sender = %d ORDER BY id DESC LIMIT %d`, req.Account, req.Limit)
-	rows, err := d.c.Query(q)
+	rows, err := d.query(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign FROM txns WHERE sender = ? ORDER BY id DESC LIMIT ?`, req.Account, req.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -122,8 +209,7 @@ func (d *DB) Fetch(ctx context.Context, req *plutodbpb.FetchRequest) (resp *plut
 		return nil, err
 	}
 
-	q = fmt.Sprintf(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, prev_hash, sign FROM txns WHERE receiver = %d AND id = 0`, req.Account)
-	rows, err = d.c.Query(q)
+	rows, err = d.query(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign FROM txns WHERE receiver = ? AND spent_by = 0`, req.Account)
 	if err != nil {
 		return nil, err
 	}
@@ -132,14 +218,14 @@ func (d *DB) Fetch(ctx context.Context, req *plutodbpb.FetchRequest) (resp *plut
 	}
 
 	var sett *chainpb.Settings
-	rows, err = d.c.Query(`SELECT id, account, verify_transfer_sign, prev_hash, data_hash, sign, public_key FROM sett WHERE account = ? ORDER BY id DESC LIMIT 1`, req.Account)
+	rows, err = d.query(`SELECT id, account, verify_transfer_sign, server_sequencing, registered, closed, sweep_to, processed_at, prev_hash, data_hash, sign, public_key FROM sett WHERE account = ? ORDER BY id DESC LIMIT 1`, req.Account)
 	if err != nil {
 		return nil, err
 	}
 	for rows.Next() {
 		sett = new(chainpb.Settings)
 		var ph, dh, sign, key string
-		err = rows.Scan(&sett.ID, &sett.Account, &sett.VerifyTransferSign, &ph, &dh, &sign, &key)
+		err = rows.Scan(&sett.ID, &sett.Account, &sett.VerifyTransferSign, &sett.ServerSequencing, &sett.Registered, &sett.Closed, &sett.SweepTo, &sett.ProcessedAt, &ph, &dh, &sign, &key)
 		if err != nil {
 			return nil, err
 		}
@@ -160,90 +246,701 @@ func (d *DB) Fetch(ctx context.Context, req *plutodbpb.FetchRequest) (resp *plut
 	return &plutodbpb.FetchResponse{Status: &plutodbpb.Status{}, Txns: txns, Settings: sett}, nil
 }
 
-func (d *DB) GetHistory(ctx context.Context, req *plutodbpb.GetHistoryRequest) (resp *plutodbpb.GetHistoryResponse, _ error) {
-	resp = &plutodbpb.GetHistoryResponse{
-		Status: &plutodbpb.Status{Code: 0},
+// GetHistory returns account txns page. Response token is set if there could be more txns,
+// pages continued by token don't include txns processed after the first page.
+func (d *DB) GetHistory(ctx context.Context, req *plutodbpb.GetHistoryRequest) (*plutodbpb.GetHistoryResponse, error) {
+	tok := &pagetoken.Token{Account: req.Account, Filtered: hasHistoryFilters(req)}
+	if req.Token != "" {
+		t, err := pagetoken.Parse(req.Token)
+		if err == nil && (t.Account != req.Account || t.Filtered != tok.Filtered || t.Settings) {
+			err = pagetoken.ErrInvalid
+		}
+		if err != nil {
+			return &plutodbpb.GetHistoryResponse{Status: &plutodbpb.Status{Code: plutodbpb.DBStatusCode_INVALID_TOKEN, Message: err.Error()}}, nil
+		}
+		tok = t
 	}
+
 	var txns []*chainpb.Txn
+	var more bool
+	var err error
+	if tok.Filtered {
+		txns, more, err = d.getHistoryFiltered(ctx, req, tok)
+	} else {
+		txns, more, err = d.walkHistory(ctx, req, tok)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	out := false
-	id := uint64(0)
-loop:
-	for {
-		var err error
-		var rows *sql.Rows
-		if out {
-			if id == 0 {
-				id--
-			}
-			rows, err = d.c.Query(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, prev_hash, sign FROM txns WHERE sender = ? AND id < ? ORDER BY id DESC LIMIT 1`, req.Account, id)
-		} else {
-			rows, err = d.c.Query(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, prev_hash, sign FROM txns WHERE receiver = ? AND spent_by = ?`, req.Account, id)
-		}
+	resp := &plutodbpb.GetHistoryResponse{
+		Status: &plutodbpb.Status{},
+		Txns:   txns,
+	}
+	if more {
+		resp.Token = tok.String()
+	}
+
+	return resp, nil
+}
+
+// walkHistory walks account chain from the token position.
+// Each outgoing txn is followed by incoming txns spent by it, unspent incoming txns are the first.
+func (d *DB) walkHistory(ctx context.Context, req *plutodbpb.GetHistoryRequest, tok *pagetoken.Token) ([]*chainpb.Txn, bool, error) {
+	if req.Token == "" {
+		err := d.queryRow(`SELECT COALESCE(MAX(id), 0) FROM txns WHERE sender = ?`, req.Account).Scan(&tok.Head)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		var added bool
-		for rows.Next() {
-			var txn chainpb.Txn
-			var ph, sign string
-			err = rows.Scan(&txn.ID, &txn.Sender, &txn.Receiver, &txn.Amount, &txn.Balance, &txn.SettingsId, &txn.SpentBy, &ph, &sign)
-			if err != nil {
-				return nil, err
-			}
-			if err = decodeHex(&txn.PrevHash, ph); err != nil {
-				return nil, err
+	}
+
+	limit := int(req.Limit)
+	var txns []*chainpb.Txn
+	for limit == 0 || len(txns) < limit {
+		if tok.Out {
+			bound := tok.ID
+			if bound == 0 {
+				bound = tok.Head + 1
 			}
-			if err = decodeHex(&txn.Sign, sign); err != nil {
-				return nil, err
+			rows, err := d.query(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign FROM txns WHERE sender = ? AND id < ? ORDER BY id DESC LIMIT 1`, req.Account, bound)
+			if err != nil {
+				return nil, false, err
 			}
-			txns = append(txns, &txn)
-			if len(txns) == int(req.Limit) {
-				rows.Close()
-				break loop
+			out, err := scanTxns(rows)
+			if err != nil {
+				return nil, false, err
 			}
-			added = true
-			if out {
-				id = txn.ID
+			if len(out) == 0 {
+				return txns, false, nil
 			}
+
+			txns = append(txns, out[0])
+			tok.Out = false
+			tok.ID = out[0].ID
+			tok.HasKey = false
+			continue
+		}
+
+		// incoming txns spent by tok.ID; unspent at the first page could be spent by newer txns
+		q := `SELECT id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign FROM txns WHERE receiver = ? AND spent_by = ?`
+		args := []interface{}{req.Account, tok.ID}
+		if tok.ID == 0 {
+			q = `SELECT id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign FROM txns WHERE receiver = ? AND (spent_by = 0 OR spent_by > ?)`
+			args = []interface{}{req.Account, tok.Head}
+		}
+		if tok.HasKey {
+			q += ` AND (sender > ? OR sender = ? AND id > ?)`
+			args = append(args, tok.Sender, tok.Sender, tok.TxnID)
+		}
+		q += ` ORDER BY sender, id`
+		if limit != 0 {
+			q += fmt.Sprintf(" LIMIT %d", limit-len(txns))
 		}
-		if out && !added {
-			break loop
+
+		rows, err := d.query(q, args...)
+		if err != nil {
+			return nil, false, err
+		}
+		in, err := scanTxns(rows)
+		if err != nil {
+			return nil, false, err
+		}
+
+		txns = append(txns, in...)
+		if len(in) != 0 {
+			last := in[len(in)-1]
+			tok.HasKey = true
+			tok.Sender = last.Sender
+			tok.TxnID = last.ID
+		}
+		if limit != 0 && len(txns) == limit {
+			break
+		}
+
+		tok.Out = true
+		tok.HasKey = false
+	}
+
+	return txns, true, nil
+}
+
+// getHistoryFiltered returns account txns matching request filters ordered by processing time, latest first
+func (d *DB) getHistoryFiltered(ctx context.Context, req *plutodbpb.GetHistoryRequest, tok *pagetoken.Token) ([]*chainpb.Txn, bool, error) {
+	where, wargs := historyWhere(req)
+
+	var extra string
+	var extraArgs []interface{}
+	if tok.HasKey {
+		extra = `(processed_at < ? OR processed_at = ? AND (sender > ? OR sender = ? AND id < ?))`
+		extraArgs = []interface{}{tok.ProcessedAt, tok.ProcessedAt, tok.Sender, tok.Sender, tok.TxnID}
+	}
+
+	order := `ORDER BY processed_at DESC, sender, id DESC`
+	if req.Limit != 0 {
+		order += fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	q, args := historyUnion(`id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign`, where, wargs, extra, extraArgs, order)
+	q += " " + order
+
+	rows, err := d.query(q, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	txns, err := scanTxns(rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if req.Limit == 0 || len(txns) < int(req.Limit) {
+		return txns, false, nil
+	}
+
+	last := txns[len(txns)-1]
+	tok.HasKey = true
+	tok.ProcessedAt = last.ProcessedAt
+	tok.Sender = last.Sender
+	tok.TxnID = last.ID
+
+	return txns, true, nil
+}
+
+// GetSettingsHistory returns account settings page, latest first.
+// Entry is marked as KeyChanged if its public key differs from the previous settings one.
+func (d *DB) GetSettingsHistory(ctx context.Context, req *plutodbpb.GetSettingsHistoryRequest) (*plutodbpb.GetSettingsHistoryResponse, error) {
+	q := `SELECT id, account, verify_transfer_sign, server_sequencing, registered, closed, sweep_to, processed_at, prev_hash, data_hash, sign, public_key, hash FROM sett WHERE account = ?`
+	args := []interface{}{req.Account}
+	if req.Token != "" {
+		tok, err := pagetoken.Parse(req.Token)
+		if err == nil && (tok.Account != req.Account || !tok.Settings) {
+			err = pagetoken.ErrInvalid
+		}
+		if err != nil {
+			return &plutodbpb.GetSettingsHistoryResponse{Status: &plutodbpb.Status{Code: plutodbpb.DBStatusCode_INVALID_TOKEN, Message: err.Error()}}, nil
+		}
+		q += ` AND id < ?`
+		args = append(args, tok.ID)
+	}
+	q += ` ORDER BY id DESC`
+	if req.Limit != 0 {
+		// one more to compare keys of the last entry and to know if there are more
+		q += fmt.Sprintf(" LIMIT %d", req.Limit+1)
+	}
+
+	rows, err := d.query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	setts, err := scanSettings(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &plutodbpb.GetSettingsHistoryResponse{Status: &plutodbpb.Status{}}
+	for i, s := range setts {
+		if req.Limit != 0 && i == int(req.Limit) {
+			resp.Token = (&pagetoken.Token{Account: req.Account, Settings: true, ID: setts[i-1].ID}).String()
+			break
 		}
-		out = !out
+		var prev []byte
+		if i+1 < len(setts) {
+			prev = setts[i+1].PublicKey
+		}
+		resp.Settings = append(resp.Settings, &plutodbpb.SettingsHistoryEntry{Settings: s, KeyChanged: !bytes.Equal(s.PublicKey, prev)})
 	}
-	resp.Txns = txns
+
 	return resp, nil
 }
 
-func (d *DB) GetTxnMulti(ctx context.Context, req *plutodbpb.GetTxnMultiRequest) (*plutodbpb.GetTxnMultiResponse, error) {
-	resp := &plutodbpb.GetTxnMultiResponse{
-		Status: &plutodbpb.Status{},
+// PutData stores settings user data addressed by its hash
+func (d *DB) PutData(ctx context.Context, req *plutodbpb.PutDataRequest) (*plutodbpb.PutDataResponse, error) {
+	h := pt.GetDataHash(req.Data)
+
+	_, err := d.exec(d.dl.InsertData(), hex.EncodeToString(h[:]), req.Data)
+	if err != nil {
+		return nil, err
 	}
 
-	txns := make([]*chainpb.Txn, len(req.IDs))
-	for i, id := range req.IDs {
-		row := d.c.QueryRow(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, prev_hash, sign FROM txns WHERE sender = ? AND id < ? ORDER BY id DESC LIMIT 1`, id.Account, id.ID)
-		var txn chainpb.Txn
-		var ph, sign string
-		err := row.Scan(&txn.ID, &txn.Sender, &txn.Receiver, &txn.Amount, &txn.Balance, &txn.SettingsId, &txn.SpentBy, &ph, &sign)
+	return &plutodbpb.PutDataResponse{Status: &plutodbpb.Status{}, Hash: h[:]}, nil
+}
+
+// GetData returns settings user data by its hash
+func (d *DB) GetData(ctx context.Context, req *plutodbpb.GetDataRequest) (*plutodbpb.GetDataResponse, error) {
+	resp := &plutodbpb.GetDataResponse{Status: &plutodbpb.Status{}}
+
+	err := d.queryRow(`SELECT data FROM sett_data WHERE hash = ?`, hex.EncodeToString(req.Hash)).Scan(&resp.Data)
+	if err == sql.ErrNoRows {
+		resp.Status.Code = plutodbpb.DBStatusCode_NOT_FOUND
+		resp.Status.Message = "data not found"
+		return resp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// scanSettings reads and closes settings rows
+func scanSettings(rows *sql.Rows) ([]*chainpb.Settings, error) {
+	defer rows.Close()
+
+	var setts []*chainpb.Settings
+	for rows.Next() {
+		sett, err := scanSettingsRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		setts = append(setts, sett)
+	}
+
+	return setts, rows.Err()
+}
+
+// scanSettingsRow reads settings columns, the following ones are scanned into extra
+func scanSettingsRow(rows *sql.Rows, extra ...interface{}) (*chainpb.Settings, error) {
+	var sett chainpb.Settings
+	var ph, dh, sign, key, hash string
+	dest := []interface{}{&sett.ID, &sett.Account, &sett.VerifyTransferSign, &sett.ServerSequencing, &sett.Registered, &sett.Closed, &sett.SweepTo, &sett.ProcessedAt, &ph, &dh, &sign, &key, &hash}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if err := decodeHex(&sett.PrevHash, ph); err != nil {
+		return nil, err
+	}
+	if err := decodeHex(&sett.DataHash, dh); err != nil {
+		return nil, err
+	}
+	if err := decodeHex(&sett.Sign, sign); err != nil {
+		return nil, err
+	}
+	if err := decodeHex(&sett.PublicKey, key); err != nil {
+		return nil, err
+	}
+	if err := decodeHex(&sett.Hash, hash); err != nil {
+		return nil, err
+	}
+	return &sett, nil
+}
+
+// scanTxns reads and closes txns rows
+func scanTxns(rows *sql.Rows) ([]*chainpb.Txn, error) {
+	defer rows.Close()
+
+	var txns []*chainpb.Txn
+	for rows.Next() {
+		txn, err := scanTxnRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		txns = append(txns, txn)
+	}
+
+	return txns, rows.Err()
+}
+
+// scanTxnRow reads txn columns, the following ones are scanned into extra
+func scanTxnRow(rows *sql.Rows, extra ...interface{}) (*chainpb.Txn, error) {
+	var txn chainpb.Txn
+	var ph, sign string
+	dest := []interface{}{&txn.ID, &txn.Sender, &txn.Receiver, &txn.Amount, &txn.Balance, &txn.SettingsId, &txn.SpentBy, &txn.ProcessedAt, &ph, &sign}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if err := decodeHex(&txn.PrevHash, ph); err != nil {
+		return nil, err
+	}
+	if err := decodeHex(&txn.Sign, sign); err != nil {
+		return nil, err
+	}
+	return &txn, nil
+}
+
+// GetStatement returns account balances at the period bounds, period totals and txns
+func (d *DB) GetStatement(ctx context.Context, req *plutodbpb.GetStatementRequest) (*plutodbpb.GetStatementResponse, error) {
+	resp := &plutodbpb.GetStatementResponse{Status: &plutodbpb.Status{}}
+
+	if req.ToTime != 0 && req.FromTime > req.ToTime {
+		resp.Status.Code = plutodbpb.DBStatusCode_BAD_REQUEST
+		resp.Status.Message = "from_time is after to_time"
+		return resp, nil
+	}
+
+	var err error
+	if req.FromTime != 0 {
+		resp.OpeningBalance, err = d.balanceAt(req.Account, req.FromTime)
 		if err != nil {
 			return nil, err
 		}
-		if err = decodeHex(&txn.PrevHash, ph); err != nil {
+	}
+
+	to := req.ToTime
+	if to == 0 {
+		to = math.MaxInt64
+	}
+	resp.ClosingBalance, err = d.balanceAt(req.Account, to)
+	if err != nil {
+		return nil, err
+	}
+
+	where, wargs := historyWhere(&plutodbpb.GetHistoryRequest{Account: req.Account, FromTime: req.FromTime, ToTime: req.ToTime})
+
+	union, args := historyUnion(`sender, receiver, amount`, where, wargs, "", nil, "")
+	q := `SELECT COALESCE(SUM(CASE WHEN sender = ? THEN amount ELSE 0 END), 0), COALESCE(SUM(CASE WHEN receiver = ? THEN amount ELSE 0 END), 0), COUNT(*) FROM (` + union + `) t`
+	err = d.queryRow(q, append([]interface{}{req.Account, req.Account}, args...)...).Scan(&resp.Debits, &resp.Credits, &resp.TxnCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.TotalsOnly {
+		return resp, nil
+	}
+
+	q, args = historyUnion(`id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign`, where, wargs, "", nil, "")
+	rows, err := d.query(q+` ORDER BY processed_at, sender, id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	resp.Txns, err = scanTxns(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetBalanceAt returns account balance right after outgoing txn or before the given time.
+// Balance is the last outgoing txn balance, incoming txns not spent by it are returned separately.
+func (d *DB) GetBalanceAt(ctx context.Context, req *plutodbpb.GetBalanceAtRequest) (*plutodbpb.GetBalanceAtResponse, error) {
+	resp := &plutodbpb.GetBalanceAtResponse{Status: &plutodbpb.Status{}}
+
+	if req.TxnId != 0 && req.Time != 0 {
+		resp.Status.Code = plutodbpb.DBStatusCode_BAD_REQUEST
+		resp.Status.Message = "either txn_id or time expected"
+		return resp, nil
+	}
+
+	var err error
+	t := req.Time
+	if req.TxnId != 0 {
+		var ts int64
+		err = d.queryRow(`SELECT id, balance, processed_at FROM txns WHERE sender = ? AND id = ?`, req.Account, req.TxnId).Scan(&resp.TxnId, &resp.Balance, &ts)
+		if err == sql.ErrNoRows {
+			resp.Status.Code = plutodbpb.DBStatusCode_BAD_REQUEST
+			resp.Status.Message = "txn not found"
+			return resp, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		t = ts + 1
+	} else {
+		if t == 0 {
+			t = math.MaxInt64
+		}
+		resp.TxnId, resp.Balance, err = d.lastOutgoing(req.Account, t)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp.PendingIncoming, err = d.pendingIncoming(req.Account, t, resp.TxnId)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetPendingIncoming returns account incoming txns not spent yet, oldest first
+func (d *DB) GetPendingIncoming(ctx context.Context, req *plutodbpb.GetPendingIncomingRequest) (*plutodbpb.GetPendingIncomingResponse, error) {
+	rows, err := d.query(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign FROM txns WHERE receiver = ? AND spent_by = 0 ORDER BY processed_at, sender, id`, req.Account)
+	if err != nil {
+		return nil, err
+	}
+	txns, err := scanTxns(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &plutodbpb.GetPendingIncomingResponse{Status: &plutodbpb.Status{}, Txns: txns}, nil
+}
+
+// balanceAt returns account balance before the given time including pending incoming txns
+func (d *DB) balanceAt(acc uint64, t int64) (int64, error) {
+	last, balance, err := d.lastOutgoing(acc, t)
+	if err != nil {
+		return 0, err
+	}
+
+	pending, err := d.pendingIncoming(acc, t, last)
+	if err != nil {
+		return 0, err
+	}
+
+	return balance + pending, nil
+}
+
+// lastOutgoing returns id and balance of the last account outgoing txn processed before t
+func (d *DB) lastOutgoing(acc uint64, t int64) (id uint64, balance int64, err error) {
+	err = d.queryRow(`SELECT id, balance FROM txns WHERE sender = ? AND processed_at < ? ORDER BY processed_at DESC, id DESC LIMIT 1`, acc, t).Scan(&id, &balance)
+	if err == sql.ErrNoRows {
+		return 0, 0, nil
+	}
+	return id, balance, err
+}
+
+// pendingIncoming returns sum of incoming txns processed before t and not spent by outgoing txn last
+func (d *DB) pendingIncoming(acc uint64, t int64, last uint64) (int64, error) {
+	var sum int64
+	err := d.queryRow(`SELECT COALESCE(SUM(amount), 0) FROM txns WHERE receiver = ? AND processed_at < ? AND (spent_by = 0 OR spent_by > ?)`, acc, t, last).Scan(&sum)
+	return sum, err
+}
+
+func hasHistoryFilters(req *plutodbpb.GetHistoryRequest) bool {
+	return req.FromTime != 0 || req.ToTime != 0 || req.Direction != plutodbpb.HistoryDirection_ALL ||
+		req.Counterparty != 0 || req.MinAmount != 0 || req.MaxAmount != 0
+}
+
+// historyWhere builds WHERE conditions and their args for history request filters.
+// Account txns are matched by sender and by receiver in separate conditions
+// (not by OR) so that sender_time and receiver_time indexes could be used. Query is a UNION ALL of them then.
+func historyWhere(req *plutodbpb.GetHistoryRequest) ([]string, [][]interface{}) {
+	var where []string
+	var args [][]interface{}
+	branch := func(c string, a ...interface{}) {
+		where = append(where, c)
+		args = append(args, a)
+	}
+
+	switch req.Direction {
+	case plutodbpb.HistoryDirection_INCOMING:
+		if req.Counterparty != 0 {
+			branch("receiver = ? AND sender = ?", req.Account, req.Counterparty)
+		} else {
+			branch("receiver = ?", req.Account)
+		}
+	case plutodbpb.HistoryDirection_OUTGOING:
+		if req.Counterparty != 0 {
+			branch("sender = ? AND receiver = ?", req.Account, req.Counterparty)
+		} else {
+			branch("sender = ?", req.Account)
+		}
+	default:
+		switch req.Counterparty {
+		case 0:
+			branch("sender = ?", req.Account)
+			// self transfers are taken by the first branch
+			branch("receiver = ? AND sender <> ?", req.Account, req.Account)
+		case req.Account:
+			branch("sender = ? AND receiver = ?", req.Account, req.Account)
+		default:
+			branch("sender = ? AND receiver = ?", req.Account, req.Counterparty)
+			branch("receiver = ? AND sender = ?", req.Account, req.Counterparty)
+		}
+	}
+
+	var conds []string
+	var cargs []interface{}
+	add := func(c string, a interface{}) {
+		conds = append(conds, c)
+		cargs = append(cargs, a)
+	}
+
+	if req.FromTime != 0 {
+		add("processed_at >= ?", req.FromTime)
+	}
+	if req.ToTime != 0 {
+		add("processed_at < ?", req.ToTime)
+	}
+	if req.MinAmount != 0 {
+		add("amount >= ?", req.MinAmount)
+	}
+	if req.MaxAmount != 0 {
+		add("amount <= ?", req.MaxAmount)
+	}
+
+	for i := range where {
+		if len(conds) != 0 {
+			where[i] += " AND " + strings.Join(conds, " AND ")
+		}
+		args[i] = append(args[i], cargs...)
+	}
+
+	return where, args
+}
+
+// historyUnion builds UNION ALL query of txns cols matching any of where conditions.
+// extra condition (if any) is added to each of them, tail (ORDER BY, LIMIT) is applied to each subquery.
+func historyUnion(cols string, where []string, args [][]interface{}, extra string, extraArgs []interface{}, tail string) (string, []interface{}) {
+	var qs []string
+	var qargs []interface{}
+	for i, w := range where {
+		if extra != "" {
+			w += " AND " + extra
+		}
+		q := "SELECT " + cols + " FROM txns WHERE " + w
+		if tail != "" {
+			q += " " + tail
+		}
+		qs = append(qs, "("+q+")")
+		qargs = append(qargs, args[i]...)
+		qargs = append(qargs, extraArgs...)
+	}
+
+	return strings.Join(qs, " UNION ALL "), qargs
+}
+
+// GetReplicaLag returns replication lag of the database
+func (d *DB) GetReplicaLag(ctx context.Context, req *plutodbpb.GetReplicaLagRequest) (*plutodbpb.GetReplicaLagResponse, error) {
+	lag, err := d.dl.Lag(ctx, d.c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &plutodbpb.GetReplicaLagResponse{Status: &plutodbpb.Status{}, Lag: lag.Nanoseconds()}, nil
+}
+
+// txnMultiBatch limits number of ids looked up by one query
+const txnMultiBatch = 500
+
+// GetTxnMulti returns txns in the order of requested ids.
+// Not found txns are zero ones marked in Found.
+func (d *DB) GetTxnMulti(ctx context.Context, req *plutodbpb.GetTxnMultiRequest) (*plutodbpb.GetTxnMultiResponse, error) {
+	got := make(map[pt.TxnID]*chainpb.Txn, len(req.IDs))
+	for i := 0; i < len(req.IDs); i += txnMultiBatch {
+		ids := req.IDs[i:]
+		if len(ids) > txnMultiBatch {
+			ids = ids[:txnMultiBatch]
+		}
+
+		conds := make([]string, len(ids))
+		args := make([]interface{}, 0, 2*len(ids))
+		for j, id := range ids {
+			conds[j] = "(sender = ? AND id = ?)"
+			args = append(args, id.Account, id.ID)
+		}
+
+		rows, err := d.query(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign FROM txns WHERE `+strings.Join(conds, " OR "), args...)
+		if err != nil {
 			return nil, err
 		}
-		if err = decodeHex(&txn.Sign, sign); err != nil {
+		txns, err := scanTxns(rows)
+		if err != nil {
 			return nil, err
 		}
-		txns[i] = &txn
+		for _, txn := range txns {
+			got[pt.NewTxnID(pt.AccID(txn.Sender), pt.ID(txn.ID))] = txn
+		}
 	}
 
-	resp.Txns = txns
+	resp := &plutodbpb.GetTxnMultiResponse{
+		Status: &plutodbpb.Status{},
+		Txns:   make([]*chainpb.Txn, len(req.IDs)),
+		Found:  make([]bool, len(req.IDs)),
+	}
+	for i, id := range req.IDs {
+		txn, ok := got[pt.NewTxnID(pt.AccID(id.Account), pt.ID(id.ID))]
+		if !ok {
+			txn = &chainpb.Txn{}
+		}
+		resp.Txns[i] = txn
+		resp.Found[i] = ok
+	}
 
 	return resp, nil
 }
 
+// maxChanges limits number of changes returned by StreamChanges
+const maxChanges = 1000
+
+// StreamChanges returns txns and settings stored after the cursor in commit order.
+// Only changes up to the last committed sequence number are read, so txns and settings
+// committed between the two queries are not mixed in and skipped by the next cursor.
+// Txn is streamed again with the new seq when its spent_by is updated, the latest change wins.
+// Changes stored before the sequence was introduced are numbered by migration after the ones stored since.
+func (d *DB) StreamChanges(ctx context.Context, req *plutodbpb.StreamChangesRequest) (*plutodbpb.StreamChangesResponse, error) {
+	limit := req.Limit
+	if limit == 0 || limit > maxChanges {
+		limit = maxChanges
+	}
+
+	var last uint64
+	if err := d.queryRow(`SELECT seq FROM change_seq`).Scan(&last); err != nil {
+		return nil, errors.Wrap(err, "get change_seq")
+	}
+
+	resp := &plutodbpb.StreamChangesResponse{Status: &plutodbpb.Status{}, Cursor: req.Cursor}
+	if last <= req.Cursor {
+		return resp, nil
+	}
+
+	rows, err := d.query(fmt.Sprintf(`SELECT id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign, seq FROM txns
+						WHERE seq > ? AND seq <= ? ORDER BY seq LIMIT %d`, limit), req.Cursor, last)
+	if err != nil {
+		return nil, err
+	}
+	txns, err := scanChanges(rows, func(seq *uint64) (*plutodbpb.Change, error) {
+		txn, err := scanTxnRow(rows, seq)
+		return &plutodbpb.Change{Txn: txn}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = d.query(fmt.Sprintf(`SELECT id, account, verify_transfer_sign, server_sequencing, registered, closed, sweep_to, processed_at, prev_hash, data_hash, sign, public_key, hash, seq FROM sett
+						WHERE seq > ? AND seq <= ? ORDER BY seq LIMIT %d`, limit), req.Cursor, last)
+	if err != nil {
+		return nil, err
+	}
+	setts, err := scanChanges(rows, func(seq *uint64) (*plutodbpb.Change, error) {
+		sett, err := scanSettingsRow(rows, seq)
+		return &plutodbpb.Change{Settings: sett}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// merge by seq
+	for len(txns)+len(setts) != 0 && len(resp.Changes) < int(limit) {
+		if len(setts) == 0 || len(txns) != 0 && txns[0].Seq < setts[0].Seq {
+			resp.Changes = append(resp.Changes, txns[0])
+			txns = txns[1:]
+		} else {
+			resp.Changes = append(resp.Changes, setts[0])
+			setts = setts[1:]
+		}
+	}
+
+	if len(resp.Changes) == int(limit) {
+		// there could be more
+		resp.Cursor = resp.Changes[len(resp.Changes)-1].Seq
+	} else {
+		// skip sequence numbers taken by spent_by updates of stored txns
+		resp.Cursor = last
+	}
+
+	return resp, nil
+}
+
+// scanChanges reads and closes rows with scan setting seq
+func scanChanges(rows *sql.Rows, scan func(seq *uint64) (*plutodbpb.Change, error)) ([]*plutodbpb.Change, error) {
+	defer rows.Close()
+
+	var changes []*plutodbpb.Change
+	for rows.Next() {
+		var seq uint64
+		c, err := scan(&seq)
+		if err != nil {
+			return nil, err
+		}
+		c.Seq = seq
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
+
 func decodeHex(dst *[]byte, s string) error {
 	n := hex.DecodedLen(len(s))
 	*dst = make([]byte, n)
@@ -0,0 +1,374 @@
+package sqlchain
+
+import (
+	"context"
+	"database/sql/driver"
+	"strconv"
+	"strings"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/pagetoken"
+	"github.com/qiwitech/qdp/proto/chainpb"
+	"github.com/qiwitech/qdp/proto/plutodbpb"
+	"github.com/qiwitech/qdp/pt"
+)
+
+func TestHistoryWhere(t *testing.T) {
+	for _, tc := range []struct {
+		req   plutodbpb.GetHistoryRequest
+		where []string
+		args  [][]interface{}
+	}{
+		{
+			req:   plutodbpb.GetHistoryRequest{Account: 1},
+			where: []string{"sender = ?", "receiver = ? AND sender <> ?"},
+			args:  [][]interface{}{{uint64(1)}, {uint64(1), uint64(1)}},
+		},
+		{
+			req:   plutodbpb.GetHistoryRequest{Account: 1, Counterparty: 2, FromTime: 100, ToTime: 200},
+			where: []string{"sender = ? AND receiver = ? AND processed_at >= ? AND processed_at < ?", "receiver = ? AND sender = ? AND processed_at >= ? AND processed_at < ?"},
+			args:  [][]interface{}{{uint64(1), uint64(2), int64(100), int64(200)}, {uint64(1), uint64(2), int64(100), int64(200)}},
+		},
+		{
+			req:   plutodbpb.GetHistoryRequest{Account: 1, Counterparty: 1},
+			where: []string{"sender = ? AND receiver = ?"},
+			args:  [][]interface{}{{uint64(1), uint64(1)}},
+		},
+		{
+			req:   plutodbpb.GetHistoryRequest{Account: 1, Direction: plutodbpb.HistoryDirection_INCOMING, MinAmount: 10},
+			where: []string{"receiver = ? AND amount >= ?"},
+			args:  [][]interface{}{{uint64(1), int64(10)}},
+		},
+		{
+			req:   plutodbpb.GetHistoryRequest{Account: 1, Direction: plutodbpb.HistoryDirection_OUTGOING, Counterparty: 3, MaxAmount: 50},
+			where: []string{"sender = ? AND receiver = ? AND amount <= ?"},
+			args:  [][]interface{}{{uint64(1), uint64(3), int64(50)}},
+		},
+	} {
+		where, args := historyWhere(&tc.req)
+		assert.Equal(t, tc.where, where)
+		assert.Equal(t, tc.args, args)
+	}
+
+	assert.False(t, hasHistoryFilters(&plutodbpb.GetHistoryRequest{Account: 1, Limit: 10, Token: "t"}))
+	assert.True(t, hasHistoryFilters(&plutodbpb.GetHistoryRequest{Account: 1, Direction: plutodbpb.HistoryDirection_OUTGOING}))
+}
+
+func TestHistoryUnion(t *testing.T) {
+	where, args := historyWhere(&plutodbpb.GetHistoryRequest{Account: 1, MinAmount: 10})
+
+	q, qargs := historyUnion("id", where, args, "id < ?", []interface{}{5}, "ORDER BY id DESC LIMIT 2")
+	assert.Equal(t, "(SELECT id FROM txns WHERE sender = ? AND amount >= ? AND id < ? ORDER BY id DESC LIMIT 2) UNION ALL "+
+		"(SELECT id FROM txns WHERE receiver = ? AND sender <> ? AND amount >= ? AND id < ? ORDER BY id DESC LIMIT 2)", q)
+	assert.Equal(t, []interface{}{uint64(1), int64(10), 5, uint64(1), uint64(1), int64(10), 5}, qargs)
+
+	// no OR between sender and receiver, so both indexes are usable
+	assert.NotContains(t, q, " OR ")
+}
+
+func TestGetHistoryInvalidToken(t *testing.T) {
+	d := &DB{}
+
+	for _, req := range []*plutodbpb.GetHistoryRequest{
+		{Account: 1, Token: "bad"},
+		{Account: 2, Token: (&pagetoken.Token{Account: 1}).String()},
+		{Account: 1, Token: (&pagetoken.Token{Account: 1}).String(), MinAmount: 1},
+		{Account: 1, Token: (&pagetoken.Token{Account: 1, Filtered: true}).String()},
+	} {
+		resp, err := d.GetHistory(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, plutodbpb.DBStatusCode_INVALID_TOKEN, resp.Status.Code)
+	}
+}
+
+func TestGetBalanceAtBadRequest(t *testing.T) {
+	d := &DB{}
+
+	resp, err := d.GetBalanceAt(context.TODO(), &plutodbpb.GetBalanceAtRequest{Account: 1, TxnId: 2, Time: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, plutodbpb.DBStatusCode_BAD_REQUEST, resp.Status.Code)
+}
+
+func TestGetSettingsHistoryInvalidToken(t *testing.T) {
+	d := &DB{}
+
+	for _, req := range []*plutodbpb.GetSettingsHistoryRequest{
+		{Account: 1, Token: "bad"},
+		{Account: 1, Token: (&pagetoken.Token{Account: 1, ID: 3}).String()},
+		{Account: 2, Token: (&pagetoken.Token{Account: 1, Settings: true, ID: 3}).String()},
+	} {
+		resp, err := d.GetSettingsHistory(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, plutodbpb.DBStatusCode_INVALID_TOKEN, resp.Status.Code)
+	}
+
+	resp, err := d.GetHistory(context.TODO(), &plutodbpb.GetHistoryRequest{Account: 1, Token: (&pagetoken.Token{Account: 1, Settings: true, ID: 3}).String()})
+	assert.NoError(t, err)
+	assert.Equal(t, plutodbpb.DBStatusCode_INVALID_TOKEN, resp.Status.Code)
+}
+
+// lastSeq answers change_seq queries
+func lastSeq(seq int64) func(q string, args []driver.Value) [][]driver.Value {
+	return func(q string, args []driver.Value) [][]driver.Value {
+		if strings.Contains(q, "change_seq") {
+			return [][]driver.Value{{seq}}
+		}
+		return nil
+	}
+}
+
+// fakeTxns is a txns table answering simple `col = ? AND col = 0` queries
+type fakeTxns [][]driver.Value
+
+var fakeTxnsCols = map[string]int{"id": 0, "sender": 1, "receiver": 2, "spent_by": 6}
+
+func (tb fakeTxns) query(q string, args []driver.Value) [][]driver.Value {
+	where := q[strings.Index(q, " WHERE ")+len(" WHERE "):]
+	if i := strings.Index(where, " ORDER BY"); i != -1 {
+		where = where[:i]
+	}
+
+	var res [][]driver.Value
+rows:
+	for _, r := range tb {
+		a := 0
+		for _, c := range strings.Split(where, " AND ") {
+			f := strings.Split(c, " = ")
+			var v driver.Value
+			if strings.HasPrefix(f[1], "$") || f[1] == "?" {
+				v = args[a]
+				a++
+				if str, ok := v.(string); ok { // postgres uint64
+					v, _ = strconv.ParseInt(str, 10, 64)
+				}
+			} else {
+				n, _ := strconv.ParseInt(f[1], 10, 64)
+				v = n
+			}
+			if r[fakeTxnsCols[f[0]]] != v {
+				continue rows
+			}
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func TestFetchUnspentIncoming(t *testing.T) {
+	fd, c := newFakeDB("fetch")
+	d := &DB{c: c, dl: Postgres}
+
+	row := func(id, sender, receiver, spentBy int64) []driver.Value {
+		return []driver.Value{id, sender, receiver, int64(10), int64(0), int64(0), spentBy, int64(0), "", ""}
+	}
+	tb := fakeTxns{
+		row(1, 0, 20, 1), // spent by txn 20_1
+		row(2, 0, 20, 0), // unspent
+		row(1, 20, 30, 0),
+	}
+	fd.query = func(q string, args []driver.Value) [][]driver.Value {
+		if !strings.Contains(q, "FROM txns") {
+			return nil
+		}
+		return tb.query(q, args)
+	}
+
+	resp, err := d.Fetch(context.TODO(), &plutodbpb.FetchRequest{Account: 20, Limit: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Txns, 2) {
+		assert.Equal(t, &chainpb.Txn{ID: 1, Sender: 20, Receiver: 30, Amount: 10, PrevHash: []byte{}, Sign: []byte{}}, resp.Txns[0])
+		assert.Equal(t, &chainpb.Txn{ID: 2, Sender: 0, Receiver: 20, Amount: 10, PrevHash: []byte{}, Sign: []byte{}}, resp.Txns[1])
+	}
+
+	// incoming txns have sender's ids which start from 1, so the former `id = 0` condition matched nothing
+	// and preloaded account lost its unspent incoming txns
+	assert.Empty(t, tb.query(`SELECT * FROM txns WHERE receiver = $1 AND id = 0`, []driver.Value{int64(20)}))
+	assert.Len(t, tb.query(`SELECT * FROM txns WHERE receiver = $1 AND spent_by = 0`, []driver.Value{int64(20)}), 1)
+}
+
+func TestPushRetriesDeadlock(t *testing.T) {
+	fd, c := newFakeDB("push_deadlock")
+	fd.query = lastSeq(5)
+	d := &DB{c: c, dl: MySQL}
+
+	// the second txn of the first attempt deadlocks
+	fd.fail = func(n int, args []driver.Value) error {
+		if n == 2 {
+			return &mysqldriver.MySQLError{Number: 1213, Message: "Deadlock found"}
+		}
+		return nil
+	}
+
+	err := d.Push(context.TODO(), []pt.Txn{{ID: 1, Sender: 1, Receiver: 2, Amount: 10}, {ID: 1, Sender: 3, Receiver: 1, Amount: 20, SpentBy: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fd.begins)
+	assert.Equal(t, 1, fd.rollbacks)
+	assert.Equal(t, 1, fd.commits)
+	if assert.Len(t, fd.committed, 3) {
+		assert.Equal(t, []driver.Value{int64(1), int64(1), int64(2), int64(10)}, fd.committed[0][:4])
+		assert.Equal(t, int64(6), fd.committed[0][11]) // seq
+		assert.Equal(t, int64(1), fd.committed[1][6])  // spent_by
+		assert.Equal(t, int64(7), fd.committed[1][11]) // seq of possibly updated txn
+		assert.Equal(t, []driver.Value{int64(7)}, fd.committed[2])
+	}
+	// updated spent_by moves txn to the new seq
+	assert.Contains(t, fd.stmts[len(fd.stmts)-2], "seq = IF(spent_by <> VALUES(spent_by), VALUES(seq), seq)")
+}
+
+func TestPushUnchanged(t *testing.T) {
+	fd, c := newFakeDB("push_unchanged")
+	fd.query = lastSeq(5)
+	d := &DB{c: c, dl: MySQL}
+
+	// the first txn is pushed again with the same spent_by
+	fd.unchanged = func(args []driver.Value) bool {
+		return len(args) > 1 && args[1] == int64(1)
+	}
+
+	err := d.Push(context.TODO(), []pt.Txn{{ID: 1, Sender: 1, SpentBy: 3}, {ID: 1, Sender: 2, Receiver: 1}})
+	assert.NoError(t, err)
+	if assert.Len(t, fd.committed, 2) {
+		assert.Equal(t, int64(6), fd.committed[0][11]) // seq isn't taken by unchanged txn
+		assert.Equal(t, []driver.Value{int64(6)}, fd.committed[1])
+	}
+}
+
+func TestPushRollback(t *testing.T) {
+	fd, c := newFakeDB("push_rollback")
+	fd.query = lastSeq(0)
+	d := &DB{c: c, dl: MySQL}
+
+	fd.fail = func(n int, args []driver.Value) error {
+		if n == 2 {
+			return errors.New("disk is full")
+		}
+		return nil
+	}
+
+	err := d.Push(context.TODO(), []pt.Txn{{ID: 1, Sender: 1}, {ID: 2, Sender: 1}})
+	assert.Error(t, err)
+	assert.Equal(t, 1, fd.begins)
+	assert.Equal(t, 1, fd.rollbacks)
+	assert.Empty(t, fd.committed)
+
+	// gives up after maxTxRetries deadlocks
+	fd.fail = func(n int, args []driver.Value) error {
+		return &mysqldriver.MySQLError{Number: 1213}
+	}
+	err = d.PushSettings(context.TODO(), &pt.Settings{Account: 1, ID: 1})
+	assert.Error(t, err)
+	assert.Equal(t, 1+maxTxRetries, fd.begins)
+	assert.Empty(t, fd.committed)
+}
+
+func TestGetTxnMulti(t *testing.T) {
+	fd, c := newFakeDB("txn_multi")
+	d := &DB{c: c, dl: Postgres}
+
+	row := func(sender, id int64) []driver.Value {
+		return []driver.Value{id, sender, int64(9), int64(10 * id), int64(0), int64(0), int64(0), int64(0), "", ""}
+	}
+	fd.query = func(q string, args []driver.Value) [][]driver.Value {
+		return [][]driver.Value{row(3, 2), row(1, 1)}
+	}
+
+	resp, err := d.GetTxnMulti(context.TODO(), &plutodbpb.GetTxnMultiRequest{IDs: []*chainpb.TxnID{{Account: 1, ID: 1}, {Account: 2, ID: 5}, {Account: 3, ID: 2}, {Account: 1, ID: 1}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true, true}, resp.Found)
+	if assert.Len(t, resp.Txns, 4) {
+		assert.Equal(t, int64(10), resp.Txns[0].Amount)
+		assert.Equal(t, &chainpb.Txn{}, resp.Txns[1])
+		assert.Equal(t, uint64(3), resp.Txns[2].Sender)
+		assert.Equal(t, int64(20), resp.Txns[2].Amount)
+		assert.Equal(t, resp.Txns[0], resp.Txns[3])
+	}
+
+	// single query
+	if assert.Len(t, fd.queries, 1) {
+		assert.Contains(t, fd.queries[0], "WHERE (sender = $1 AND id = $2) OR (sender = $3 AND id = $4) OR")
+	}
+
+	// batched
+	fd.queries = nil
+	ids := make([]*chainpb.TxnID, txnMultiBatch+1)
+	for i := range ids {
+		ids[i] = &chainpb.TxnID{Account: 1, ID: uint64(i + 1)}
+	}
+	resp, err = d.GetTxnMulti(context.TODO(), &plutodbpb.GetTxnMultiRequest{IDs: ids})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Found, txnMultiBatch+1)
+	assert.Len(t, fd.queries, 2)
+}
+
+func TestGetTxnMultiQuery(t *testing.T) {
+	fd, c := newFakeDB("txn_multi_query")
+	d := &DB{c: c, dl: Postgres}
+
+	var qargs []driver.Value
+	fd.query = func(q string, args []driver.Value) [][]driver.Value {
+		qargs = args
+		return nil
+	}
+
+	// sender and id are paired in each condition, matching is checked against real database in TestPostgres
+	resp, err := d.GetTxnMulti(context.TODO(), &plutodbpb.GetTxnMultiRequest{IDs: []*chainpb.TxnID{{Account: 1, ID: 3}, {Account: 3, ID: 1}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false, false}, resp.Found)
+	if assert.Len(t, fd.queries, 1) {
+		assert.Contains(t, fd.queries[0], "WHERE (sender = $1 AND id = $2) OR (sender = $3 AND id = $4)")
+	}
+	assert.Equal(t, []driver.Value{"1", "3", "3", "1"}, qargs)
+}
+
+func TestStreamChanges(t *testing.T) {
+	fd, c := newFakeDB("stream_changes")
+	d := &DB{c: c, dl: MySQL}
+
+	txn := func(id, seq int64) []driver.Value {
+		return []driver.Value{id, int64(1), int64(2), int64(10), int64(-10 * id), int64(0), int64(0), int64(0), "", "", seq}
+	}
+	fd.query = func(q string, args []driver.Value) [][]driver.Value {
+		switch {
+		case strings.Contains(q, "FROM change_seq"):
+			return [][]driver.Value{{int64(10)}}
+		case strings.Contains(q, "FROM txns"):
+			return [][]driver.Value{txn(1, 2), txn(2, 5), txn(3, 6)}
+		case strings.Contains(q, "FROM sett"):
+			return [][]driver.Value{{int64(1), int64(1), false, false, true, false, int64(0), int64(0), "", "", "", "", "", int64(3)}}
+		}
+		return nil
+	}
+
+	resp, err := d.StreamChanges(context.TODO(), &plutodbpb.StreamChangesRequest{Cursor: 1})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Changes, 4) {
+		var seqs []uint64
+		for _, ch := range resp.Changes {
+			seqs = append(seqs, ch.Seq)
+		}
+		assert.Equal(t, []uint64{2, 3, 5, 6}, seqs)
+		assert.Equal(t, uint64(1), resp.Changes[0].Txn.ID)
+		assert.True(t, resp.Changes[1].Settings.Registered)
+	}
+	// gaps up to the last seq are skipped
+	assert.Equal(t, uint64(10), resp.Cursor)
+	assert.Contains(t, fd.queries, "SELECT id, sender, receiver, amount, balance, settings_id, spent_by, processed_at, prev_hash, sign, seq FROM txns\n\t\t\t\t\t\tWHERE seq > ? AND seq <= ? ORDER BY seq LIMIT 1000")
+
+	// there could be more
+	resp, err = d.StreamChanges(context.TODO(), &plutodbpb.StreamChangesRequest{Cursor: 1, Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Changes, 2)
+	assert.Equal(t, uint64(3), resp.Cursor)
+
+	// up to date
+	fd.queries = nil
+	resp, err = d.StreamChanges(context.TODO(), &plutodbpb.StreamChangesRequest{Cursor: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Changes)
+	assert.Equal(t, uint64(10), resp.Cursor)
+	assert.Len(t, fd.queries, 1)
+}
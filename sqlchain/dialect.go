@@ -0,0 +1,229 @@
+package sqlchain
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Dialect covers SQL differences of supported databases.
+// Queries are written with ? placeholders and rebound by dialect.
+type Dialect interface {
+	// Name is a driver name used with sql.Open
+	Name() string
+	// DDL expands {uint64} and {blob} column types of schema statement
+	DDL(q string) string
+	// ColumnQuery counts columns by table and column name in the current schema
+	ColumnQuery() string
+	// IndexQuery counts indexes by table and index name in the current schema
+	IndexQuery() string
+	// IndexName returns index name as it's created in database
+	IndexName(table, index string) string
+	// Rebind converts ? placeholders to dialect ones
+	Rebind(q string) string
+	// Args converts query args to values driver accepts
+	Args(args []interface{}) []interface{}
+	// UpsertTxns is appended to txns INSERT to update spent_by of already stored txns.
+	// Changed txns get the new seq, so they are streamed again. Unchanged ones must not be counted as affected rows.
+	UpsertTxns() string
+	// InsertData returns statement storing (hash, data) into sett_data unless it's there
+	InsertData() string
+	// Deadlock reports whether transaction was aborted due to deadlock and could be retried
+	Deadlock(err error) bool
+	// Lag returns replication lag, it's zero on primary
+	Lag(ctx context.Context, c *sql.DB) (time.Duration, error)
+}
+
+var (
+	MySQL    Dialect = mysql{}
+	Postgres Dialect = postgres{}
+)
+
+// DialectByName returns dialect by its driver name
+func DialectByName(name string) (Dialect, error) {
+	switch name {
+	case "mysql":
+		return MySQL, nil
+	case "postgres":
+		return Postgres, nil
+	default:
+		return nil, errors.Errorf("unsupported dialect %q", name)
+	}
+}
+
+type mysql struct{}
+
+func (mysql) Name() string { return "mysql" }
+
+var mysqlTypes = strings.NewReplacer("{uint64}", "BIGINT UNSIGNED", "{blob}", "MEDIUMBLOB")
+
+func (mysql) DDL(q string) string { return mysqlTypes.Replace(q) }
+
+func (mysql) ColumnQuery() string {
+	return `SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?`
+}
+
+func (mysql) IndexQuery() string {
+	return `SELECT COUNT(DISTINCT index_name) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?`
+}
+
+func (mysql) IndexName(table, index string) string { return index }
+
+func (mysql) Rebind(q string) string { return q }
+
+// Args are passed as is, mysql driver accepts uint64 with the high bit set
+func (mysql) Args(args []interface{}) []interface{} { return args }
+
+// UpsertTxns sets seq first, assignments are evaluated left to right and see updated spent_by
+func (mysql) UpsertTxns() string {
+	return ` ON DUPLICATE KEY UPDATE seq = IF(spent_by <> VALUES(spent_by), VALUES(seq), seq), spent_by = VALUES(spent_by)`
+}
+
+func (mysql) InsertData() string { return `INSERT IGNORE INTO sett_data (hash, data) VALUES (?, ?)` }
+
+// Deadlock checks for ER_LOCK_DEADLOCK and ER_LOCK_WAIT_TIMEOUT
+func (mysql) Deadlock(err error) bool {
+	e, ok := err.(*mysqldriver.MySQLError)
+	return ok && (e.Number == 1213 || e.Number == 1205)
+}
+
+// Lag reads seconds behind source from replica status, no status means it's not a replica.
+// SHOW REPLICA STATUS replaced SHOW SLAVE STATUS in 8.0.22.
+func (mysql) Lag(ctx context.Context, c *sql.DB) (time.Duration, error) {
+	rows, err := c.QueryContext(ctx, `SHOW REPLICA STATUS`)
+	if err != nil {
+		rows, err = c.QueryContext(ctx, `SHOW SLAVE STATUS`)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return 0, rows.Err()
+	}
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	vals := make([]sql.NullString, len(cols))
+	ptrs := make([]interface{}, len(cols))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	if err = rows.Scan(ptrs...); err != nil {
+		return 0, err
+	}
+
+	for i, col := range cols {
+		if col != "Seconds_Behind_Source" && col != "Seconds_Behind_Master" {
+			continue
+		}
+		if !vals[i].Valid {
+			return 0, errors.New("replication is not running")
+		}
+		sec, err := strconv.ParseInt(vals[i].String, 10, 64)
+		if err != nil {
+			return 0, errors.Wrap(err, "seconds behind")
+		}
+		return time.Duration(sec) * time.Second, nil
+	}
+
+	return 0, errors.New("no seconds behind in replica status")
+}
+
+// postgres has no unsigned types, so ids are stored as NUMERIC(20,0) to hold the whole uint64 range
+type postgres struct{}
+
+func (postgres) Name() string { return "postgres" }
+
+var postgresTypes = strings.NewReplacer("{uint64}", "NUMERIC(20,0)", "{blob}", "BYTEA")
+
+func (postgres) DDL(q string) string { return postgresTypes.Replace(q) }
+
+func (postgres) ColumnQuery() string {
+	return `SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?`
+}
+
+func (postgres) IndexQuery() string {
+	return `SELECT COUNT(*) FROM pg_indexes WHERE schemaname = current_schema() AND tablename = ? AND indexname = ?`
+}
+
+// IndexName is prefixed by table as postgres index names are unique within schema
+func (postgres) IndexName(table, index string) string { return table + "_" + index }
+
+// Rebind replaces ? with $1, $2, ... Queries don't contain ? inside literals.
+func (postgres) Rebind(q string) string {
+	n := strings.Count(q, "?")
+	if n == 0 {
+		return q
+	}
+
+	var b strings.Builder
+	b.Grow(len(q) + n*2)
+	i := 0
+	for _, c := range q {
+		if c != '?' {
+			b.WriteRune(c)
+			continue
+		}
+		i++
+		b.WriteByte('$')
+		b.WriteString(strconv.Itoa(i))
+	}
+
+	return b.String()
+}
+
+// Args passes uint64 as decimal strings since database/sql rejects uint64 with the high bit set.
+// They are scanned back to uint64 as is.
+func (postgres) Args(args []interface{}) []interface{} {
+	var res []interface{}
+	for i, a := range args {
+		u, ok := a.(uint64)
+		if !ok {
+			continue
+		}
+		if res == nil {
+			res = append([]interface{}{}, args...)
+		}
+		res[i] = strconv.FormatUint(u, 10)
+	}
+	if res == nil {
+		return args
+	}
+	return res
+}
+
+// UpsertTxns skips unchanged rows by WHERE, so they are not affected
+func (postgres) UpsertTxns() string {
+	return ` ON CONFLICT (sender, id) DO UPDATE SET spent_by = EXCLUDED.spent_by, seq = EXCLUDED.seq
+						WHERE txns.spent_by <> EXCLUDED.spent_by`
+}
+
+func (postgres) InsertData() string {
+	return `INSERT INTO sett_data (hash, data) VALUES (?, ?) ON CONFLICT (hash) DO NOTHING`
+}
+
+// Deadlock checks for deadlock_detected and serialization_failure
+func (postgres) Deadlock(err error) bool {
+	e, ok := err.(*pq.Error)
+	return ok && (e.Code == "40P01" || e.Code == "40001")
+}
+
+// Lag is the age of the last replayed transaction unless all received wal is replayed
+func (postgres) Lag(ctx context.Context, c *sql.DB) (time.Duration, error) {
+	var sec float64
+	err := c.QueryRowContext(ctx, `SELECT CASE
+		WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`).Scan(&sec)
+	return time.Duration(sec * float64(time.Second)), err
+}
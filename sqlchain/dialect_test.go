@@ -0,0 +1,191 @@
+package sqlchain
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiwitech/qdp/proto/chainpb"
+	"github.com/qiwitech/qdp/proto/plutodbpb"
+	"github.com/qiwitech/qdp/pt"
+)
+
+func TestRebind(t *testing.T) {
+	q := `SELECT id FROM txns WHERE sender = ? AND id < ? LIMIT 1`
+	assert.Equal(t, q, MySQL.Rebind(q))
+	assert.Equal(t, `SELECT id FROM txns WHERE sender = $1 AND id < $2 LIMIT 1`, Postgres.Rebind(q))
+	assert.Equal(t, `SELECT 1`, Postgres.Rebind(`SELECT 1`))
+
+	where, args := historyWhere(&plutodbpb.GetHistoryRequest{Account: 1, Counterparty: 2, MinAmount: 3})
+	q, _ = historyUnion("id", where, args, "", nil, "")
+	assert.Equal(t, "(SELECT id FROM txns WHERE sender = $1 AND receiver = $2 AND amount >= $3) UNION ALL (SELECT id FROM txns WHERE receiver = $4 AND sender = $5 AND amount >= $6)", Postgres.Rebind(q))
+}
+
+func TestArgs(t *testing.T) {
+	args := []interface{}{uint64(1<<63 + 1), int64(-1), "s"}
+	assert.Equal(t, args, MySQL.Args(args))
+	assert.Equal(t, []interface{}{"9223372036854775809", int64(-1), "s"}, Postgres.Args(args))
+	// not modified
+	assert.Equal(t, uint64(1<<63+1), args[0])
+
+	assert.Equal(t, "id NUMERIC(20,0) NOT NULL", Postgres.DDL("id {uint64} NOT NULL"))
+}
+
+func TestDialectByName(t *testing.T) {
+	d, err := DialectByName("mysql")
+	assert.NoError(t, err)
+	assert.Equal(t, MySQL, d)
+
+	d, err = DialectByName("postgres")
+	assert.NoError(t, err)
+	assert.Equal(t, Postgres, d)
+
+	_, err = DialectByName("sqlite")
+	assert.Error(t, err)
+}
+
+func TestDeadlock(t *testing.T) {
+	assert.True(t, MySQL.Deadlock(&mysqldriver.MySQLError{Number: 1213}))
+	assert.False(t, MySQL.Deadlock(&mysqldriver.MySQLError{Number: 1062}))
+	assert.False(t, MySQL.Deadlock(errors.New("deadlock")))
+
+	assert.True(t, Postgres.Deadlock(&pq.Error{Code: "40P01"}))
+	assert.False(t, Postgres.Deadlock(&pq.Error{Code: "23505"}))
+	assert.False(t, Postgres.Deadlock(&mysqldriver.MySQLError{Number: 1213}))
+}
+
+// TestPostgres runs against local postgres, set SQLCHAIN_POSTGRES to its dsn to enable it.
+// Tables are dropped and created again.
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv("SQLCHAIN_POSTGRES")
+	if dsn == "" {
+		t.Skip("SQLCHAIN_POSTGRES is not set")
+	}
+
+	c, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	for _, tb := range []string{"txns", "sett", "sett_data", "change_seq", "schema_version"} {
+		if _, err = c.Exec(`DROP TABLE IF EXISTS ` + tb); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d, err := NewDialect(c, Postgres)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// twice to check schema is idempotent
+	d, err = NewDialect(c, Postgres)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+	assert.NoError(t, d.Push(ctx, []pt.Txn{
+		{ID: 1, Sender: 0, Receiver: 20, Amount: 1000, Balance: -1000, ProcessedAt: 1},
+		{ID: 2, Sender: 0, Receiver: 20, Amount: 500, Balance: -1500, ProcessedAt: 2},
+	}))
+	// spent_by update of already stored txn
+	assert.NoError(t, d.Push(ctx, []pt.Txn{
+		{ID: 1, Sender: 0, Receiver: 20, Amount: 1000, Balance: -1000, ProcessedAt: 1, SpentBy: 1},
+		{ID: 1, Sender: 20, Receiver: 30, Amount: 100, Balance: 900, ProcessedAt: 3},
+	}))
+
+	// ids with the high bit set don't fit into BIGINT and are rejected by database/sql as uint64
+	big := pt.AccID(1<<63 + 1)
+	assert.NoError(t, d.Push(ctx, []pt.Txn{
+		{ID: 1, Sender: big, Receiver: big + 1, Amount: 7, Balance: -7, ProcessedAt: 1},
+	}))
+	bresp, err := d.Fetch(ctx, &plutodbpb.FetchRequest{Account: uint64(big + 1), Limit: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, bresp.Txns, 1) {
+		assert.Equal(t, uint64(big), bresp.Txns[0].Sender)
+		assert.Equal(t, uint64(big+1), bresp.Txns[0].Receiver)
+	}
+	bhresp, err := d.GetHistory(ctx, &plutodbpb.GetHistoryRequest{Account: uint64(big), Counterparty: uint64(big + 1)})
+	assert.NoError(t, err)
+	assert.Len(t, bhresp.Txns, 1)
+
+	fresp, err := d.Fetch(ctx, &plutodbpb.FetchRequest{Account: 20, Limit: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, fresp.Txns, 2) {
+		assert.Equal(t, uint64(20), fresp.Txns[0].Sender)
+		assert.Equal(t, uint64(2), fresp.Txns[1].ID)
+	}
+
+	hresp, err := d.GetHistory(ctx, &plutodbpb.GetHistoryRequest{Account: 20, Direction: plutodbpb.HistoryDirection_INCOMING, MinAmount: 600})
+	assert.NoError(t, err)
+	if assert.Len(t, hresp.Txns, 1) {
+		assert.Equal(t, uint64(1), hresp.Txns[0].SpentBy)
+	}
+
+	sresp, err := d.GetStatement(ctx, &plutodbpb.GetStatementRequest{Account: 20, TotalsOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1400), sresp.ClosingBalance)
+	assert.Equal(t, int64(1500), sresp.Credits)
+
+	assert.NoError(t, d.PushSettings(ctx, &pt.Settings{Account: 20, ID: 1, PublicKey: pt.PublicKey{1}, VerifyTransferSign: true}))
+	assert.Error(t, d.PushSettings(ctx, &pt.Settings{Account: 20, ID: 1}))
+
+	cresp, err := d.StreamChanges(ctx, &plutodbpb.StreamChangesRequest{})
+	assert.NoError(t, err)
+	// spent_by update moves txn 0/1 to seq 3, txn 0/2 keeps seq 2
+	if assert.Len(t, cresp.Changes, 5) {
+		assert.Equal(t, uint64(2), cresp.Changes[0].Seq)
+		assert.Equal(t, uint64(2), cresp.Changes[0].Txn.ID)
+		assert.Equal(t, uint64(3), cresp.Changes[1].Seq)
+		assert.Equal(t, uint64(1), cresp.Changes[1].Txn.ID)
+		assert.Equal(t, uint64(1), cresp.Changes[1].Txn.SpentBy)
+		assert.Equal(t, uint64(4), cresp.Changes[2].Seq)
+		assert.Equal(t, uint64(20), cresp.Changes[2].Txn.Sender)
+		assert.Equal(t, uint64(5), cresp.Changes[3].Seq)
+		assert.Equal(t, uint64(big), cresp.Changes[3].Txn.Sender)
+		assert.Equal(t, uint64(6), cresp.Changes[4].Seq)
+		assert.NotNil(t, cresp.Changes[4].Settings)
+	}
+	assert.Equal(t, uint64(6), cresp.Cursor)
+
+	shresp, err := d.GetSettingsHistory(ctx, &plutodbpb.GetSettingsHistoryRequest{Account: 20})
+	assert.NoError(t, err)
+	if assert.Len(t, shresp.Settings, 1) {
+		assert.True(t, shresp.Settings[0].Settings.VerifyTransferSign)
+	}
+
+	mresp, err := d.GetTxnMulti(ctx, &plutodbpb.GetTxnMultiRequest{IDs: []*chainpb.TxnID{{Account: 20, ID: 1}, {Account: 20, ID: 2}, {Account: 0, ID: 1}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, mresp.Found)
+	if assert.Len(t, mresp.Txns, 3) {
+		assert.Equal(t, int64(100), mresp.Txns[0].Amount)
+		assert.Equal(t, uint64(1), mresp.Txns[2].SpentBy)
+	}
+
+	// missing ids and ids ordered before stored ones, results follow request order
+	mresp, err = d.GetTxnMulti(ctx, &plutodbpb.GetTxnMultiRequest{IDs: []*chainpb.TxnID{{Account: 0, ID: 2}, {Account: 20, ID: 5}, {Account: 0, ID: 1}, {Account: uint64(big), ID: 1}, {Account: 0, ID: 3}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true, true, false}, mresp.Found)
+	if assert.Len(t, mresp.Txns, 5) {
+		assert.Equal(t, int64(500), mresp.Txns[0].Amount)
+		assert.Equal(t, &chainpb.Txn{}, mresp.Txns[1])
+		assert.Equal(t, int64(1000), mresp.Txns[2].Amount)
+		assert.Equal(t, int64(7), mresp.Txns[3].Amount)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = d.PutData(ctx, &plutodbpb.PutDataRequest{Data: []byte("profile")})
+		assert.NoError(t, err)
+	}
+	h := pt.GetDataHash([]byte("profile"))
+	gresp, err := d.GetData(ctx, &plutodbpb.GetDataRequest{Hash: h[:]})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("profile"), gresp.Data)
+}
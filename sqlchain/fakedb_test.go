@@ -0,0 +1,139 @@
+package sqlchain
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+)
+
+// fakeDriver records statements executed in committed transactions.
+// fail is called for each Exec and can abort it with an error.
+// unchanged makes Exec affect no rows as upsert of the same values does.
+// query returns rows for a query, queries are recorded separately from executed statements.
+type fakeDriver struct {
+	mu        sync.Mutex
+	fail      func(n int, args []driver.Value) error
+	unchanged func(args []driver.Value) bool
+	query     func(q string, args []driver.Value) [][]driver.Value
+	stmts     []string
+	queries   []string
+	execs     int
+	pending   [][]driver.Value
+	committed [][]driver.Value
+	begins    int
+	commits   int
+	rollbacks int
+}
+
+var fakeDrivers = map[string]*fakeDriver{}
+
+func newFakeDB(name string) (*fakeDriver, *sql.DB) {
+	d := &fakeDriver{}
+	fakeDrivers[name] = d
+	db, err := sql.Open("sqlchain_fake", name)
+	if err != nil {
+		panic(err)
+	}
+	return d, db
+}
+
+func init() {
+	sql.Register("sqlchain_fake", fakeConnector{})
+}
+
+type fakeConnector struct{}
+
+func (fakeConnector) Open(name string) (driver.Conn, error) {
+	return &fakeConn{d: fakeDrivers[name]}, nil
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c *fakeConn) Prepare(q string) (driver.Stmt, error) { return &fakeStmt{d: c.d, q: q}, nil }
+func (c *fakeConn) Close() error                          { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.begins++
+	c.d.pending = nil
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.commits++
+	c.d.committed = append(c.d.committed, c.d.pending...)
+	c.d.pending = nil
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.rollbacks++
+	c.d.pending = nil
+	return nil
+}
+
+type fakeStmt struct {
+	d *fakeDriver
+	q string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.execs++
+	s.d.stmts = append(s.d.stmts, s.q)
+	if s.d.fail != nil {
+		if err := s.d.fail(s.d.execs, args); err != nil {
+			return nil, err
+		}
+	}
+	if s.d.unchanged != nil && s.d.unchanged(args) {
+		return driver.RowsAffected(0), nil
+	}
+	s.d.pending = append(s.d.pending, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries = append(s.d.queries, s.q)
+	r := &fakeRows{}
+	if s.d.query != nil {
+		r.rows = s.d.query(s.q, args)
+	}
+	return r, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,262 @@
+package sqlchain
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var ErrSchemaTooNew = errors.New("database schema is newer than this binary supports")
+
+// migration is a schema change applied once. Its version is its position in migrations starting from 1.
+// Databases created before versioning have some changes applied already, so steps must be idempotent.
+type migration struct {
+	name  string
+	steps []step
+}
+
+type step func(ctx context.Context, tx *sql.Tx, dl Dialect) error
+
+// migrations are ordered schema changes. Append only, never edit applied ones.
+// Statements could use {uint64} and {blob} types which are expanded by dialect.
+var migrations = []migration{
+	{name: "initial", steps: []step{
+		execStep(`CREATE TABLE IF NOT EXISTS txns (
+		id          {uint64} NOT NULL,
+		sender      {uint64} NOT NULL,
+		receiver    {uint64},
+		amount      BIGINT,
+		balance     BIGINT,
+		settings_id {uint64},
+		spent_by    {uint64},
+		prev_hash   VARCHAR(64),
+		hash        VARCHAR(64),
+		sign        VARCHAR(250),
+		UNIQUE (sender, id)
+	)`),
+		execStep(`CREATE TABLE IF NOT EXISTS sett (
+		id          {uint64} NOT NULL,
+		account     {uint64} NOT NULL,
+		verify_transfer_sign BOOL,
+		prev_hash   VARCHAR(64),
+		data_hash   VARCHAR(64),
+		hash        VARCHAR(64),
+		sign        VARCHAR(250),
+		public_key  VARCHAR(250),
+		UNIQUE (account, id)
+	)`),
+	}},
+	{name: "sett server_sequencing", steps: []step{
+		addColumn("sett", "server_sequencing", "BOOL NOT NULL DEFAULT FALSE"),
+	}},
+	{name: "sett registration", steps: []step{
+		addColumn("sett", "registered", "BOOL NOT NULL DEFAULT FALSE"),
+		addColumn("sett", "closed", "BOOL NOT NULL DEFAULT FALSE"),
+		addColumn("sett", "sweep_to", "{uint64} NOT NULL DEFAULT 0"),
+	}},
+	{name: "processed_at", steps: []step{
+		addColumn("txns", "processed_at", "BIGINT NOT NULL DEFAULT 0"),
+		addColumn("sett", "processed_at", "BIGINT NOT NULL DEFAULT 0"),
+	}},
+	{name: "history indexes", steps: []step{
+		addIndex("txns", "sender_time", "sender, processed_at"),
+		addIndex("txns", "receiver_time", "receiver, processed_at"),
+	}},
+	{name: "sett_data", steps: []step{
+		execStep(`CREATE TABLE IF NOT EXISTS sett_data (
+		hash        VARCHAR(64) PRIMARY KEY,
+		data        {blob}
+	)`),
+	}},
+	{name: "pending incoming index", steps: []step{
+		addIndex("txns", "receiver_spent_by", "receiver, spent_by"),
+	}},
+	{name: "change sequence", steps: []step{
+		execStep(`CREATE TABLE IF NOT EXISTS change_seq (
+		seq         {uint64} NOT NULL
+	)`),
+		execStep(`INSERT INTO change_seq (seq) SELECT 0 FROM (SELECT COUNT(*) AS n FROM change_seq) c WHERE c.n = 0`),
+		addColumn("txns", "seq", "{uint64} NOT NULL DEFAULT 0"),
+		addColumn("sett", "seq", "{uint64} NOT NULL DEFAULT 0"),
+		addIndex("txns", "seq", "seq"),
+		addIndex("sett", "seq", "seq"),
+	}},
+	{name: "postgres numeric ids", steps: []step{
+		numericIDs("txns", "id", "sender", "receiver", "settings_id", "spent_by", "seq"),
+		numericIDs("sett", "id", "account", "sweep_to", "seq"),
+		numericIDs("change_seq", "seq"),
+	}},
+	{name: "backfill change sequence", steps: []step{
+		backfillSeq,
+	}},
+}
+
+// SchemaVersion is the latest schema version known to this binary
+func SchemaVersion() int {
+	return len(migrations)
+}
+
+// Migrate applies pending migrations each in its own transaction and returns schema versions before and after.
+// It returns ErrSchemaTooNew if database was migrated by a newer binary.
+func Migrate(ctx context.Context, c *sql.DB, dl Dialect) (from, to int, err error) {
+	_, err = c.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_version (
+		version     INT PRIMARY KEY,
+		name        VARCHAR(250),
+		applied_at  BIGINT
+	)`)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "create schema_version")
+	}
+
+	from, err = schemaVersion(ctx, c)
+	if err != nil {
+		return 0, 0, err
+	}
+	if from > len(migrations) {
+		return from, from, errors.Wrapf(ErrSchemaTooNew, "version %d, supported %d", from, len(migrations))
+	}
+
+	for v := from + 1; v <= len(migrations); v++ {
+		m := migrations[v-1]
+		err = tryTx(ctx, c, func(tx *sql.Tx) error {
+			for _, s := range m.steps {
+				if err := s(ctx, tx, dl); err != nil {
+					return err
+				}
+			}
+			_, err := tx.ExecContext(ctx, dl.Rebind(`INSERT INTO schema_version (version, name, applied_at) VALUES (?, ?, ?)`), v, m.name, time.Now().UnixNano())
+			return err
+		})
+		if err != nil {
+			return from, v - 1, errors.Wrapf(err, "migration %d %q", v, m.name)
+		}
+	}
+
+	return from, len(migrations), nil
+}
+
+func execStep(q string) step {
+	return func(ctx context.Context, tx *sql.Tx, dl Dialect) error {
+		_, err := tx.ExecContext(ctx, dl.DDL(q))
+		return err
+	}
+}
+
+// addColumn adds column unless it exists
+func addColumn(table, column, def string) step {
+	return func(ctx context.Context, tx *sql.Tx, dl Dialect) error {
+		var n int
+		err := tx.QueryRowContext(ctx, dl.Rebind(dl.ColumnQuery()), table, column).Scan(&n)
+		if err != nil || n != 0 {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, dl.DDL(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, def)))
+		return err
+	}
+}
+
+// addIndex creates index unless it exists
+func addIndex(table, index, columns string) step {
+	return func(ctx context.Context, tx *sql.Tx, dl Dialect) error {
+		var n int
+		err := tx.QueryRowContext(ctx, dl.Rebind(dl.IndexQuery()), table, dl.IndexName(table, index)).Scan(&n)
+		if err != nil || n != 0 {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX %s ON %s (%s)`, dl.IndexName(table, index), table, columns))
+		return err
+	}
+}
+
+// numericIDs converts postgres BIGINT columns created by older versions to NUMERIC(20,0) to hold the whole uint64 range.
+// mysql columns are unsigned already.
+func numericIDs(table string, columns ...string) step {
+	return func(ctx context.Context, tx *sql.Tx, dl Dialect) error {
+		if _, ok := dl.(postgres); !ok {
+			return nil
+		}
+
+		for _, c := range columns {
+			var n int
+			err := tx.QueryRowContext(ctx, dl.Rebind(`SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? AND column_name = ? AND data_type = 'numeric'`), table, c).Scan(&n)
+			if err != nil {
+				return err
+			}
+			if n != 0 {
+				continue
+			}
+
+			_, err = tx.ExecContext(ctx, dl.DDL(fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s TYPE {uint64}`, table, c)))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// backfillSeq assigns change sequence numbers to txns and settings stored before the sequence was introduced,
+// ordered by processed_at, account and id, so they are streamed too. They get numbers after already assigned ones.
+func backfillSeq(ctx context.Context, tx *sql.Tx, dl Dialect) error {
+	seq, err := lockSeq(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	type key struct {
+		table       string
+		account, id uint64
+	}
+	var keys []key
+
+	rows, err := tx.QueryContext(ctx, `SELECT 'txns' AS tbl, processed_at, sender, id FROM txns WHERE seq = 0
+		UNION ALL SELECT 'sett', processed_at, account, id FROM sett WHERE seq = 0
+		ORDER BY processed_at, sender, id, tbl`)
+	if err != nil {
+		return errors.Wrap(err, "select unsequenced")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var k key
+		var processedAt int64
+		if err := rows.Scan(&k.table, &processedAt, &k.account, &k.id); err != nil {
+			return errors.Wrap(err, "scan unsequenced")
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "select unsequenced")
+	}
+	rows.Close()
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	for _, k := range keys {
+		col := "sender"
+		if k.table == "sett" {
+			col = "account"
+		}
+		seq++
+		_, err = tx.ExecContext(ctx, dl.Rebind(fmt.Sprintf(`UPDATE %s SET seq = ? WHERE %s = ? AND id = ?`, k.table, col)), dl.Args([]interface{}{seq, k.account, k.id})...)
+		if err != nil {
+			return errors.Wrapf(err, "update %s seq", k.table)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, dl.Rebind(`UPDATE change_seq SET seq = ?`), dl.Args([]interface{}{seq})...)
+	return errors.Wrap(err, "update change_seq")
+}
+
+// schemaVersion returns current database schema version
+func schemaVersion(ctx context.Context, c *sql.DB) (v int, err error) {
+	err = c.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&v)
+	return v, errors.Wrap(err, "get schema version")
+}
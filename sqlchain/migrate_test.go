@@ -0,0 +1,163 @@
+package sqlchain
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// schemaQuery answers schema version and existence checks
+func schemaQuery(version int64, exists bool) func(q string, args []driver.Value) [][]driver.Value {
+	return func(q string, args []driver.Value) [][]driver.Value {
+		if strings.Contains(q, "schema_version") {
+			return [][]driver.Value{{version}}
+		}
+		if strings.Contains(q, "WHERE seq = 0") {
+			return nil
+		}
+		if exists {
+			return [][]driver.Value{{int64(1)}}
+		}
+		return [][]driver.Value{{int64(0)}}
+	}
+}
+
+func countPrefix(stmts []string, p string) int {
+	n := 0
+	for _, s := range stmts {
+		if strings.HasPrefix(s, p) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestMigrateFresh(t *testing.T) {
+	fd, c := newFakeDB("migrate_fresh")
+	fd.query = schemaQuery(0, false)
+
+	from, to, err := Migrate(context.TODO(), c, MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, from)
+	assert.Equal(t, SchemaVersion(), to)
+
+	assert.Equal(t, SchemaVersion(), countPrefix(fd.stmts, "INSERT INTO schema_version"))
+	assert.Equal(t, SchemaVersion(), fd.commits)
+	assert.Contains(t, fd.stmts, "ALTER TABLE sett ADD COLUMN sweep_to BIGINT UNSIGNED NOT NULL DEFAULT 0")
+	assert.Contains(t, fd.stmts, "CREATE INDEX sender_time ON txns (sender, processed_at)")
+	for _, s := range fd.stmts {
+		assert.NotContains(t, s, "{")
+	}
+}
+
+// versionBefore returns schema version the named migration is applied to
+func versionBefore(name string) int64 {
+	for i, m := range migrations {
+		if m.name == name {
+			return int64(i)
+		}
+	}
+	panic(name)
+}
+
+func TestMigratePostgresNumeric(t *testing.T) {
+	fd, c := newFakeDB("migrate_numeric")
+	fd.query = schemaQuery(versionBefore("postgres numeric ids"), false)
+
+	_, to, err := Migrate(context.TODO(), c, Postgres)
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion(), to)
+	assert.Contains(t, fd.stmts, "ALTER TABLE txns ALTER COLUMN sender TYPE NUMERIC(20,0)")
+	assert.Contains(t, fd.stmts, "ALTER TABLE change_seq ALTER COLUMN seq TYPE NUMERIC(20,0)")
+	assert.Equal(t, 11, countPrefix(fd.stmts, "ALTER TABLE"))
+
+	// mysql columns are unsigned already
+	fd, c = newFakeDB("migrate_numeric_mysql")
+	fd.query = schemaQuery(versionBefore("postgres numeric ids"), false)
+
+	_, _, err = Migrate(context.TODO(), c, MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, countPrefix(fd.stmts, "ALTER TABLE"))
+}
+
+func TestMigrateBackfillSeq(t *testing.T) {
+	fd, c := newFakeDB("migrate_backfill_seq")
+	fd.query = func(q string, args []driver.Value) [][]driver.Value {
+		switch {
+		case strings.Contains(q, "schema_version"):
+			return [][]driver.Value{{versionBefore("backfill change sequence")}}
+		case strings.Contains(q, "WHERE seq = 0"):
+			return [][]driver.Value{
+				{"txns", int64(1), int64(3), int64(1)},
+				{"sett", int64(2), int64(3), int64(1)},
+			}
+		case strings.Contains(q, "change_seq"):
+			return [][]driver.Value{{int64(5)}}
+		}
+		return nil
+	}
+
+	_, to, err := Migrate(context.TODO(), c, MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion(), to)
+	assert.Contains(t, fd.stmts, "UPDATE txns SET seq = ? WHERE sender = ? AND id = ?")
+	assert.Contains(t, fd.stmts, "UPDATE sett SET seq = ? WHERE account = ? AND id = ?")
+	if assert.Len(t, fd.committed, 4) {
+		assert.Equal(t, []driver.Value{int64(6), int64(3), int64(1)}, fd.committed[0])
+		assert.Equal(t, []driver.Value{int64(7), int64(3), int64(1)}, fd.committed[1])
+		assert.Equal(t, []driver.Value{int64(7)}, fd.committed[2]) // change_seq
+	}
+}
+
+func TestMigratePartial(t *testing.T) {
+	// created before versioning, columns and indexes exist already
+	fd, c := newFakeDB("migrate_partial")
+	fd.query = schemaQuery(0, true)
+
+	_, to, err := Migrate(context.TODO(), c, Postgres)
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion(), to)
+	assert.Equal(t, 0, countPrefix(fd.stmts, "ALTER TABLE"))
+	assert.Equal(t, 0, countPrefix(fd.stmts, "CREATE INDEX"))
+	assert.Equal(t, SchemaVersion(), countPrefix(fd.stmts, "INSERT INTO schema_version"))
+
+	// up to date
+	fd, c = newFakeDB("migrate_latest")
+	fd.query = schemaQuery(int64(SchemaVersion()), false)
+
+	from, to, err := Migrate(context.TODO(), c, Postgres)
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion(), from)
+	assert.Equal(t, SchemaVersion(), to)
+	assert.Equal(t, 1, len(fd.stmts)) // schema_version table
+}
+
+func TestMigrateTooNew(t *testing.T) {
+	fd, c := newFakeDB("migrate_too_new")
+	fd.query = schemaQuery(int64(SchemaVersion()+1), false)
+
+	_, err := NewDialect(c, MySQL)
+	assert.Equal(t, ErrSchemaTooNew, errors.Cause(err))
+	assert.Equal(t, 1, len(fd.stmts))
+}
+
+func TestMigrationFailure(t *testing.T) {
+	fd, c := newFakeDB("migrate_fail")
+	fd.query = schemaQuery(0, false)
+	fd.fail = func(n int, args []driver.Value) error {
+		if strings.HasPrefix(fd.stmts[len(fd.stmts)-1], "ALTER TABLE sett ADD COLUMN registered") {
+			return errors.New("no space left")
+		}
+		return nil
+	}
+
+	_, to, err := Migrate(context.TODO(), c, MySQL)
+	assert.Error(t, err)
+	assert.Equal(t, 2, to)
+	assert.Equal(t, 2, fd.commits)
+	assert.Equal(t, 1, fd.rollbacks)
+}